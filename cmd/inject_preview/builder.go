@@ -0,0 +1,112 @@
+package inject_preview
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/Dynatrace/dynatrace-operator/cmd/config"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	use = "inject-preview"
+
+	filenameFlagName          = "filename"
+	namespaceFlagName         = "namespace"
+	operatorNamespaceFlagName = "operator-namespace"
+	outputFlagName            = "output"
+	stdinFilenameValue        = "-"
+)
+
+var (
+	filenameFlagValue          string
+	namespaceFlagValue         string
+	operatorNamespaceFlagValue string
+	outputFlagValue            string
+)
+
+type CommandBuilder struct {
+	configProvider config.Provider
+}
+
+func NewCommandBuilder() CommandBuilder {
+	return CommandBuilder{}
+}
+
+func (builder CommandBuilder) SetConfigProvider(provider config.Provider) CommandBuilder {
+	builder.configProvider = provider
+
+	return builder
+}
+
+func (builder CommandBuilder) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  use,
+		Long: "show what the webhook would inject into a pod, without creating the pod or changing anything in the cluster. Requires the webhook.injectPreview value of the helm chart to be enabled",
+		RunE: builder.buildRun(),
+	}
+
+	cmd.Flags().StringVarP(&filenameFlagValue, filenameFlagName, "f", "", "file containing the pod to preview ('-' reads from stdin)")
+	cmd.Flags().StringVarP(&namespaceFlagValue, namespaceFlagName, "n", "", "namespace the pod would be created in (default the namespace of the pod)")
+	cmd.Flags().StringVar(&operatorNamespaceFlagValue, operatorNamespaceFlagName, env.DefaultNamespace(), "namespace the webhook is running in")
+	cmd.Flags().StringVarP(&outputFlagValue, outputFlagName, "o", yamlOutput, "output format, one of 'yaml' or 'json'")
+
+	_ = cmd.MarkFlagRequired(filenameFlagName)
+
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
+func (builder CommandBuilder) buildRun() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		if outputFlagValue != yamlOutput && outputFlagValue != jsonOutput {
+			return errors.Errorf("unsupported output format '%s'", outputFlagValue)
+		}
+
+		input, err := openInput(cmd.InOrStdin(), filenameFlagValue)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		previewRequest, err := decodePreviewRequest(input, namespaceFlagValue)
+		if err != nil {
+			return err
+		}
+
+		kubeConfig, err := builder.configProvider.GetConfig()
+		if err != nil {
+			return err
+		}
+
+		clientSet, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		response, err := requestPreview(context.Background(), clientSet.CoreV1().RESTClient(), operatorNamespaceFlagValue, previewRequest)
+		if err != nil {
+			return err
+		}
+
+		return writeResponse(cmd.OutOrStdout(), response, outputFlagValue)
+	}
+}
+
+func openInput(stdin io.Reader, filename string) (io.ReadCloser, error) {
+	if filename == stdinFilenameValue {
+		return io.NopCloser(stdin), nil
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,98 @@
+package inject_preview
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	yamlOutput = "yaml"
+	jsonOutput = "json"
+
+	// the webhook service has a single unnamed https port
+	webhookServicePort = "443"
+)
+
+func decodePreviewRequest(input io.Reader, namespace string) (pod.PreviewRequest, error) {
+	var previewPod corev1.Pod
+
+	err := k8syaml.NewYAMLOrJSONDecoder(input, 4096).Decode(&previewPod) //nolint:mnd
+	if err != nil {
+		return pod.PreviewRequest{}, errors.Wrap(err, "failed to decode pod")
+	}
+
+	if previewPod.Kind != "" && previewPod.Kind != "Pod" {
+		return pod.PreviewRequest{}, errors.Errorf("expected a Pod but got a %s", previewPod.Kind)
+	}
+
+	if namespace == "" && previewPod.Namespace == "" {
+		return pod.PreviewRequest{}, errors.New("the pod has no namespace, please set one using --namespace")
+	}
+
+	return pod.PreviewRequest{Pod: previewPod, Namespace: namespace}, nil
+}
+
+// requestPreview calls the inject-preview endpoint of the webhook through the service proxy of the API server, so it works from outside the cluster.
+// The endpoint is only served if it was enabled via the webhook.injectPreview value of the helm chart, as it doesn't authenticate its callers.
+func requestPreview(ctx context.Context, restClient rest.Interface, operatorNamespace string, previewRequest pod.PreviewRequest) (*pod.PreviewResponse, error) {
+	serviceProxyName := "https:" + dtwebhook.DeploymentName + ":" + webhookServicePort
+
+	body, err := json.Marshal(previewRequest)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	raw, err := restClient.Post().
+		Namespace(operatorNamespace).
+		Resource("services").
+		Name(serviceProxyName).
+		SubResource("proxy").
+		Suffix(strings.TrimPrefix(pod.InjectPreviewPath, "/")).
+		SetHeader("Content-Type", "application/json").
+		Body(body).
+		DoRaw(ctx)
+	if err != nil && strings.Contains(string(raw), "404 page not found") {
+		return nil, errors.Wrap(err, "the inject-preview endpoint of the webhook is not enabled, set webhook.injectPreview in the helm chart to enable it")
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to request the injection preview from the webhook: %s", strings.TrimSpace(string(raw)))
+	}
+
+	var response pod.PreviewResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the injection preview")
+	}
+
+	return &response, nil
+}
+
+func writeResponse(out io.Writer, response *pod.PreviewResponse, format string) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	if format == jsonOutput {
+		data, err = json.MarshalIndent(response, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(response)
+	}
+
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = out.Write(data)
+
+	return errors.WithStack(err)
+}
@@ -0,0 +1,137 @@
+package inject_preview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	testPodYAML = `apiVersion: v1
+kind: Pod
+metadata:
+  name: test-pod
+  namespace: test-namespace
+spec:
+  containers:
+    - name: app
+      image: app:latest
+`
+	testOperatorNamespace = "dynatrace"
+)
+
+func TestDecodePreviewRequest(t *testing.T) {
+	t.Run("yaml pod", func(t *testing.T) {
+		previewRequest, err := decodePreviewRequest(strings.NewReader(testPodYAML), "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "test-pod", previewRequest.Pod.Name)
+		assert.Equal(t, "test-namespace", previewRequest.Pod.Namespace)
+		assert.Empty(t, previewRequest.Namespace)
+		require.Len(t, previewRequest.Pod.Spec.Containers, 1)
+	})
+	t.Run("namespace override", func(t *testing.T) {
+		previewRequest, err := decodePreviewRequest(strings.NewReader(testPodYAML), "other")
+		require.NoError(t, err)
+
+		assert.Equal(t, "other", previewRequest.Namespace)
+	})
+	t.Run("missing namespace", func(t *testing.T) {
+		_, err := decodePreviewRequest(strings.NewReader(strings.Replace(testPodYAML, "  namespace: test-namespace\n", "", 1)), "")
+		require.Error(t, err)
+	})
+	t.Run("not a pod", func(t *testing.T) {
+		_, err := decodePreviewRequest(strings.NewReader(strings.Replace(testPodYAML, "kind: Pod", "kind: Deployment", 1)), "")
+		require.Error(t, err)
+	})
+}
+
+func TestRequestPreview(t *testing.T) {
+	ctx := context.Background()
+	previewRequest := pod.PreviewRequest{Pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-namespace"}}}
+
+	t.Run("calls webhook through service proxy", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/namespaces/dynatrace/services/https:dynatrace-webhook:443/proxy/inject-preview", r.URL.Path)
+
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+
+			var received pod.PreviewRequest
+			assert.NoError(t, json.Unmarshal(body, &received))
+			assert.Equal(t, "test-pod", received.Pod.Name)
+
+			_ = json.NewEncoder(w).Encode(pod.PreviewResponse{
+				DynaKube: "dynakube",
+				Mutated:  true,
+				Mutators: []pod.MutatorDecision{{Name: "oneagent", Fired: true}},
+			})
+		}))
+		defer server.Close()
+
+		response, err := requestPreview(ctx, newTestRESTClient(t, server.URL), testOperatorNamespace, previewRequest)
+		require.NoError(t, err)
+
+		assert.True(t, response.Mutated)
+		assert.Equal(t, "dynakube", response.DynaKube)
+		require.Len(t, response.Mutators, 1)
+	})
+	t.Run("error of webhook is returned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "namespaces \"test-namespace\" not found", http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		_, err := requestPreview(ctx, newTestRESTClient(t, server.URL), testOperatorNamespace, previewRequest)
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "webhook.injectPreview")
+	})
+	t.Run("disabled endpoint is reported", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		_, err := requestPreview(ctx, newTestRESTClient(t, server.URL), testOperatorNamespace, previewRequest)
+		require.ErrorContains(t, err, "webhook.injectPreview")
+	})
+}
+
+func TestWriteResponse(t *testing.T) {
+	response := &pod.PreviewResponse{DynaKube: "dynakube", Message: "injection finished", Mutated: true}
+
+	t.Run("yaml", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeResponse(&out, response, yamlOutput))
+
+		assert.Contains(t, out.String(), "dynakube: dynakube\n")
+		assert.Contains(t, out.String(), "mutated: true\n")
+	})
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeResponse(&out, response, jsonOutput))
+
+		var decoded pod.PreviewResponse
+		require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+		assert.Equal(t, *response, decoded)
+	})
+}
+
+func newTestRESTClient(t *testing.T, host string) rest.Interface {
+	clientSet, err := kubernetes.NewForConfig(&rest.Config{Host: host})
+	require.NoError(t, err)
+
+	return clientSet.CoreV1().RESTClient()
+}
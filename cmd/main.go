@@ -21,7 +21,9 @@ import (
 	csiInit "github.com/Dynatrace/dynatrace-operator/cmd/csi/init"
 	csiProvisioner "github.com/Dynatrace/dynatrace-operator/cmd/csi/provisioner"
 	csiServer "github.com/Dynatrace/dynatrace-operator/cmd/csi/server"
+	"github.com/Dynatrace/dynatrace-operator/cmd/inject_preview"
 	"github.com/Dynatrace/dynatrace-operator/cmd/operator"
+	"github.com/Dynatrace/dynatrace-operator/cmd/render"
 	"github.com/Dynatrace/dynatrace-operator/cmd/standalone"
 	"github.com/Dynatrace/dynatrace-operator/cmd/startup_probe"
 	"github.com/Dynatrace/dynatrace-operator/cmd/support_archive"
@@ -71,6 +73,11 @@ func createSupportArchiveCommandBuilder() support_archive.CommandBuilder {
 		SetConfigProvider(cmdConfig.NewKubeConfigProvider())
 }
 
+func createInjectPreviewCommandBuilder() inject_preview.CommandBuilder {
+	return inject_preview.NewCommandBuilder().
+		SetConfigProvider(cmdConfig.NewKubeConfigProvider())
+}
+
 func createStartupProbe() startup_probe.CommandBuilder {
 	return startup_probe.NewCommandBuilder()
 }
@@ -91,6 +98,8 @@ func main() {
 		createTroubleshootCommandBuilder().Build(),
 		createSupportArchiveCommandBuilder().Build(),
 		createStartupProbe().Build(),
+		render.NewCommandBuilder().Build(),
+		createInjectPreviewCommandBuilder().Build(),
 		csiInit.New(),
 		csiProvisioner.New(),
 		csiServer.New(),
@@ -7,15 +7,18 @@ import (
 
 	cmdManager "github.com/Dynatrace/dynatrace-operator/cmd/manager"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	injectionpolicyv1alpha1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/injectionpolicy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/certificates"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/edgeconnect"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/injectionpolicy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/nodes"
 	"github.com/pkg/errors"
 	_ "k8s.io/client-go/plugin/pkg/client/auth" // important for running operator locally
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -113,6 +116,11 @@ func (provider operatorManagerProvider) CreateManager(namespace string, cfg *res
 		return nil, err
 	}
 
+	err = injectionpolicy.Add(mgr, namespace)
+	if err != nil {
+		return nil, err
+	}
+
 	return mgr, nil
 }
 
@@ -130,6 +138,14 @@ func (provider operatorManagerProvider) createOptions(namespace string) ctrl.Opt
 			DefaultNamespaces: map[string]cache.Config{
 				namespace: {},
 			},
+			// InjectionPolicies are created in the namespaces of the workloads
+			ByObject: map[client.Object]cache.ByObject{
+				&injectionpolicyv1alpha1.InjectionPolicy{}: {
+					Namespaces: map[string]cache.Config{
+						cache.AllNamespaces: {},
+					},
+				},
+			},
 		},
 		Scheme: scheme.Scheme,
 		Metrics: server.Options{
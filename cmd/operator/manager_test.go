@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/config"
 )
@@ -84,6 +85,7 @@ func testHealthzAndReadyz(t *testing.T, createProviderAndRunManager func(mockMgr
 	mockMgr.On("Add", mock.AnythingOfType("*controller.Controller[sigs.k8s.io/controller-runtime/pkg/reconcile.Request]")).Return(nil)
 	mockMgr.On("GetCache").Return(nil)
 	mockMgr.On("GetRESTMapper").Return(nil)
+	mockMgr.On("GetEventRecorderFor", mock.Anything).Return(&record.FakeRecorder{})
 
 	err := createProviderAndRunManager(mockMgr)
 
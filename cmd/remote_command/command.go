@@ -2,6 +2,7 @@ package remote_command
 
 import (
 	"bytes"
+	"io"
 
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
@@ -16,11 +17,17 @@ import (
 
 type Executor interface {
 	Exec(ctx context.Context, config *rest.Config, podName string, podNamespace string, containerName string, command []string) (stdOut *bytes.Buffer, stdErr *bytes.Buffer, err error)
+	// ExecWithStdin is like Exec, but streams stdin to the command, e.g. to pass secrets that must not be visible in its arguments.
+	ExecWithStdin(ctx context.Context, config *rest.Config, podName string, podNamespace string, containerName string, command []string, stdin io.Reader) (stdOut *bytes.Buffer, stdErr *bytes.Buffer, err error)
 }
 
 type DefaultExecutor struct{}
 
 func (r DefaultExecutor) Exec(ctx context.Context, config *rest.Config, podName string, podNamespace string, containerName string, command []string) (stdOut *bytes.Buffer, stdErr *bytes.Buffer, err error) { //nolint:revive
+	return r.ExecWithStdin(ctx, config, podName, podNamespace, containerName, command, &bytes.Buffer{})
+}
+
+func (r DefaultExecutor) ExecWithStdin(ctx context.Context, config *rest.Config, podName string, podNamespace string, containerName string, command []string, stdin io.Reader) (stdOut *bytes.Buffer, stdErr *bytes.Buffer, err error) { //nolint:revive
 	sch := scheme.Scheme
 	parameterCodec := scheme.ParameterCodec
 
@@ -63,7 +70,7 @@ func (r DefaultExecutor) Exec(ctx context.Context, config *rest.Config, podName
 	}
 
 	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
-		Stdin:  &bytes.Buffer{},
+		Stdin:  stdin,
 		Stdout: stdOut,
 		Stderr: stdErr,
 		Tty:    false,
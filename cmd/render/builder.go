@@ -0,0 +1,94 @@
+package render
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	use = "render"
+
+	filenameFlagName   = "filename"
+	namespaceFlagName  = "namespace"
+	clusterIDFlagName  = "kube-system-uid"
+	defaultNamespace   = "dynatrace"
+	defaultClusterID   = "00000000-0000-0000-0000-000000000000"
+	stdinFilenameValue = "-"
+)
+
+var (
+	filenameFlagValue  string
+	namespaceFlagValue string
+	clusterIDFlagValue string
+)
+
+type CommandBuilder struct {
+}
+
+func NewCommandBuilder() CommandBuilder {
+	return CommandBuilder{}
+}
+
+func (builder CommandBuilder) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  use,
+		Long: "render the manifests the operator would create for a DynaKube, without connecting to a cluster or a Dynatrace environment. The data of Secrets and hash annotations are replaced by placeholders, so the output is stable",
+		RunE: builder.buildRun(),
+	}
+
+	cmd.Flags().StringVarP(&filenameFlagValue, filenameFlagName, "f", "", "file containing the DynaKube, and optionally its token secret, to render ('-' reads from stdin)")
+	cmd.Flags().StringVar(&namespaceFlagValue, namespaceFlagName, defaultNamespace, "namespace used for objects that don't specify one")
+	cmd.Flags().StringVar(&clusterIDFlagValue, clusterIDFlagName, defaultClusterID, "UID of the kube-system namespace used as cluster ID")
+
+	_ = cmd.MarkFlagRequired(filenameFlagName)
+
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
+func (builder CommandBuilder) buildRun() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		// the manifests are written to stdout, so the logs of the controller must not end up there
+		logd.SetOutput(cmd.ErrOrStderr())
+
+		input, err := openInput(cmd.InOrStdin(), filenameFlagValue)
+		if err != nil {
+			return err
+		}
+		defer input.Close()
+
+		dynakubes, others, err := decodeManifests(input)
+		if err != nil {
+			return err
+		}
+
+		objects, err := renderDynaKubes(context.Background(), dynakubes, others, renderOptions{
+			namespace: namespaceFlagValue,
+			clusterID: clusterIDFlagValue,
+		})
+		if err != nil {
+			return err
+		}
+
+		return writeManifests(cmd.OutOrStdout(), objects)
+	}
+}
+
+func openInput(stdin io.Reader, filename string) (io.ReadCloser, error) {
+	if filename == stdinFilenameValue {
+		return io.NopCloser(stdin), nil
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return file, nil
+}
@@ -0,0 +1,5 @@
+package render
+
+import "github.com/Dynatrace/dynatrace-operator/pkg/logd"
+
+var log = logd.Get().WithName("render-command")
@@ -0,0 +1,186 @@
+package render
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/logmonitoring"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/pkg/errors"
+)
+
+const (
+	renderTenantUUID   = "render"
+	renderTenantToken  = "render-tenant-token"
+	renderAgentVersion = "1.0.0.20000101-000000"
+	renderAgTokenId    = "render"
+	renderAgToken      = "dt0g02.RENDER.RENDER"
+	renderObjectId     = "render-object-id"
+	renderEntityId     = "KUBERNETES_CLUSTER-RENDER"
+	renderEntityName   = "render"
+	defaultHttpsPort   = 443
+
+	renderOneAgentImage    = "public.ecr.aws/dynatrace/dynatrace-oneagent"
+	renderCodeModulesImage = "public.ecr.aws/dynatrace/dynatrace-codemodules"
+	renderActiveGateImage  = "public.ecr.aws/dynatrace/dynatrace-activegate"
+)
+
+// stubDynatraceClient answers every request of the DynaKube controller with canned values derived from the apiUrl,
+// so the rendered manifests look like the ones of a real environment without ever contacting one.
+type stubDynatraceClient struct {
+	communicationHost dtclient.CommunicationHost
+}
+
+var _ dtclient.Client = stubDynatraceClient{}
+
+func newStubDynatraceClient(apiUrl string) (stubDynatraceClient, error) {
+	parsedUrl, err := url.Parse(apiUrl)
+	if err != nil {
+		return stubDynatraceClient{}, errors.WithMessagef(err, "invalid apiUrl %s", apiUrl)
+	}
+
+	port := uint32(defaultHttpsPort)
+
+	if parsedUrl.Port() != "" {
+		parsedPort, err := strconv.ParseUint(parsedUrl.Port(), 10, 32)
+		if err != nil {
+			return stubDynatraceClient{}, errors.WithMessagef(err, "invalid port in apiUrl %s", apiUrl)
+		}
+
+		port = uint32(parsedPort)
+	}
+
+	return stubDynatraceClient{
+		communicationHost: dtclient.CommunicationHost{
+			Protocol: "https",
+			Host:     parsedUrl.Hostname(),
+			Port:     port,
+		},
+	}, nil
+}
+
+func (stub stubDynatraceClient) endpoints() string {
+	return stub.communicationHost.Protocol + "://" + stub.communicationHost.Host + ":" + strconv.FormatUint(uint64(stub.communicationHost.Port), 10)
+}
+
+func (stub stubDynatraceClient) connectionInfo() dtclient.ConnectionInfo {
+	return dtclient.ConnectionInfo{
+		TenantUUID:  renderTenantUUID,
+		TenantToken: renderTenantToken,
+		Endpoints:   stub.endpoints(),
+	}
+}
+
+func (stub stubDynatraceClient) GetLatestAgentVersion(_ context.Context, _, _ string) (string, error) {
+	return renderAgentVersion, nil
+}
+
+func (stub stubDynatraceClient) GetLatestAgent(_ context.Context, _, _, _, _ string, _ []string, _ bool, _ io.Writer) error {
+	return errors.New("downloading agents is not supported when rendering")
+}
+
+func (stub stubDynatraceClient) GetAgent(_ context.Context, _, _, _, _, _ string, _ []string, _ bool, _ io.Writer) error {
+	return errors.New("downloading agents is not supported when rendering")
+}
+
+func (stub stubDynatraceClient) GetAgentViaInstallerUrl(_ context.Context, _ string, _ io.Writer) error {
+	return errors.New("downloading agents is not supported when rendering")
+}
+
+func (stub stubDynatraceClient) GetAgentVersions(_ context.Context, _, _, _ string) ([]string, error) {
+	return []string{renderAgentVersion}, nil
+}
+
+func (stub stubDynatraceClient) GetOneAgentConnectionInfo(_ context.Context) (dtclient.OneAgentConnectionInfo, error) {
+	return dtclient.OneAgentConnectionInfo{
+		ConnectionInfo:     stub.connectionInfo(),
+		CommunicationHosts: []dtclient.CommunicationHost{stub.communicationHost},
+	}, nil
+}
+
+func (stub stubDynatraceClient) GetProcessModuleConfig(_ context.Context, _ uint) (*dtclient.ProcessModuleConfig, error) {
+	return &dtclient.ProcessModuleConfig{}, nil
+}
+
+func (stub stubDynatraceClient) GetCommunicationHostForClient() (dtclient.CommunicationHost, error) {
+	return stub.communicationHost, nil
+}
+
+func (stub stubDynatraceClient) SendEvent(_ context.Context, _ *dtclient.EventData) error {
+	return nil
+}
+
+func (stub stubDynatraceClient) GetEntityIDForIP(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+func (stub stubDynatraceClient) GetTokenScopes(_ context.Context, _ string) (dtclient.TokenScopes, error) {
+	return dtclient.TokenScopes{
+		dtclient.TokenScopeInstallerDownload,
+		dtclient.TokenScopeDataExport,
+		dtclient.TokenScopeMetricsIngest,
+		dtclient.TokenScopeEntitiesRead,
+		dtclient.TokenScopeSettingsRead,
+		dtclient.TokenScopeSettingsWrite,
+		dtclient.TokenScopeActiveGateTokenCreate,
+	}, nil
+}
+
+func (stub stubDynatraceClient) GetActiveGateConnectionInfo(_ context.Context) (dtclient.ActiveGateConnectionInfo, error) {
+	return dtclient.ActiveGateConnectionInfo{
+		ConnectionInfo: stub.connectionInfo(),
+	}, nil
+}
+
+func (stub stubDynatraceClient) CreateOrUpdateKubernetesSetting(_ context.Context, _, _, _ string) (string, error) {
+	return renderObjectId, nil
+}
+
+func (stub stubDynatraceClient) CreateLogMonitoringSetting(_ context.Context, _, _ string, _ []logmonitoring.IngestRuleMatchers) (string, error) {
+	return renderObjectId, nil
+}
+
+func (stub stubDynatraceClient) CreateOrUpdateKubernetesAppSetting(_ context.Context, _ string) (string, error) {
+	return renderObjectId, nil
+}
+
+func (stub stubDynatraceClient) GetMonitoredEntitiesForKubeSystemUUID(_ context.Context, _ string) ([]dtclient.MonitoredEntity, error) {
+	return []dtclient.MonitoredEntity{{EntityId: renderEntityId, DisplayName: renderEntityName}}, nil
+}
+
+func (stub stubDynatraceClient) GetSettingsForMonitoredEntity(_ context.Context, _ *dtclient.MonitoredEntity, _ string) (dtclient.GetSettingsResponse, error) {
+	return dtclient.GetSettingsResponse{TotalCount: 1}, nil
+}
+
+func (stub stubDynatraceClient) GetSettingsForLogModule(_ context.Context, _ string) (dtclient.GetLogMonSettingsResponse, error) {
+	return dtclient.GetLogMonSettingsResponse{TotalCount: 1}, nil
+}
+
+func (stub stubDynatraceClient) GetRulesSettings(_ context.Context, _ string, _ string) (dtclient.GetRulesSettingsResponse, error) {
+	return dtclient.GetRulesSettingsResponse{}, nil
+}
+
+func (stub stubDynatraceClient) GetActiveGateAuthToken(_ context.Context, _ string) (*dtclient.ActiveGateAuthTokenInfo, error) {
+	return &dtclient.ActiveGateAuthTokenInfo{
+		TokenId: renderAgTokenId,
+		Token:   renderAgToken,
+	}, nil
+}
+
+func (stub stubDynatraceClient) GetLatestOneAgentImage(_ context.Context) (*dtclient.LatestImageInfo, error) {
+	return &dtclient.LatestImageInfo{Source: renderOneAgentImage, Tag: renderAgentVersion}, nil
+}
+
+func (stub stubDynatraceClient) GetLatestCodeModulesImage(_ context.Context) (*dtclient.LatestImageInfo, error) {
+	return &dtclient.LatestImageInfo{Source: renderCodeModulesImage, Tag: renderAgentVersion}, nil
+}
+
+func (stub stubDynatraceClient) GetLatestActiveGateImage(_ context.Context) (*dtclient.LatestImageInfo, error) {
+	return &dtclient.LatestImageInfo{Source: renderActiveGateImage, Tag: renderAgentVersion}, nil
+}
+
+func (stub stubDynatraceClient) GetLatestActiveGateVersion(_ context.Context, _ string) (string, error) {
+	return renderAgentVersion, nil
+}
@@ -0,0 +1,355 @@
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	dynakubecontroller "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	renderApiToken        = "dt0c01.RENDER.API"
+	renderPaasToken       = "dt0c01.RENDER.PAAS"
+	renderDataIngestToken = "dt0c01.RENDER.DATAINGEST"
+
+	// renderPlaceholder replaces values that are generated or derived from generated values
+	renderPlaceholder = "<rendered>"
+
+	// reconcileRounds is the upper bound of reconciliations, some components (e.g. the ActiveGate) only create
+	// all of their objects once the objects of a previous round are present
+	reconcileRounds = 5
+)
+
+// renderedLists contains the kinds the DynaKube controller creates, in the order they are printed.
+var renderedLists = []client.ObjectList{
+	&corev1.SecretList{},
+	&corev1.ConfigMapList{},
+	&corev1.ServiceList{},
+	&appsv1.DaemonSetList{},
+	&appsv1.StatefulSetList{},
+	&appsv1.DeploymentList{},
+	&policyv1.PodDisruptionBudgetList{},
+	&autoscalingv2.HorizontalPodAutoscalerList{},
+	&networkingv1.NetworkPolicyList{},
+}
+
+type renderOptions struct {
+	namespace string
+	clusterID string
+}
+
+// decodeManifests reads all YAML documents of the given input, converting DynaKubes of older API versions to the
+// current one. Every other object is returned as is, so e.g. the token secret can be provided alongside the DynaKube.
+func decodeManifests(input io.Reader) ([]*dynakube.DynaKube, []client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme.Scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(input))
+
+	var dynakubes []*dynakube.DynaKube
+
+	var others []client.Object
+
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+
+		if len(bytes.TrimSpace(document)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(document, nil, nil)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "failed to decode manifest")
+		}
+
+		switch typed := obj.(type) {
+		case *dynakube.DynaKube:
+			dynakubes = append(dynakubes, typed)
+		case conversion.Convertible:
+			dk := &dynakube.DynaKube{}
+			if err := typed.ConvertTo(dk); err != nil {
+				return nil, nil, errors.WithMessage(err, "failed to convert DynaKube to the current version")
+			}
+
+			dynakubes = append(dynakubes, dk)
+		case client.Object:
+			others = append(others, typed)
+		default:
+			return nil, nil, errors.Errorf("unsupported object of kind %s", obj.GetObjectKind().GroupVersionKind().Kind)
+		}
+	}
+
+	if len(dynakubes) == 0 {
+		return nil, nil, errors.New("no DynaKube found in input")
+	}
+
+	return dynakubes, others, nil
+}
+
+// renderDynaKubes runs the DynaKube controller for the given DynaKubes against a fake client and a stubbed
+// Dynatrace client, and returns every object it created.
+func renderDynaKubes(ctx context.Context, dynakubes []*dynakube.DynaKube, others []client.Object, options renderOptions) ([]client.Object, error) {
+	for _, obj := range others {
+		if obj.GetNamespace() == "" && isNamespaced(obj) {
+			obj.SetNamespace(options.namespace)
+		}
+	}
+
+	inputObjects := make([]client.Object, 0, len(dynakubes)+len(others)+1)
+	inputObjects = append(inputObjects, others...)
+
+	if !containsObject(others, &corev1.Namespace{}, types.NamespacedName{Name: kubesystem.Namespace}) {
+		inputObjects = append(inputObjects, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: kubesystem.Namespace,
+				UID:  types.UID(options.clusterID),
+			},
+		})
+	}
+
+	for _, dk := range dynakubes {
+		if dk.Namespace == "" {
+			dk.Namespace = options.namespace
+		}
+
+		if dk.Spec.EnableIstio {
+			log.Info("istio is not supported when rendering, ignoring it", "dynakube", dk.Name)
+
+			dk.Spec.EnableIstio = false
+		}
+
+		inputObjects = append(inputObjects, dk)
+
+		tokenKey := types.NamespacedName{Name: dk.Tokens(), Namespace: dk.Namespace}
+		if !containsObject(others, &corev1.Secret{}, tokenKey) {
+			inputObjects = append(inputObjects, newTokenSecret(tokenKey))
+		}
+	}
+
+	fakeClient := fake.NewClientWithIndex(inputObjects...)
+
+	for _, dk := range dynakubes {
+		dtClient, err := newStubDynatraceClient(dk.ApiUrl())
+		if err != nil {
+			return nil, err
+		}
+
+		controller := dynakubecontroller.NewRenderController(fakeClient, dtClient, options.namespace, options.clusterID)
+
+		err = reconcileUntilStable(ctx, fakeClient, controller, dk)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to render DynaKube %s", dk.Name)
+		}
+	}
+
+	return listCreatedObjects(ctx, fakeClient, inputObjects)
+}
+
+func reconcileUntilStable(ctx context.Context, fakeClient client.Client, controller reconcile.Reconciler, dk *dynakube.DynaKube) error {
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: dk.Name, Namespace: dk.Namespace}}
+	previousCount := -1
+
+	var err error
+
+	for range reconcileRounds {
+		_, err = controller.Reconcile(ctx, request)
+
+		objects, listErr := listCreatedObjects(ctx, fakeClient, nil)
+		if listErr != nil {
+			return listErr
+		}
+
+		if err == nil && len(objects) == previousCount {
+			return nil
+		}
+
+		previousCount = len(objects)
+	}
+
+	return err
+}
+
+func listCreatedObjects(ctx context.Context, fakeClient client.Client, inputObjects []client.Object) ([]client.Object, error) {
+	var created []client.Object
+
+	for _, list := range renderedLists {
+		err := fakeClient.List(ctx, list)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		var objects []client.Object
+
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || containsObject(inputObjects, obj, client.ObjectKeyFromObject(obj)) {
+				continue
+			}
+
+			if err := prepareForOutput(obj); err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, obj)
+		}
+
+		sort.Slice(objects, func(i, j int) bool {
+			if objects[i].GetNamespace() != objects[j].GetNamespace() {
+				return objects[i].GetNamespace() < objects[j].GetNamespace()
+			}
+
+			return objects[i].GetName() < objects[j].GetName()
+		})
+
+		created = append(created, objects...)
+	}
+
+	return created, nil
+}
+
+// prepareForOutput sets the type information, which the fake client drops, and removes the fields only the
+// api-server would populate.
+func prepareForOutput(obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+
+	stabilizeForOutput(obj)
+
+	return nil
+}
+
+// stabilizeForOutput replaces the data of Secrets and the hash annotations with placeholders.
+// Secrets can contain generated keys and tokens, which must not be printed and would change on every run, like the hashes derived from them,
+// so the output could not be diffed.
+func stabilizeForOutput(obj client.Object) {
+	if secret, ok := obj.(*corev1.Secret); ok {
+		for key := range secret.Data {
+			secret.Data[key] = []byte(renderPlaceholder)
+		}
+
+		for key := range secret.StringData {
+			secret.StringData[key] = renderPlaceholder
+		}
+	}
+
+	stabilizeHashAnnotations(obj.GetAnnotations())
+
+	switch typed := obj.(type) {
+	case *appsv1.DaemonSet:
+		stabilizeHashAnnotations(typed.Spec.Template.Annotations)
+	case *appsv1.StatefulSet:
+		stabilizeHashAnnotations(typed.Spec.Template.Annotations)
+	case *appsv1.Deployment:
+		stabilizeHashAnnotations(typed.Spec.Template.Annotations)
+	}
+}
+
+func stabilizeHashAnnotations(annotations map[string]string) {
+	for key := range annotations {
+		if strings.HasPrefix(key, api.InternalFlagPrefix) && strings.HasSuffix(key, "hash") {
+			annotations[key] = renderPlaceholder
+		}
+	}
+}
+
+func writeManifests(out io.Writer, objects []client.Object) error {
+	for _, obj := range objects {
+		manifest, err := yaml.Marshal(obj)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		_, err = io.WriteString(out, "---\n")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		_, err = out.Write(manifest)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func newTokenSecret(key types.NamespacedName) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Data: map[string][]byte{
+			dtclient.ApiToken:        []byte(renderApiToken),
+			dtclient.PaasToken:       []byte(renderPaasToken),
+			dtclient.DataIngestToken: []byte(renderDataIngestToken),
+		},
+	}
+}
+
+func containsObject(objects []client.Object, obj runtime.Object, key types.NamespacedName) bool {
+	gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+	if err != nil {
+		return false
+	}
+
+	for _, candidate := range objects {
+		candidateGvk, err := apiutil.GVKForObject(candidate, scheme.Scheme)
+		if err != nil || candidateGvk.Kind != gvk.Kind {
+			continue
+		}
+
+		if candidate.GetName() == key.Name && (key.Namespace == "" || candidate.GetNamespace() == key.Namespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNamespaced(obj client.Object) bool {
+	_, isNamespace := obj.(*corev1.Namespace)
+
+	return !isNamespace
+}
@@ -0,0 +1,226 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/oneagent"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testName   = "dynakube"
+	testApiUrl = "https://test.dev.dynatracelabs.com/api"
+)
+
+const testManifest = `apiVersion: dynatrace.com/v1beta3
+kind: DynaKube
+metadata:
+  name: dynakube
+spec:
+  apiUrl: https://test.dev.dynatracelabs.com/api
+  oneAgent:
+    hostMonitoring: {}
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: dynakube
+data:
+  apiToken: YXBp
+`
+
+const testV1beta1Manifest = `apiVersion: dynatrace.com/v1beta1
+kind: DynaKube
+metadata:
+  name: dynakube
+  namespace: other
+spec:
+  apiUrl: https://test.dev.dynatracelabs.com/api
+  oneAgent:
+    classicFullStack: {}
+`
+
+func TestDecodeManifests(t *testing.T) {
+	t.Run("dynakube and additional objects", func(t *testing.T) {
+		dynakubes, others, err := decodeManifests(strings.NewReader(testManifest))
+		require.NoError(t, err)
+
+		require.Len(t, dynakubes, 1)
+		assert.Equal(t, testName, dynakubes[0].Name)
+		assert.Equal(t, testApiUrl, dynakubes[0].ApiUrl())
+
+		require.Len(t, others, 1)
+		secret, ok := others[0].(*corev1.Secret)
+		require.True(t, ok)
+		assert.Equal(t, []byte("api"), secret.Data[dtclient.ApiToken])
+	})
+	t.Run("older versions are converted", func(t *testing.T) {
+		dynakubes, others, err := decodeManifests(strings.NewReader(testV1beta1Manifest))
+		require.NoError(t, err)
+
+		require.Len(t, dynakubes, 1)
+		assert.Empty(t, others)
+		assert.Equal(t, "other", dynakubes[0].Namespace)
+		assert.True(t, dynakubes[0].OneAgent().IsClassicFullStackMode())
+	})
+	t.Run("no dynakube", func(t *testing.T) {
+		_, _, err := decodeManifests(strings.NewReader("apiVersion: v1\nkind: Secret\nmetadata:\n  name: test\n"))
+		require.Error(t, err)
+	})
+	t.Run("invalid manifest", func(t *testing.T) {
+		_, _, err := decodeManifests(strings.NewReader("kind: Unknown\n"))
+		require.Error(t, err)
+	})
+}
+
+func TestRenderDynaKubes(t *testing.T) {
+	options := renderOptions{namespace: "dynatrace", clusterID: defaultClusterID}
+
+	t.Run("cloud native fullstack with activegate", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: testName},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testApiUrl,
+				OneAgent: oneagent.Spec{
+					CloudNativeFullStack: &oneagent.CloudNativeFullStackSpec{},
+				},
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+				},
+			},
+		}
+
+		objects, err := renderDynaKubes(context.Background(), []*dynakube.DynaKube{dk}, nil, options)
+		require.NoError(t, err)
+
+		daemonSet := findObject[*appsv1.DaemonSet](objects, testName+"-oneagent")
+		require.NotNil(t, daemonSet)
+		assert.Equal(t, "dynatrace", daemonSet.Namespace)
+		assert.Equal(t, "DaemonSet", daemonSet.Kind)
+		assert.Empty(t, daemonSet.ResourceVersion)
+
+		statefulSet := findObject[*appsv1.StatefulSet](objects, testName+"-activegate")
+		require.NotNil(t, statefulSet)
+
+		assert.NotNil(t, findObject[*corev1.Secret](objects, testName+"-activegate-authtoken-secret"))
+		assert.Nil(t, findObject[*corev1.Secret](objects, testName), "the input token secret must not be rendered")
+	})
+	t.Run("provided token secret is used", func(t *testing.T) {
+		dynakubes, others, err := decodeManifests(strings.NewReader(testManifest))
+		require.NoError(t, err)
+
+		objects, err := renderDynaKubes(context.Background(), dynakubes, others, options)
+		require.NoError(t, err)
+
+		assert.Nil(t, findObject[*corev1.Secret](objects, testName))
+		assert.NotNil(t, findObject[*appsv1.DaemonSet](objects, testName+"-oneagent"))
+	})
+}
+
+func TestRenderIsStable(t *testing.T) {
+	options := renderOptions{namespace: "dynatrace", clusterID: defaultClusterID}
+	render := func() (string, []client.Object) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: testName},
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testApiUrl,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+				},
+				Extensions: &dynakube.ExtensionsSpec{},
+			},
+		}
+
+		objects, err := renderDynaKubes(context.Background(), []*dynakube.DynaKube{dk}, nil, options)
+		require.NoError(t, err)
+
+		out := bytes.Buffer{}
+		require.NoError(t, writeManifests(&out, objects))
+
+		return out.String(), objects
+	}
+
+	first, objects := render()
+	second, _ := render()
+
+	assert.Equal(t, first, second)
+
+	tlsSecret := findObject[*corev1.Secret](objects, testName+"-extensions-controller-tls")
+	require.NotNil(t, tlsSecret)
+	require.NotEmpty(t, tlsSecret.Data)
+
+	for _, value := range tlsSecret.Data {
+		assert.Equal(t, renderPlaceholder, string(value))
+	}
+
+	assert.NotContains(t, first, "PRIVATE KEY")
+}
+
+func TestWriteManifests(t *testing.T) {
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "first"},
+	}
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "second"},
+	}
+
+	out := bytes.Buffer{}
+	require.NoError(t, writeManifests(&out, []client.Object{secret, configMap}))
+
+	documents := strings.Split(strings.TrimPrefix(out.String(), "---\n"), "---\n")
+	require.Len(t, documents, 2)
+	assert.Contains(t, documents[0], "kind: Secret")
+	assert.Contains(t, documents[0], "name: first")
+	assert.Contains(t, documents[1], "kind: ConfigMap")
+}
+
+func TestStubDynatraceClient(t *testing.T) {
+	t.Run("default port", func(t *testing.T) {
+		stub, err := newStubDynatraceClient(testApiUrl)
+		require.NoError(t, err)
+
+		connectionInfo, err := stub.GetOneAgentConnectionInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "https://test.dev.dynatracelabs.com:443", connectionInfo.Endpoints)
+		require.Len(t, connectionInfo.CommunicationHosts, 1)
+		assert.Equal(t, uint32(443), connectionInfo.CommunicationHosts[0].Port)
+	})
+	t.Run("custom port", func(t *testing.T) {
+		stub, err := newStubDynatraceClient("https://managed.example.com:9999/e/abc/api")
+		require.NoError(t, err)
+
+		host, err := stub.GetCommunicationHostForClient()
+		require.NoError(t, err)
+		assert.Equal(t, "managed.example.com", host.Host)
+		assert.Equal(t, uint32(9999), host.Port)
+	})
+	t.Run("invalid port", func(t *testing.T) {
+		_, err := newStubDynatraceClient("https://managed.example.com:port/api")
+		require.Error(t, err)
+	})
+}
+
+func findObject[T client.Object](objects []client.Object, name string) T {
+	var empty T
+
+	for _, obj := range objects {
+		if typed, ok := obj.(T); ok && typed.GetName() == name {
+			return typed
+		}
+	}
+
+	return empty
+}
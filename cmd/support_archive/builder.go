@@ -36,6 +36,20 @@ const (
 	loadsimFileSizeFlagName        = "loadsim-file-size"
 	loadsimFilesFlagName           = "loadsim-files"
 	collectManagedLogsFlagName     = "managed-logs"
+	redactConfigFlagName           = "redact-config"
+	injectedNamespacesFlagName     = "injected-namespaces"
+	injectedPodsFlagName           = "injected-pods"
+	defaultInjectedNamespaces      = 10
+	defaultInjectedPods            = 3
+	sinceFlagName                  = "since"
+	tailFlagName                   = "tail"
+	maxContainerLogSizeFlagName    = "max-container-log-size"
+	maxLogSizeFlagName             = "max-log-size"
+	logSelectorFlagName            = "log-selector"
+	nodesFlagName                  = "nodes"
+	uploadFlagName                 = "upload"
+	uploadDynakubeFlagName         = "upload-dynakube"
+	uploadS3EndpointFlagName       = "upload-s3-endpoint"
 	defaultSimFileSize             = 10
 )
 
@@ -46,12 +60,24 @@ const (
 )
 
 var (
-	namespaceFlagValue          string
-	archiveToStdoutFlagValue    bool
-	loadsimFilesFlagValue       int
-	loadsimFileSizeFlagValue    int
-	collectManagedLogsFlagValue bool
-	delayFlagValue              int
+	namespaceFlagValue           string
+	archiveToStdoutFlagValue     bool
+	loadsimFilesFlagValue        int
+	loadsimFileSizeFlagValue     int
+	collectManagedLogsFlagValue  bool
+	delayFlagValue               int
+	redactConfigFlagValue        string
+	injectedNamespacesFlagValue  int
+	injectedPodsFlagValue        int
+	sinceFlagValue               time.Duration
+	tailFlagValue                int64
+	maxContainerLogSizeFlagValue int
+	maxLogSizeFlagValue          int
+	logSelectorFlagValue         string
+	nodesFlagValue               []string
+	uploadFlagValue              string
+	uploadDynakubeFlagValue      string
+	uploadS3EndpointFlagValue    string
 )
 
 type CommandBuilder struct {
@@ -90,6 +116,18 @@ func addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().IntVar(&loadsimFilesFlagValue, loadsimFilesFlagName, 0, "Number of simulated log files (default 0)")
 	cmd.PersistentFlags().BoolVar(&collectManagedLogsFlagValue, collectManagedLogsFlagName, true, "Add logs from rolled out pods to the support archive.")
 	cmd.PersistentFlags().IntVar(&delayFlagValue, delayFlagName, 0, "Delay start of support-archive collection. Useful for standalone execution with 'kubectl run'")
+	cmd.PersistentFlags().StringVar(&redactConfigFlagValue, redactConfigFlagName, "", "YAML file with additional redaction rules, tokens, url credentials and the data of Secrets are always redacted.")
+	cmd.PersistentFlags().IntVar(&injectedNamespacesFlagValue, injectedNamespacesFlagName, defaultInjectedNamespaces, "Maximum number of injected namespaces to collect the state of injected pods from, 0 disables the collection.")
+	cmd.PersistentFlags().IntVar(&injectedPodsFlagValue, injectedPodsFlagName, defaultInjectedPods, "Maximum number of injected pods per namespace to collect the injection state and init container logs of.")
+	cmd.PersistentFlags().DurationVar(&sinceFlagValue, sinceFlagName, 0, "Only collect container logs newer than the given duration, e.g. 2h (default all).")
+	cmd.PersistentFlags().Int64Var(&tailFlagValue, tailFlagName, 0, "Only collect the given number of most recent lines of each container log (default all).")
+	cmd.PersistentFlags().IntVar(&maxContainerLogSizeFlagValue, maxContainerLogSizeFlagName, 0, "Maximum size of a single container log in MiB, larger logs are truncated (default unlimited).")
+	cmd.PersistentFlags().IntVar(&maxLogSizeFlagValue, maxLogSizeFlagName, 0, "Maximum size of all container logs in MiB, logs exceeding it are truncated or skipped (default unlimited).")
+	cmd.PersistentFlags().StringVar(&logSelectorFlagValue, logSelectorFlagName, "", "Additional label selector for the pods to collect logs from, e.g. 'app.kubernetes.io/component=oneagent'.")
+	cmd.PersistentFlags().StringSliceVar(&nodesFlagValue, nodesFlagName, nil, "Only collect logs of pods running on the given nodes.")
+	cmd.PersistentFlags().StringVar(&uploadFlagValue, uploadFlagName, "", "Upload the archive instead of writing it to a file, either to a pre-signed https url, which gets a single PUT request with a Content-Length, or to s3://<bucket>/<key> using the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_REGION env vars.")
+	cmd.PersistentFlags().StringVar(&uploadDynakubeFlagValue, uploadDynakubeFlagName, "", "Dynakube whose proxy and trustedCAs are used for the upload (default the only Dynakube in the namespace).")
+	cmd.PersistentFlags().StringVar(&uploadS3EndpointFlagValue, uploadS3EndpointFlagName, "", "Endpoint of an S3-compatible storage, e.g. https://minio.example.com (default AWS S3 of AWS_REGION).")
 }
 
 func (builder CommandBuilder) buildRun() func(*cobra.Command, []string) error {
@@ -101,30 +139,104 @@ func (builder CommandBuilder) buildRun() func(*cobra.Command, []string) error {
 		installconfig.ReadModulesToLogger(log)
 		version.LogVersionToLogger(log)
 
-		archiveTargetFile, err := createZipArchiveTargetFile(archiveToStdoutFlagValue, defaultSupportArchiveTargetDir)
+		redactionRules, err := loadRedactionRules(redactConfigFlagValue)
 		if err != nil {
 			return err
 		}
 
-		supportArchive := newZipArchive(archiveTargetFile)
+		archiveTarget, archiveTargetName, err := builder.createArchiveTarget(cmd.Context(), log)
+		if err != nil {
+			return err
+		}
 
-		defer archiveTargetFile.Close()
-		defer supportArchive.Close()
+		archive := newZipArchive(archiveTarget)
+		supportArchive := newRedactingArchive(archive, redactionRules)
 
 		err = builder.runCollectors(log, supportArchive)
 		if err != nil {
+			_ = archive.Close()
+			archiveTarget.Abort(err)
+
 			return err
 		}
 
-		printCopyCommand(log, archiveToStdoutFlagValue, archiveTargetFile.Name())
+		if uploadFlagValue == "" {
+			printCopyCommand(log, archiveToStdoutFlagValue, archiveTargetName)
+		}
+
+		logInfof(log, "Redacted %d values, see %s for details", supportArchive.redactionCount(), RedactionManifestFileName)
 
 		// make sure to run this collector at the very end
 		newSupportArchiveOutputCollector(log, supportArchive, &logBuffer).Do()
 
+		// the manifest is written last, so it covers the output of all collectors
+		err = supportArchive.writeManifest()
+		if err != nil {
+			_ = archive.Close()
+			archiveTarget.Abort(err)
+
+			return err
+		}
+
+		err = archive.Close()
+		if err != nil {
+			archiveTarget.Abort(err)
+
+			return err
+		}
+
+		// for uploads, closing the target finishes the upload, so errors must not be ignored
+		err = archiveTarget.Close()
+		if err != nil {
+			return err
+		}
+
+		if uploadFlagValue != "" {
+			logInfof(log, "Uploaded support archive to %s", archiveTargetName)
+		}
+
 		return nil
 	}
 }
 
+// createArchiveTarget returns where the archive is written to, which is a file, stdout or an upload, and the name of the target to be logged.
+func (builder CommandBuilder) createArchiveTarget(ctx context.Context, log logd.Logger) (archiveTarget, string, error) {
+	if uploadFlagValue == "" {
+		archiveTargetFile, err := createZipArchiveTargetFile(archiveToStdoutFlagValue, defaultSupportArchiveTargetDir)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return fileTarget{archiveTargetFile}, archiveTargetFile.Name(), nil
+	}
+
+	if archiveToStdoutFlagValue {
+		return nil, "", errors.Errorf("--%s and --%s can't be used together", uploadFlagName, archiveToStdoutFlagName)
+	}
+
+	kubeConfig, err := builder.configProvider.GetConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	_, apiReader, err := getK8sClients(kubeConfig)
+	if err != nil {
+		return nil, "", err
+	}
+
+	transport, err := getUploadTransport(ctx, log, apiReader, namespaceFlagValue, uploadDynakubeFlagValue)
+	if err != nil {
+		return nil, "", err
+	}
+
+	uploadTarget, err := newUploadTarget(ctx, uploadFlagValue, uploadS3EndpointFlagValue, transport)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return uploadTarget, redactUploadUrl(uploadFlagValue), nil
+}
+
 func getLogOutput(tarballToStdout bool, logBuffer *bytes.Buffer) io.Writer {
 	if tarballToStdout {
 		// avoid corrupting tarball
@@ -176,9 +288,11 @@ func (builder CommandBuilder) runCollectors(log logd.Logger, supportArchive arch
 	fileSize := loadsimFileSizeFlagValue * Mebi
 	collectors := []collector{
 		newOperatorVersionCollector(log, supportArchive),
-		newLogCollector(ctx, log, supportArchive, pods, appName, collectManagedLogsFlagValue),
+		newLogCollector(ctx, log, supportArchive, pods, appName, collectManagedLogsFlagValue, getLogCollectionOptions()),
 		newFsLogCollector(ctx, kubeConfig, &remote_command.DefaultExecutor{}, log, supportArchive, pods, appName, collectManagedLogsFlagValue),
+		newCsiDiagnosticsCollector(ctx, kubeConfig, &remote_command.DefaultExecutor{}, log, supportArchive, pods, appName),
 		newK8sObjectCollector(ctx, log, supportArchive, namespaceFlagValue, appName, apiReader, discoveryClient),
+		newInjectedPodsCollector(ctx, log, supportArchive, clientSet.CoreV1(), injectedNamespacesFlagValue, injectedPodsFlagValue),
 		newTroubleshootCollector(ctx, log, supportArchive, namespaceFlagValue, apiReader, *kubeConfig),
 		newLoadSimCollector(ctx, log, supportArchive, fileSize, loadsimFilesFlagValue, clientSet.CoreV1().Pods(namespaceFlagValue)),
 	}
@@ -192,6 +306,17 @@ func (builder CommandBuilder) runCollectors(log logd.Logger, supportArchive arch
 	return nil
 }
 
+func getLogCollectionOptions() logCollectionOptions {
+	return logCollectionOptions{
+		labelSelector:       logSelectorFlagValue,
+		nodeNames:           nodesFlagValue,
+		since:               sinceFlagValue,
+		tailLines:           tailFlagValue,
+		maxContainerLogSize: int64(maxContainerLogSizeFlagValue) * Mebi,
+		maxTotalLogSize:     int64(maxLogSizeFlagValue) * Mebi,
+	}
+}
+
 func getK8sClients(kubeConfig *rest.Config) (*kubernetes.Clientset, client.Reader, error) {
 	k8sCluster, err := cluster.New(kubeConfig, clusterOptions)
 	if err != nil {
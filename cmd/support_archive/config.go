@@ -2,10 +2,12 @@ package support_archive
 
 const OperatorVersionFileName = "operator-version.txt"
 const SupportArchiveOutputFileName = "supportarchive_console.log"
+const LogTruncationManifestFileName = "log-truncation-manifest.yaml"
 
 const LogsDirectoryName = "logs"
 const ManifestsDirectoryName = "manifests"
 const InjectedNamespacesManifestsDirectoryName = "injected_namespaces"
+const InjectedPodsDirectoryName = "injected_pods"
 const CRDDirectoryName = "crds"
 const WebhookConfigurationsDirectoryName = "webhook_configurations"
 const ManifestsFileExtension = ".yaml"
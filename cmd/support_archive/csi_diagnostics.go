@@ -0,0 +1,203 @@
+package support_archive
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/cmd/remote_command"
+	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apilabels "k8s.io/apimachinery/pkg/labels"
+	clientgocorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	csiDiagnosticsCollectorName = "csiDiagnosticsCollector"
+	csiDriverComponentLabel     = "csi-driver"
+	csiServerContainerName      = "server"
+	csiProvisionerContainerName = "provisioner"
+
+	// csiCleanupLoggerName has to match the name of the logger used by the cleanup of the provisioner
+	csiCleanupLoggerName = "csi-cleanup"
+
+	csiDiagnosticsMaxBytes     = 1 * Mebi
+	csiProvisionerLogTailLines = 5000
+
+	csiListingFileName   = "data-listing.txt"
+	csiMountsFileName    = "overlay-mounts.txt"
+	csiDiskUsageFileName = "disk-usage.txt"
+	csiSymlinksFileName  = "latest-symlinks.txt"
+	csiCleanupFileName   = "provisioner-cleanup.log"
+)
+
+// csiDiagnosticsCollector captures the state of the CSI driver's filesystem on every node, by running commands in the CSI driver pods.
+// Every output is limited to csiDiagnosticsMaxBytes, as the data directory can contain a lot of files.
+type csiDiagnosticsCollector struct {
+	ctx                   context.Context
+	pods                  clientgocorev1.PodInterface
+	remoteCommandExecutor remote_command.Executor
+	config                *rest.Config
+	collectorCommon
+	pathResolver metadata.PathResolver
+	appName      string
+}
+
+func newCsiDiagnosticsCollector(context context.Context, config *rest.Config, command remote_command.Executor, log logd.Logger, supportArchive archiver, pods clientgocorev1.PodInterface, appName string) collector { //nolint:revive
+	return csiDiagnosticsCollector{
+		collectorCommon: collectorCommon{
+			log:            log,
+			supportArchive: supportArchive,
+		},
+		ctx:                   context,
+		config:                config,
+		pods:                  pods,
+		appName:               appName,
+		remoteCommandExecutor: command,
+		pathResolver:          metadata.PathResolver{RootDir: dtcsi.DataPath},
+	}
+}
+
+func (collector csiDiagnosticsCollector) Name() string {
+	return csiDiagnosticsCollectorName
+}
+
+func (collector csiDiagnosticsCollector) Do() error {
+	if !installconfig.GetModules().Supportability {
+		logInfof(collector.log, "%s", installconfig.GetModuleValidationErrorMessage("CSI Diagnostics Collection"))
+
+		return nil
+	}
+
+	if !installconfig.GetModules().CSIDriver {
+		return nil
+	}
+
+	listOptions := metav1.ListOptions{
+		LabelSelector: apilabels.Set{
+			labels.AppNameLabel:      collector.appName,
+			labels.AppComponentLabel: csiDriverComponentLabel,
+		}.String(),
+	}
+
+	podList, err := collector.pods.List(collector.ctx, listOptions)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if len(podList.Items) == 0 {
+		logInfof(collector.log, "CSI driver pods not found, CSI diagnostics will not be collected")
+
+		return nil
+	}
+
+	for _, pod := range podList.Items {
+		collector.collectNodeDiagnostics(pod)
+	}
+
+	return nil
+}
+
+func (collector csiDiagnosticsCollector) collectNodeDiagnostics(pod corev1.Pod) {
+	dataDir := collector.pathResolver.RootDir
+
+	collector.execAndStore(pod, csiListingFileName, "ls -lAR '"+dataDir+"'", nil)
+	collector.execAndStore(pod, csiMountsFileName, "cat /proc/self/mounts", collector.filterOverlayMounts)
+	collector.execAndStore(pod, csiDiskUsageFileName, fmt.Sprintf("df -k '%s'; du -k -d 2 '%s'", dataDir, dataDir), nil)
+	collector.execAndStore(pod, csiSymlinksFileName, fmt.Sprintf(
+		`for link in '%s'/*/latest-codemodule; do [ -L "$link" ] || continue; if [ -e "$link" ]; then echo "$link -> $(readlink "$link")"; else echo "BROKEN $link -> $(readlink "$link")"; fi; done`,
+		collector.pathResolver.DynaKubesBaseDir()), nil)
+	collector.collectCleanupLogs(pod)
+}
+
+// execAndStore runs the shell command in the server container, the output is cut off in the pod already, so large outputs aren't transferred.
+func (collector csiDiagnosticsCollector) execAndStore(pod corev1.Pod, fileName string, shellCommand string, filter func(io.Reader) io.Reader) {
+	command := []string{"/usr/bin/sh", "-c", "(" + shellCommand + ") 2>&1 | head -c " + strconv.Itoa(csiDiagnosticsMaxBytes)}
+
+	stdOut, _, err := collector.remoteCommandExecutor.Exec(collector.ctx, collector.config, pod.Name, pod.Namespace, csiServerContainerName, command)
+	if err != nil {
+		logErrorf(collector.log, err, "failed to collect %s from pod: %s", fileName, pod.Name)
+
+		return
+	}
+
+	var output io.Reader = stdOut
+	if filter != nil {
+		output = filter(stdOut)
+	}
+
+	zipFilePath := BuildZipFilePath(pod.Name, fileName)
+
+	err = collector.supportArchive.addFile(zipFilePath, output)
+	if err != nil {
+		logErrorf(collector.log, err, "error writing to tarball")
+
+		return
+	}
+
+	logInfof(collector.log, "Successfully collected CSI diagnostics %s", zipFilePath)
+}
+
+// filterOverlayMounts only keeps the overlay mounts of the driver, which are using the shared binaries as lower dir.
+func (collector csiDiagnosticsCollector) filterOverlayMounts(mounts io.Reader) io.Reader {
+	filtered := bytes.Buffer{}
+	scanner := bufio.NewScanner(mounts)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "overlay ") && strings.Contains(line, collector.pathResolver.RootDir+"/") {
+			filtered.WriteString(line + "\n")
+		}
+	}
+
+	return &filtered
+}
+
+// collectCleanupLogs keeps only the log lines of the cleanup from the recent logs of the provisioner.
+func (collector csiDiagnosticsCollector) collectCleanupLogs(pod corev1.Pod) {
+	logOptions := corev1.PodLogOptions{
+		Container: csiProvisionerContainerName,
+		TailLines: ptr.To(int64(csiProvisionerLogTailLines)),
+	}
+
+	podLogs, err := collector.pods.GetLogs(pod.Name, &logOptions).Stream(collector.ctx)
+	if err != nil {
+		logErrorf(collector.log, err, "error in opening stream")
+
+		return
+	}
+
+	defer podLogs.Close()
+
+	cleanupLogs := bytes.Buffer{}
+	scanner := bufio.NewScanner(podLogs)
+
+	for scanner.Scan() && cleanupLogs.Len() < csiDiagnosticsMaxBytes {
+		if strings.Contains(scanner.Text(), csiCleanupLoggerName) {
+			cleanupLogs.WriteString(scanner.Text() + "\n")
+		}
+	}
+
+	zipFilePath := BuildZipFilePath(pod.Name, csiCleanupFileName)
+
+	err = collector.supportArchive.addFile(zipFilePath, &cleanupLogs)
+	if err != nil {
+		logErrorf(collector.log, err, "error writing to tarball")
+
+		return
+	}
+
+	logInfof(collector.log, "Successfully collected CSI diagnostics %s", zipFilePath)
+}
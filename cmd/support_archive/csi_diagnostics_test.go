@@ -0,0 +1,115 @@
+package support_archive
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	mocks "github.com/Dynatrace/dynatrace-operator/test/mocks/cmd/remote_command"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	csiPodName   = "dynatrace-oneagent-csi-driver-abcde"
+	csiNamespace = "dynatrace"
+
+	testMounts = `overlay /var/lib/kubelet/pods/1234/volumes/kubernetes.io~csi/pv/mount overlay rw,lowerdir=/data/codemodules/1.2.3,upperdir=/data/appmounts/csi-1/var,workdir=/data/appmounts/csi-1/work 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+overlay / overlay rw,lowerdir=/var/lib/containers/storage/overlay/l/ABC 0 0
+`
+)
+
+func TestCsiDiagnosticsCollector(t *testing.T) {
+	t.Run("diagnostics are collected from every csi driver pod", func(t *testing.T) {
+		fakeClientSet := fake.NewSimpleClientset(createCsiPod(csiPodName))
+
+		executor := mocks.NewExecutor(t)
+		mockCsiCommand(executor, "ls -lAR '/data'", "/data:\ncodemodules\n")
+		mockCsiCommand(executor, "cat /proc/self/mounts", testMounts)
+		mockCsiCommand(executor, "df -k '/data'", "Filesystem 1K-blocks Used Available Use% Mounted on\n")
+		mockCsiCommand(executor, "latest-codemodule", "BROKEN /data/_dynakubes/dynakube/latest-codemodule -> /data/codemodules/1.2.3\n")
+
+		files := runCsiDiagnosticsCollector(t, fakeClientSet, executor)
+
+		assert.Equal(t, "/data:\ncodemodules\n", files["logs/"+csiPodName+"/"+csiListingFileName])
+		assert.Equal(t, strings.Split(testMounts, "\n")[0]+"\n", files["logs/"+csiPodName+"/"+csiMountsFileName])
+		assert.Contains(t, files["logs/"+csiPodName+"/"+csiDiskUsageFileName], "Filesystem")
+		assert.Contains(t, files["logs/"+csiPodName+"/"+csiSymlinksFileName], "BROKEN")
+		assert.Contains(t, files, "logs/"+csiPodName+"/"+csiCleanupFileName)
+	})
+	t.Run("output is limited in the pod", func(t *testing.T) {
+		fakeClientSet := fake.NewSimpleClientset(createCsiPod(csiPodName))
+
+		executor := mocks.NewExecutor(t)
+		executor.On("Exec", mock.Anything, mock.Anything, csiPodName, csiNamespace, csiServerContainerName, mock.MatchedBy(func(command []string) bool {
+			return strings.HasSuffix(command[2], "| head -c 1048576")
+		})).Return(&bytes.Buffer{}, &bytes.Buffer{}, nil)
+
+		runCsiDiagnosticsCollector(t, fakeClientSet, executor)
+
+		executor.AssertNumberOfCalls(t, "Exec", 4)
+	})
+	t.Run("no csi driver pods", func(t *testing.T) {
+		fakeClientSet := fake.NewSimpleClientset()
+
+		files := runCsiDiagnosticsCollector(t, fakeClientSet, mocks.NewExecutor(t))
+
+		assert.Empty(t, files)
+	})
+}
+
+func TestFilterOverlayMounts(t *testing.T) {
+	collector := newCsiDiagnosticsCollector(context.Background(), nil, nil, newSupportArchiveLogger(&bytes.Buffer{}), nil, nil, defaultOperatorAppName).(csiDiagnosticsCollector)
+
+	filtered := collector.filterOverlayMounts(strings.NewReader(testMounts))
+
+	output := bytes.Buffer{}
+	_, err := output.ReadFrom(filtered)
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Split(testMounts, "\n")[0]+"\n", output.String())
+}
+
+func runCsiDiagnosticsCollector(t *testing.T, fakeClientSet *fake.Clientset, executor *mocks.Executor) map[string]string {
+	logBuffer := bytes.Buffer{}
+	buffer := bytes.Buffer{}
+	supportArchive := newZipArchive(&buffer)
+
+	collector := newCsiDiagnosticsCollector(context.Background(), nil, executor, newSupportArchiveLogger(&logBuffer), supportArchive, fakeClientSet.CoreV1().Pods(csiNamespace), defaultOperatorAppName)
+	require.NoError(t, collector.Do())
+	require.NoError(t, supportArchive.Close())
+
+	return readZipFiles(t, buffer.Bytes())
+}
+
+func mockCsiCommand(executor *mocks.Executor, commandPart string, output string) {
+	executor.On("Exec", mock.Anything, mock.Anything, csiPodName, csiNamespace, csiServerContainerName, mock.MatchedBy(func(command []string) bool {
+		return strings.Contains(command[2], commandPart)
+	})).Return(bytes.NewBufferString(output), &bytes.Buffer{}, nil).Once()
+}
+
+func createCsiPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: csiNamespace,
+			Labels: map[string]string{
+				labels.AppNameLabel:      defaultOperatorAppName,
+				labels.AppComponentLabel: csiDriverComponentLabel,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: csiServerContainerName},
+				{Name: csiProvisionerContainerName},
+			},
+		},
+	}
+}
@@ -0,0 +1,281 @@
+package support_archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgocorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	injectedPodsCollectorName = "injectedPodsCollector"
+	injectionStateFileName    = "injection.yaml"
+	injectionSummaryFileName  = "summary.yaml"
+
+	dynatraceAnnotationDomain = "dynatrace.com/"
+	dynatraceEnvPrefix        = "DT_"
+	ldPreloadEnv              = "LD_PRELOAD"
+)
+
+// injectionSummary gives an overview of the injection in a namespace, as only a sample of the pods is collected.
+type injectionSummary struct {
+	Namespace    string   `json:"namespace"`
+	SampledPods  []string `json:"sampledPods"`
+	Pods         int      `json:"pods"`
+	InjectedPods int      `json:"injectedPods"`
+}
+
+// injectionState contains everything the webhook added to a pod, the application containers are only listed with the injected env.
+type injectionState struct {
+	InitContainer *initContainerState       `json:"initContainer,omitempty"`
+	Annotations   map[string]string         `json:"annotations,omitempty"`
+	Name          string                    `json:"name"`
+	Namespace     string                    `json:"namespace"`
+	Owner         string                    `json:"owner,omitempty"`
+	Phase         corev1.PodPhase           `json:"phase"`
+	Containers    []containerInjectionState `json:"containers"`
+}
+
+type initContainerState struct {
+	Status *corev1.ContainerStatus `json:"status,omitempty"`
+	Image  string                  `json:"image"`
+	Env    []corev1.EnvVar         `json:"env,omitempty"`
+}
+
+type containerInjectionState struct {
+	Name string          `json:"name"`
+	Env  []corev1.EnvVar `json:"env,omitempty"`
+}
+
+type injectedPodsCollector struct {
+	collectorCommon
+
+	ctx              context.Context
+	coreV1           clientgocorev1.CoreV1Interface
+	maxNamespaces    int
+	maxPodsNamespace int
+}
+
+func newInjectedPodsCollector(context context.Context, log logd.Logger, supportArchive archiver, coreV1 clientgocorev1.CoreV1Interface, maxNamespaces int, maxPodsPerNamespace int) collector { //nolint:revive // argument-limit doesn't apply to constructors
+	return injectedPodsCollector{
+		collectorCommon: collectorCommon{
+			log:            log,
+			supportArchive: supportArchive,
+		},
+		ctx:              context,
+		coreV1:           coreV1,
+		maxNamespaces:    maxNamespaces,
+		maxPodsNamespace: maxPodsPerNamespace,
+	}
+}
+
+func (collector injectedPodsCollector) Name() string {
+	return injectedPodsCollectorName
+}
+
+func (collector injectedPodsCollector) Do() error {
+	if !installconfig.GetModules().Supportability {
+		logInfof(collector.log, "%s", installconfig.GetModuleValidationErrorMessage("Injected Pods Collection"))
+
+		return nil
+	}
+
+	if collector.maxNamespaces <= 0 || collector.maxPodsNamespace <= 0 {
+		return nil
+	}
+
+	logInfof(collector.log, "Starting injected pods collection")
+
+	namespaces, err := collector.coreV1.Namespaces().List(collector.ctx, metav1.ListOptions{LabelSelector: webhook.InjectionInstanceLabel})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	sort.Slice(namespaces.Items, func(i, j int) bool {
+		return namespaces.Items[i].Name < namespaces.Items[j].Name
+	})
+
+	if len(namespaces.Items) > collector.maxNamespaces {
+		logInfof(collector.log, "Collecting injected pods of %d out of %d namespaces", collector.maxNamespaces, len(namespaces.Items))
+
+		namespaces.Items = namespaces.Items[:collector.maxNamespaces]
+	}
+
+	for _, namespace := range namespaces.Items {
+		err := collector.collectNamespace(namespace.Name)
+		if err != nil {
+			logErrorf(collector.log, err, "Failed to collect injected pods in namespace %s", namespace.Name)
+		}
+	}
+
+	return nil
+}
+
+func (collector injectedPodsCollector) collectNamespace(namespace string) error {
+	pods := collector.coreV1.Pods(namespace)
+
+	podList, err := pods.List(collector.ctx, metav1.ListOptions{})
+	if k8serrors.IsForbidden(err) {
+		// the operator may only read pods in the namespaces granted via rbac.supportabilityNamespaces of the helm chart
+		logInfof(collector.log, "Skipping injected pods in namespace %s, the operator is not allowed to list its pods", namespace)
+
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	sort.Slice(podList.Items, func(i, j int) bool {
+		return podList.Items[i].Name < podList.Items[j].Name
+	})
+
+	summary := injectionSummary{
+		Namespace:   namespace,
+		Pods:        len(podList.Items),
+		SampledPods: []string{},
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !isInjected(pod) {
+			continue
+		}
+
+		summary.InjectedPods++
+
+		if len(summary.SampledPods) >= collector.maxPodsNamespace {
+			continue
+		}
+
+		summary.SampledPods = append(summary.SampledPods, pod.Name)
+
+		collector.storeYaml(buildInjectedPodFileName(pod, injectionStateFileName), buildInjectionState(pod))
+		collector.collectInitContainerLogs(pods, pod)
+	}
+
+	collector.storeYaml(fmt.Sprintf("%s/%s/%s", InjectedPodsDirectoryName, namespace, injectionSummaryFileName), summary)
+
+	return nil
+}
+
+// collectInitContainerLogs only collects the logs of the init container added by the webhook, logs of the application are never collected.
+func (collector injectedPodsCollector) collectInitContainerLogs(pods clientgocorev1.PodInterface, pod *corev1.Pod) {
+	if getInitContainer(pod) == nil {
+		return
+	}
+
+	podLogs, err := pods.GetLogs(pod.Name, &corev1.PodLogOptions{Container: webhook.InstallContainerName}).Stream(collector.ctx)
+	if err != nil {
+		logErrorf(collector.log, err, "error in opening stream")
+
+		return
+	}
+
+	defer podLogs.Close()
+
+	fileName := buildInjectedPodFileName(pod, webhook.InstallContainerName+".log")
+
+	err = collector.supportArchive.addFile(fileName, podLogs)
+	if err != nil {
+		logErrorf(collector.log, err, "error writing to tarball")
+
+		return
+	}
+
+	logInfof(collector.log, "Successfully collected logs %s", fileName)
+}
+
+func (collector injectedPodsCollector) storeYaml(fileName string, content any) {
+	yamlContent, err := yaml.Marshal(content)
+	if err != nil {
+		logErrorf(collector.log, err, "Failed to marshal %s", fileName)
+
+		return
+	}
+
+	err = collector.supportArchive.addFile(fileName, bytes.NewBuffer(yamlContent))
+	if err != nil {
+		logErrorf(collector.log, err, "Failed to add %s to support archive", fileName)
+
+		return
+	}
+
+	logInfof(collector.log, "Collected injection state %s", fileName)
+}
+
+func isInjected(pod *corev1.Pod) bool {
+	_, hasAnnotation := pod.Annotations[webhook.AnnotationDynatraceInjected]
+
+	return hasAnnotation || getInitContainer(pod) != nil
+}
+
+func getInitContainer(pod *corev1.Pod) *corev1.Container {
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == webhook.InstallContainerName {
+			return &pod.Spec.InitContainers[i]
+		}
+	}
+
+	return nil
+}
+
+func buildInjectionState(pod *corev1.Pod) injectionState {
+	state := injectionState{
+		Name:        pod.Name,
+		Namespace:   pod.Namespace,
+		Phase:       pod.Status.Phase,
+		Annotations: map[string]string{},
+		Containers:  []containerInjectionState{},
+	}
+
+	if len(pod.OwnerReferences) > 0 {
+		state.Owner = pod.OwnerReferences[0].Kind + "/" + pod.OwnerReferences[0].Name
+	}
+
+	for key, value := range pod.Annotations {
+		if strings.Contains(key, dynatraceAnnotationDomain) {
+			state.Annotations[key] = value
+		}
+	}
+
+	if initContainer := getInitContainer(pod); initContainer != nil {
+		state.InitContainer = &initContainerState{
+			Image: initContainer.Image,
+			Env:   initContainer.Env,
+		}
+
+		for i := range pod.Status.InitContainerStatuses {
+			if pod.Status.InitContainerStatuses[i].Name == webhook.InstallContainerName {
+				state.InitContainer.Status = &pod.Status.InitContainerStatuses[i]
+			}
+		}
+	}
+
+	for _, container := range pod.Spec.Containers {
+		containerState := containerInjectionState{Name: container.Name}
+
+		for _, env := range container.Env {
+			if strings.HasPrefix(env.Name, dynatraceEnvPrefix) || env.Name == ldPreloadEnv {
+				containerState.Env = append(containerState.Env, env)
+			}
+		}
+
+		state.Containers = append(state.Containers, containerState)
+	}
+
+	return state
+}
+
+func buildInjectedPodFileName(pod *corev1.Pod, fileName string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", InjectedPodsDirectoryName, pod.Namespace, pod.Name, fileName)
+}
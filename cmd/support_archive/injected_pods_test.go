@@ -0,0 +1,176 @@
+package support_archive
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+)
+
+const testInjectedNamespace = "app"
+
+func TestInjectedPodsCollector(t *testing.T) {
+	t.Run("injection state and init container logs of injected pods are collected", func(t *testing.T) {
+		fakeClientSet := fake.NewSimpleClientset(
+			createInjectedNamespace(testInjectedNamespace),
+			createInjectedPod("injected", testInjectedNamespace),
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "not-injected", Namespace: testInjectedNamespace},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			},
+		)
+
+		files := runInjectedPodsCollector(t, fakeClientSet, 10, 10)
+
+		require.Contains(t, files, "injected_pods/app/injected/injection.yaml")
+		require.Contains(t, files, "injected_pods/app/injected/dynatrace-operator.log")
+		require.Contains(t, files, "injected_pods/app/summary.yaml")
+		assert.NotContains(t, files, "injected_pods/app/not-injected/injection.yaml")
+		assert.Len(t, files, 3)
+
+		var state injectionState
+		require.NoError(t, yaml.Unmarshal([]byte(files["injected_pods/app/injected/injection.yaml"]), &state))
+
+		assert.Equal(t, map[string]string{
+			webhook.AnnotationDynatraceInjected: "true",
+			webhook.AnnotationOneAgentInjected:  "true",
+		}, state.Annotations)
+		require.NotNil(t, state.InitContainer)
+		assert.Equal(t, "operator-image", state.InitContainer.Image)
+		require.NotNil(t, state.InitContainer.Status)
+		assert.Equal(t, int32(0), state.InitContainer.Status.State.Terminated.ExitCode)
+		require.Len(t, state.Containers, 1)
+		assert.Equal(t, []corev1.EnvVar{{Name: "LD_PRELOAD", Value: "/opt/dynatrace/oneagent-paas/agent/lib64/liboneagentproc.so"}, {Name: "DT_DEPLOYMENT_METADATA", Value: "orchestration_tech=Operator"}}, state.Containers[0].Env)
+
+		var summary injectionSummary
+		require.NoError(t, yaml.Unmarshal([]byte(files["injected_pods/app/summary.yaml"]), &summary))
+
+		assert.Equal(t, injectionSummary{Namespace: testInjectedNamespace, Pods: 2, InjectedPods: 1, SampledPods: []string{"injected"}}, summary)
+	})
+	t.Run("pods and namespaces are limited", func(t *testing.T) {
+		fakeClientSet := fake.NewSimpleClientset(
+			createInjectedNamespace("app-1"),
+			createInjectedNamespace("app-2"),
+			createInjectedPod("pod-1", "app-1"),
+			createInjectedPod("pod-2", "app-1"),
+			createInjectedPod("pod-1", "app-2"),
+		)
+
+		files := runInjectedPodsCollector(t, fakeClientSet, 1, 1)
+
+		assert.Contains(t, files, "injected_pods/app-1/pod-1/injection.yaml")
+		assert.NotContains(t, files, "injected_pods/app-1/pod-2/injection.yaml")
+		assert.NotContains(t, files, "injected_pods/app-2/summary.yaml")
+
+		var summary injectionSummary
+		require.NoError(t, yaml.Unmarshal([]byte(files["injected_pods/app-1/summary.yaml"]), &summary))
+
+		assert.Equal(t, 2, summary.InjectedPods)
+		assert.Equal(t, []string{"pod-1"}, summary.SampledPods)
+	})
+	t.Run("namespaces without permission to list pods are skipped", func(t *testing.T) {
+		fakeClientSet := fake.NewSimpleClientset(
+			createInjectedNamespace("app-1"),
+			createInjectedNamespace("app-2"),
+			createInjectedPod("pod-1", "app-1"),
+			createInjectedPod("pod-1", "app-2"),
+		)
+		fakeClientSet.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetNamespace() != "app-1" {
+				return false, nil, nil
+			}
+
+			return true, nil, k8serrors.NewForbidden(corev1.Resource("pods"), "", errors.New("not granted"))
+		})
+
+		files := runInjectedPodsCollector(t, fakeClientSet, 10, 10)
+
+		assert.NotContains(t, files, "injected_pods/app-1/summary.yaml")
+		assert.Contains(t, files, "injected_pods/app-2/pod-1/injection.yaml")
+		assert.Contains(t, files, "injected_pods/app-2/summary.yaml")
+	})
+	t.Run("collection can be disabled", func(t *testing.T) {
+		fakeClientSet := fake.NewSimpleClientset(
+			createInjectedNamespace(testInjectedNamespace),
+			createInjectedPod("injected", testInjectedNamespace),
+		)
+
+		files := runInjectedPodsCollector(t, fakeClientSet, 0, 10)
+
+		assert.Empty(t, files)
+	})
+}
+
+func runInjectedPodsCollector(t *testing.T, fakeClientSet *fake.Clientset, maxNamespaces, maxPods int) map[string]string {
+	logBuffer := bytes.Buffer{}
+	buffer := bytes.Buffer{}
+	supportArchive := newZipArchive(&buffer)
+
+	collector := newInjectedPodsCollector(context.Background(), newSupportArchiveLogger(&logBuffer), supportArchive, fakeClientSet.CoreV1(), maxNamespaces, maxPods)
+	require.NoError(t, collector.Do())
+	require.NoError(t, supportArchive.Close())
+
+	return readZipFiles(t, buffer.Bytes())
+}
+
+func createInjectedNamespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{webhook.InjectionInstanceLabel: "dynakube"},
+		},
+	}
+}
+
+func createInjectedPod(name, namespace string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				webhook.AnnotationDynatraceInjected: "true",
+				webhook.AnnotationOneAgentInjected:  "true",
+				"app.example.com/version":           "1.0",
+			},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name:  webhook.InstallContainerName,
+					Image: "operator-image",
+					Env:   []corev1.EnvVar{{Name: "FAILURE_POLICY", Value: "silent"}},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Env: []corev1.EnvVar{
+						{Name: "APP_PASSWORD", Value: "secret"},
+						{Name: "LD_PRELOAD", Value: "/opt/dynatrace/oneagent-paas/agent/lib64/liboneagentproc.so"},
+						{Name: "DT_DEPLOYMENT_METADATA", Value: "orchestration_tech=Operator"},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name:  webhook.InstallContainerName,
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+				},
+			},
+		},
+	}
+}
@@ -1,8 +1,11 @@
 package support_archive
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/installconfig"
@@ -12,20 +15,52 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientgocorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
 )
 
-const logCollectorName = "logCollector"
+const (
+	logCollectorName = "logCollector"
+
+	truncationReasonContainerLimit = "container-limit"
+	truncationReasonArchiveLimit   = "archive-limit"
+)
+
+// logCollectionOptions limits which logs are collected and how much of them, zero values disable the corresponding limit.
+type logCollectionOptions struct {
+	labelSelector       string
+	nodeNames           []string
+	since               time.Duration
+	tailLines           int64
+	maxContainerLogSize int64
+	maxTotalLogSize     int64
+}
+
+// logTruncationManifest lists the logs that were cut off or left out because of a size limit.
+type logTruncationManifest struct {
+	Files []truncatedLogFile `json:"files"`
+}
+
+type truncatedLogFile struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+	// Size is the number of bytes in the archive, it is 0 if the log was skipped
+	Size int64 `json:"size"`
+}
 
 type logCollector struct {
 	collectorCommon
 
 	ctx                context.Context
 	pods               clientgocorev1.PodInterface
+	truncations        *logTruncationManifest
+	collectedLogSize   *int64
 	appName            string
+	options            logCollectionOptions
 	collectManagedLogs bool
 }
 
-func newLogCollector(context context.Context, log logd.Logger, supportArchive archiver, pods clientgocorev1.PodInterface, appName string, collectManagedLogs bool) collector { //nolint:revive // argument-limit doesn't apply to constructors
+func newLogCollector(context context.Context, log logd.Logger, supportArchive archiver, pods clientgocorev1.PodInterface, appName string, collectManagedLogs bool, options logCollectionOptions) collector { //nolint:revive // argument-limit doesn't apply to constructors
 	return logCollector{
 		collectorCommon: collectorCommon{
 			log:            log,
@@ -35,6 +70,9 @@ func newLogCollector(context context.Context, log logd.Logger, supportArchive ar
 		pods:               pods,
 		appName:            appName,
 		collectManagedLogs: collectManagedLogs,
+		options:            options,
+		truncations:        &logTruncationManifest{Files: []truncatedLogFile{}},
+		collectedLogSize:   new(int64),
 	}
 }
 
@@ -64,6 +102,10 @@ func (lc logCollector) Do() error {
 	podGetOptions := metav1.GetOptions{}
 
 	for _, podItem := range podList.Items {
+		if !lc.isOnSelectedNode(podItem) {
+			continue
+		}
+
 		pod, err := lc.pods.Get(lc.ctx, podItem.Name, podGetOptions)
 		if err != nil {
 			logErrorf(lc.log, err, "Unable to get pod info for %s", podItem.Name)
@@ -72,7 +114,36 @@ func (lc logCollector) Do() error {
 		}
 	}
 
-	return nil
+	return lc.storeTruncationManifest()
+}
+
+func (lc logCollector) isOnSelectedNode(pod corev1.Pod) bool {
+	if len(lc.options.nodeNames) == 0 {
+		return true
+	}
+
+	for _, nodeName := range lc.options.nodeNames {
+		if pod.Spec.NodeName == nodeName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (lc logCollector) storeTruncationManifest() error {
+	if len(lc.truncations.Files) == 0 {
+		return nil
+	}
+
+	manifest, err := yaml.Marshal(lc.truncations)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	logInfof(lc.log, "%d logs were truncated or skipped because of size limits, see %s", len(lc.truncations.Files), LogTruncationManifestFileName)
+
+	return lc.supportArchive.addFile(LogTruncationManifestFileName, bytes.NewReader(manifest))
 }
 
 func (lc logCollector) Name() string {
@@ -87,6 +158,10 @@ func (lc logCollector) getPodList(labelKey string) (*corev1.PodList, error) {
 		LabelSelector: fmt.Sprintf("%s=%s", labelKey, lc.appName),
 	}
 
+	if lc.options.labelSelector != "" {
+		listOptions.LabelSelector += "," + lc.options.labelSelector
+	}
+
 	podList, err := lc.pods.List(lc.ctx, listOptions)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -101,6 +176,15 @@ func (lc logCollector) collectPodLogs(pod *corev1.Pod) {
 			Container: container.Name,
 			Follow:    false,
 		}
+
+		if lc.options.since > 0 {
+			podLogOpts.SinceSeconds = ptr.To(int64(lc.options.since.Seconds()))
+		}
+
+		if lc.options.tailLines > 0 {
+			podLogOpts.TailLines = ptr.To(lc.options.tailLines)
+		}
+
 		lc.collectContainerLogs(pod, container, podLogOpts)
 
 		podLogOpts.Previous = true
@@ -109,6 +193,20 @@ func (lc logCollector) collectPodLogs(pod *corev1.Pod) {
 }
 
 func (lc logCollector) collectContainerLogs(pod *corev1.Pod, container corev1.Container, logOptions corev1.PodLogOptions) {
+	fileName := buildLogFileName(pod, container, logOptions)
+
+	limit, reason := lc.getSizeLimit()
+	if limit == 0 {
+		lc.truncations.Files = append(lc.truncations.Files, truncatedLogFile{Name: fileName, Reason: reason})
+
+		return
+	}
+
+	if limit > 0 {
+		// one more byte than the limit is requested, to know whether the log was truncated
+		logOptions.LimitBytes = ptr.To(limit + 1)
+	}
+
 	req := lc.pods.GetLogs(pod.Name, &logOptions)
 	if req == nil {
 		logErrorf(lc.log, errors.Errorf("Unable to retrieve log stream for pod %s, container %s", pod.Name, container.Name), "")
@@ -133,9 +231,16 @@ func (lc logCollector) collectContainerLogs(pod *corev1.Pod, container corev1.Co
 
 	defer podLogs.Close()
 
-	fileName := buildLogFileName(pod, container, logOptions)
+	logReader := &truncatingReader{source: podLogs, remaining: limit}
+
+	err = lc.supportArchive.addFile(fileName, logReader)
+
+	*lc.collectedLogSize += logReader.read
+
+	if logReader.truncated {
+		lc.truncations.Files = append(lc.truncations.Files, truncatedLogFile{Name: fileName, Reason: reason, Size: logReader.read})
+	}
 
-	err = lc.supportArchive.addFile(fileName, podLogs)
 	if err != nil {
 		logErrorf(lc.log, err, "error writing to tarball")
 
@@ -152,3 +257,68 @@ func buildLogFileName(pod *corev1.Pod, container corev1.Container, logOptions co
 
 	return fmt.Sprintf("%s/%s/%s.log", LogsDirectoryName, pod.Name, container.Name)
 }
+
+// getSizeLimit returns the maximum size of the next log and which limit applies, -1 means there is no limit.
+func (lc logCollector) getSizeLimit() (int64, string) {
+	limit := int64(-1)
+	reason := ""
+
+	if lc.options.maxContainerLogSize > 0 {
+		limit = lc.options.maxContainerLogSize
+		reason = truncationReasonContainerLimit
+	}
+
+	if lc.options.maxTotalLogSize > 0 {
+		remaining := max(lc.options.maxTotalLogSize-*lc.collectedLogSize, 0)
+		if limit < 0 || remaining < limit {
+			limit = remaining
+			reason = truncationReasonArchiveLimit
+		}
+	}
+
+	return limit, reason
+}
+
+// truncatingReader stops reading after the given number of bytes and records if there was more data, a negative limit disables it.
+type truncatingReader struct {
+	source    io.Reader
+	remaining int64
+	read      int64
+	truncated bool
+}
+
+func (r *truncatingReader) Read(p []byte) (int, error) {
+	if r.remaining == 0 {
+		r.truncated = r.hasMoreData()
+
+		return 0, io.EOF
+	}
+
+	if r.remaining > 0 && int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+
+	n, err := r.source.Read(p)
+	r.read += int64(n)
+
+	if r.remaining > 0 {
+		r.remaining -= int64(n)
+	}
+
+	return n, err
+}
+
+func (r *truncatingReader) hasMoreData() bool {
+	probe := make([]byte, 1)
+
+	for {
+		n, err := r.source.Read(probe)
+		if n > 0 {
+			return true
+		}
+
+		if err != nil {
+			return false
+		}
+	}
+}
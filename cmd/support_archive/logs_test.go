@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	corev1mock "github.com/Dynatrace/dynatrace-operator/test/mocks/k8s.io/client-go/kubernetes/typed/core/v1"
@@ -16,8 +17,11 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
 )
 
 func TestLogCollector(t *testing.T) {
@@ -60,7 +64,8 @@ func testLogCollection(t *testing.T, collectManagedLogs bool) {
 		supportArchive,
 		fakeClientSet.CoreV1().Pods("dynatrace"),
 		defaultOperatorAppName,
-		collectManagedLogs)
+		collectManagedLogs,
+		logCollectionOptions{})
 
 	require.NoError(t, logCollector.Do())
 
@@ -115,7 +120,8 @@ func TestLogCollectorPodListError(t *testing.T) {
 		supportArchive,
 		mockedPods,
 		defaultOperatorAppName,
-		true)
+		true,
+		logCollectionOptions{})
 	require.Error(t, logCollector.Do())
 }
 
@@ -158,7 +164,7 @@ func TestLogCollectorGetPodFail(t *testing.T) {
 		Get(ctx, "oneagent", metav1.GetOptions{}).
 		Return(nil, assert.AnError)
 
-	logCollector := newLogCollector(ctx, newSupportArchiveLogger(&logBuffer), supportArchive, mockedPods, defaultOperatorAppName, true)
+	logCollector := newLogCollector(ctx, newSupportArchiveLogger(&logBuffer), supportArchive, mockedPods, defaultOperatorAppName, true, logCollectionOptions{})
 	require.NoError(t, logCollector.Do())
 }
 
@@ -231,7 +237,7 @@ func TestLogCollectorGetLogsFail(t *testing.T) {
 		NotBefore(getLogsPod2Container2Call).
 		Return(nil, assert.AnError)
 
-	logCollector := newLogCollector(ctx, newSupportArchiveLogger(&logBuffer), supportArchive, mockedPods, defaultOperatorAppName, true)
+	logCollector := newLogCollector(ctx, newSupportArchiveLogger(&logBuffer), supportArchive, mockedPods, defaultOperatorAppName, true, logCollectionOptions{})
 	require.NoError(t, logCollector.Do())
 
 	assert.Contains(t, logBuffer.String(), "Unable to retrieve log stream for pod pod1, container container1")
@@ -292,7 +298,7 @@ func TestLogCollectorNoAbortOnError(t *testing.T) {
 		NotBefore(getLogsPod2Container2Call).
 		Return(nil, assert.AnError)
 
-	logCollector := newLogCollector(ctx, newSupportArchiveLogger(&logBuffer), supportArchive, mockedPods, defaultOperatorAppName, true)
+	logCollector := newLogCollector(ctx, newSupportArchiveLogger(&logBuffer), supportArchive, mockedPods, defaultOperatorAppName, true, logCollectionOptions{})
 	require.NoError(t, logCollector.Do())
 
 	assertNoErrorOnClose(t, supportArchive)
@@ -343,3 +349,96 @@ func createGetPodLogOptions(container string, previous bool) *corev1.PodLogOptio
 		Previous:  previous,
 	}
 }
+
+func TestLogCollectorLimits(t *testing.T) {
+	// the fake clientset always returns "fake logs" as log of a container
+	const fakeLogs = "fake logs"
+
+	runLimitedLogCollector := func(t *testing.T, options logCollectionOptions, pods ...*corev1.Pod) map[string]string {
+		objects := make([]runtime.Object, 0, len(pods))
+		for _, pod := range pods {
+			objects = append(objects, pod)
+		}
+
+		fakeClientSet := fake.NewSimpleClientset(objects...)
+		buffer := bytes.Buffer{}
+		supportArchive := newZipArchive(&buffer)
+
+		logCollector := newLogCollector(context.Background(), newSupportArchiveLogger(&bytes.Buffer{}), supportArchive, fakeClientSet.CoreV1().Pods("dynatrace"), defaultOperatorAppName, false, options)
+		require.NoError(t, logCollector.Do())
+		require.NoError(t, supportArchive.Close())
+
+		return readZipFiles(t, buffer.Bytes())
+	}
+
+	t.Run("container logs are truncated", func(t *testing.T) {
+		files := runLimitedLogCollector(t, logCollectionOptions{maxContainerLogSize: 4}, createPod("pod1", labels.AppNameLabel))
+
+		assert.Equal(t, "fake", files["logs/pod1/container1.log"])
+
+		var manifest logTruncationManifest
+		require.NoError(t, yaml.Unmarshal([]byte(files[LogTruncationManifestFileName]), &manifest))
+		assert.Contains(t, manifest.Files, truncatedLogFile{Name: "logs/pod1/container1.log", Reason: truncationReasonContainerLimit, Size: 4})
+		assert.Len(t, manifest.Files, 4)
+	})
+	t.Run("logs are skipped after the total limit", func(t *testing.T) {
+		files := runLimitedLogCollector(t, logCollectionOptions{maxTotalLogSize: int64(len(fakeLogs)) + 1}, createPod("pod1", labels.AppNameLabel))
+
+		assert.Equal(t, fakeLogs, files["logs/pod1/container1.log"])
+		assert.Equal(t, "f", files["logs/pod1/container1_previous.log"])
+		assert.NotContains(t, files, "logs/pod1/container2.log")
+
+		var manifest logTruncationManifest
+		require.NoError(t, yaml.Unmarshal([]byte(files[LogTruncationManifestFileName]), &manifest))
+		assert.Equal(t, []truncatedLogFile{
+			{Name: "logs/pod1/container1_previous.log", Reason: truncationReasonArchiveLimit, Size: 1},
+			{Name: "logs/pod1/container2.log", Reason: truncationReasonArchiveLimit},
+			{Name: "logs/pod1/container2_previous.log", Reason: truncationReasonArchiveLimit},
+		}, manifest.Files)
+	})
+	t.Run("no manifest without truncation", func(t *testing.T) {
+		files := runLimitedLogCollector(t, logCollectionOptions{maxContainerLogSize: Mebi, maxTotalLogSize: Mebi}, createPod("pod1", labels.AppNameLabel))
+
+		assert.Equal(t, fakeLogs, files["logs/pod1/container1.log"])
+		assert.NotContains(t, files, LogTruncationManifestFileName)
+	})
+	t.Run("only pods on the selected nodes", func(t *testing.T) {
+		pod1 := createPod("pod1", labels.AppNameLabel)
+		pod1.Spec.NodeName = "node1"
+		pod2 := createPod("pod2", labels.AppNameLabel)
+		pod2.Spec.NodeName = "node2"
+
+		files := runLimitedLogCollector(t, logCollectionOptions{nodeNames: []string{"node2"}}, pod1, pod2)
+
+		assert.NotContains(t, files, "logs/pod1/container1.log")
+		assert.Contains(t, files, "logs/pod2/container1.log")
+	})
+	t.Run("since, tail and label selector are passed to the api", func(t *testing.T) {
+		ctx := context.Background()
+		fakeClientSet := fake.NewSimpleClientset(createPod("pod1", labels.AppNameLabel))
+
+		listOptions := createPodListOptions(labels.AppNameLabel)
+		listOptions.LabelSelector += ",app.kubernetes.io/component=oneagent"
+
+		logOptions := createGetPodLogOptions("container1", false)
+		logOptions.SinceSeconds = ptr.To(int64(3600))
+		logOptions.TailLines = ptr.To(int64(100))
+		logOptions.LimitBytes = ptr.To(int64(Mebi + 1))
+
+		mockedPods := corev1mock.NewPodInterface(t)
+		mockedPods.EXPECT().List(ctx, listOptions).Return(fakeClientSet.CoreV1().Pods("dynatrace").List(ctx, createPodListOptions(labels.AppNameLabel)))
+		mockedPods.EXPECT().Get(ctx, "pod1", metav1.GetOptions{}).Return(fakeClientSet.CoreV1().Pods("dynatrace").Get(ctx, "pod1", metav1.GetOptions{}))
+		mockedPods.EXPECT().GetLogs("pod1", logOptions).Return(nil).Once()
+		mockedPods.EXPECT().GetLogs("pod1", mock.Anything).Return(nil)
+
+		options := logCollectionOptions{
+			labelSelector:       "app.kubernetes.io/component=oneagent",
+			since:               time.Hour,
+			tailLines:           100,
+			maxContainerLogSize: Mebi,
+		}
+
+		logCollector := newLogCollector(ctx, newSupportArchiveLogger(&bytes.Buffer{}), newZipArchive(&bytes.Buffer{}), mockedPods, defaultOperatorAppName, false, options)
+		require.NoError(t, logCollector.Do())
+	})
+}
@@ -0,0 +1,287 @@
+package support_archive
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	RedactionManifestFileName = "redaction-manifest.yaml"
+
+	redactedValue = "<redacted>"
+
+	dynatraceTokenRuleName = "dynatrace-token"
+	urlCredentialsRuleName = "url-credentials"
+	secretDataRuleName     = "secret-data"
+
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// redactionRule replaces every match of the pattern in the collected files, the replacement may reference groups of the pattern.
+type redactionRule struct {
+	pattern     *regexp.Regexp
+	name        string
+	replacement string
+}
+
+// redactionConfig is the format of the file provided by --redact-config.
+type redactionConfig struct {
+	Rules []redactionConfigRule `json:"rules"`
+}
+
+type redactionConfigRule struct {
+	Name        string `json:"name"`
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// redactionManifest lists how many values were redacted by which rule, it never contains the redacted values.
+type redactionManifest struct {
+	Rules []string                `json:"rules"`
+	Files []redactionManifestFile `json:"files"`
+}
+
+type redactionManifestFile struct {
+	Redactions map[string]int `json:"redactions"`
+	Name       string         `json:"name"`
+}
+
+func builtinRedactionRules() []redactionRule {
+	return []redactionRule{
+		{
+			// API, PaaS, data ingest tokens (dt0c01.), as well as OAuth client secrets (dt0s02.) used by EdgeConnect
+			name:        dynatraceTokenRuleName,
+			pattern:     regexp.MustCompile(`dt0[a-z][0-9]{2}\.[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)?`),
+			replacement: redactedValue,
+		},
+		{
+			// user:password in urls, e.g. in the proxy of a Dynakube
+			name:        urlCredentialsRuleName,
+			pattern:     regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/\s:@"']+:[^/\s@"']*@`),
+			replacement: "${1}" + redactedValue + "@",
+		},
+	}
+}
+
+func loadRedactionRules(configPath string) ([]redactionRule, error) {
+	rules := builtinRedactionRules()
+
+	if configPath == "" {
+		return rules, nil
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not read redaction config")
+	}
+
+	var config redactionConfig
+
+	err = yaml.UnmarshalStrict(content, &config)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not parse redaction config '%s'", configPath)
+	}
+
+	for i, configRule := range config.Rules {
+		pattern, err := regexp.Compile(configRule.Pattern)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid pattern in redaction rule %d", i)
+		}
+
+		rule := redactionRule{
+			name:        configRule.Name,
+			pattern:     pattern,
+			replacement: configRule.Replacement,
+		}
+
+		if rule.name == "" {
+			rule.name = configRule.Pattern
+		}
+
+		if rule.replacement == "" {
+			rule.replacement = redactedValue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// redactingArchive scrubs the content of every file before passing it on to the archive.
+// Manifests are redacted as a whole, so the data of Secrets can be removed, everything else is redacted line by line.
+type redactingArchive struct {
+	archive  archiver
+	counts   map[string]map[string]int
+	rules    []redactionRule
+	fileList []string
+}
+
+func newRedactingArchive(archive archiver, rules []redactionRule) *redactingArchive {
+	return &redactingArchive{
+		archive: archive,
+		rules:   rules,
+		counts:  map[string]map[string]int{},
+	}
+}
+
+func (r *redactingArchive) addFile(fileName string, reader io.Reader) error {
+	counts := map[string]int{}
+	r.counts[fileName] = counts
+	r.fileList = append(r.fileList, fileName)
+
+	if strings.HasSuffix(fileName, ManifestsFileExtension) {
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return errors.WithMessagef(err, "could not read the file '%s'", fileName)
+		}
+
+		content = redactSecretData(content, counts)
+
+		return r.archive.addFile(fileName, strings.NewReader(r.redactText(string(content), counts)))
+	}
+
+	return r.archive.addFile(fileName, &redactingReader{
+		source: bufio.NewReader(reader),
+		redact: func(line string) string {
+			return r.redactText(line, counts)
+		},
+	})
+}
+
+func (r *redactingArchive) redactText(text string, counts map[string]int) string {
+	for _, rule := range r.rules {
+		matches := len(rule.pattern.FindAllStringIndex(text, -1))
+		if matches == 0 {
+			continue
+		}
+
+		counts[rule.name] += matches
+		text = rule.pattern.ReplaceAllString(text, rule.replacement)
+	}
+
+	return text
+}
+
+func (r *redactingArchive) manifest() redactionManifest {
+	manifest := redactionManifest{
+		Rules: []string{secretDataRuleName},
+		Files: []redactionManifestFile{},
+	}
+
+	for _, rule := range r.rules {
+		manifest.Rules = append(manifest.Rules, rule.name)
+	}
+
+	for _, fileName := range r.fileList {
+		if len(r.counts[fileName]) == 0 {
+			continue
+		}
+
+		manifest.Files = append(manifest.Files, redactionManifestFile{
+			Name:       fileName,
+			Redactions: r.counts[fileName],
+		})
+	}
+
+	return manifest
+}
+
+func (r *redactingArchive) redactionCount() int {
+	total := 0
+
+	for _, counts := range r.counts {
+		for _, count := range counts {
+			total += count
+		}
+	}
+
+	return total
+}
+
+// writeManifest adds the redaction manifest to the archive, it has to be called after all other files were added.
+func (r *redactingArchive) writeManifest() error {
+	manifest, err := yaml.Marshal(r.manifest())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return r.archive.addFile(RedactionManifestFileName, bytes.NewReader(manifest))
+}
+
+// redactSecretData replaces the values of Secrets in a manifest, it leaves the keys, so it is still visible which keys are set.
+func redactSecretData(content []byte, counts map[string]int) []byte {
+	var object map[string]any
+
+	err := yaml.Unmarshal(content, &object)
+	if err != nil || object["kind"] != "Secret" {
+		return content
+	}
+
+	redacted := 0
+
+	for _, field := range []string{"data", "stringData"} {
+		values, ok := object[field].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for key := range values {
+			values[key] = redactedValue
+			redacted++
+		}
+	}
+
+	if metadata, ok := object["metadata"].(map[string]any); ok {
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			if _, ok := annotations[lastAppliedConfigAnnotation]; ok {
+				annotations[lastAppliedConfigAnnotation] = redactedValue
+				redacted++
+			}
+		}
+	}
+
+	if redacted == 0 {
+		return content
+	}
+
+	redactedContent, err := yaml.Marshal(object)
+	if err != nil {
+		return content
+	}
+
+	counts[secretDataRuleName] += redacted
+
+	return redactedContent
+}
+
+type redactingReader struct {
+	source  *bufio.Reader
+	redact  func(string) string
+	err     error
+	pending []byte
+}
+
+func (r *redactingReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+
+		line, err := r.source.ReadString('\n')
+		r.err = err
+		r.pending = []byte(r.redact(line))
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
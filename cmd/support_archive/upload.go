@@ -0,0 +1,184 @@
+package support_archive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	uploadSchemeS3    = "s3"
+	uploadSchemeHttps = "https"
+	uploadSchemeHttp  = "http"
+)
+
+// archiveTarget is where the archive is written to.
+// Close is only called after the archive was written completely, Abort is called instead if anything failed,
+// so a partial archive is never uploaded.
+type archiveTarget interface {
+	io.WriteCloser
+	Abort(err error)
+}
+
+// fileTarget writes the archive to a file or stdout, a partial archive is kept there for inspection.
+type fileTarget struct {
+	*os.File
+}
+
+func (target fileTarget) Abort(_ error) {
+	_ = target.Close()
+}
+
+// newUploadTarget returns a writer, that uploads everything written to it.
+// The upload is only finished after a successful Close.
+func newUploadTarget(ctx context.Context, uploadUrl string, s3Endpoint string, transport *http.Transport) (archiveTarget, error) {
+	target, err := url.Parse(uploadUrl)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid upload url")
+	}
+
+	httpClient := &http.Client{Transport: transport}
+
+	switch target.Scheme {
+	case uploadSchemeS3:
+		credentials, err := getS3CredentialsFromEnv()
+		if err != nil {
+			return nil, err
+		}
+
+		return newS3Upload(ctx, httpClient, credentials, s3Endpoint, target)
+	case uploadSchemeHttps, uploadSchemeHttp:
+		return newHttpUpload(ctx, httpClient, target)
+	default:
+		return nil, errors.Errorf("unsupported upload url scheme '%s', use one of %s, %s", target.Scheme, uploadSchemeS3, uploadSchemeHttps)
+	}
+}
+
+// getUploadTransport uses the proxy and trustedCAs of the given Dynakube, if no Dynakube is given, the only Dynakube of the namespace is used.
+func getUploadTransport(ctx context.Context, log logd.Logger, apiReader client.Reader, namespace string, dynakubeName string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	var dk dynakube.DynaKube
+
+	if dynakubeName != "" {
+		err := apiReader.Get(ctx, client.ObjectKey{Name: dynakubeName, Namespace: namespace}, &dk)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get Dynakube '%s' for the upload settings", dynakubeName)
+		}
+	} else {
+		var dynakubes dynakube.DynaKubeList
+
+		err := apiReader.List(ctx, &dynakubes, client.InNamespace(namespace))
+		if err != nil || len(dynakubes.Items) != 1 {
+			logInfof(log, "Uploading without proxy and trusted CAs, use --%s to use the settings of a Dynakube", uploadDynakubeFlagName)
+
+			return transport, nil //nolint:nilerr // the Dynakube settings are optional
+		}
+
+		dk = dynakubes.Items[0]
+	}
+
+	logInfof(log, "Uploading with the proxy and trusted CAs of Dynakube '%s'", dk.Name)
+
+	return registry.PrepareTransportForDynaKube(ctx, apiReader, transport, &dk)
+}
+
+// redactUploadUrl removes the query, as it usually contains the token of a pre-signed url.
+func redactUploadUrl(uploadUrl string) string {
+	target, err := url.Parse(uploadUrl)
+	if err != nil {
+		return ""
+	}
+
+	target.RawQuery = ""
+	target.User = nil
+
+	return target.String()
+}
+
+// httpUpload spools the archive to a temporary file and uploads it with a single PUT request on Close.
+// The size of the archive has to be known upfront, as pre-signed urls of S3 and Azure reject chunked uploads without a Content-Length.
+type httpUpload struct {
+	ctx        context.Context
+	httpClient *http.Client
+	target     *url.URL
+	spool      *os.File
+}
+
+func newHttpUpload(ctx context.Context, httpClient *http.Client, target *url.URL) (*httpUpload, error) {
+	spool, err := os.CreateTemp("", "support-archive-*.zip")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create temporary file for the upload")
+	}
+
+	return &httpUpload{
+		ctx:        ctx,
+		httpClient: httpClient,
+		target:     target,
+		spool:      spool,
+	}, nil
+}
+
+func (upload *httpUpload) Write(p []byte) (int, error) {
+	return upload.spool.Write(p)
+}
+
+// Close uploads the spooled archive, the temporary file is removed in any case.
+func (upload *httpUpload) Close() error {
+	defer upload.removeSpool()
+
+	size, err := upload.spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = upload.spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	request, err := http.NewRequestWithContext(upload.ctx, http.MethodPut, upload.target.String(), io.NopCloser(upload.spool))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	request.ContentLength = size
+	request.Header.Set("Content-Type", "application/zip")
+
+	response, err := upload.httpClient.Do(request)
+	if err != nil {
+		return errors.WithMessage(err, "upload failed")
+	}
+
+	defer response.Body.Close()
+
+	return checkUploadResponse(response)
+}
+
+// Abort discards the spooled archive without uploading anything.
+func (upload *httpUpload) Abort(_ error) {
+	upload.removeSpool()
+}
+
+func (upload *httpUpload) removeSpool() {
+	_ = upload.spool.Close()
+	_ = os.Remove(upload.spool.Name())
+}
+
+func checkUploadResponse(response *http.Response) error {
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, Kibi))
+
+	return errors.Errorf("upload failed with status %d: %s", response.StatusCode, string(body))
+}
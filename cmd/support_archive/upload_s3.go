@@ -0,0 +1,334 @@
+package support_archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	s3AccessKeyIdEnv     = "AWS_ACCESS_KEY_ID"
+	s3SecretAccessKeyEnv = "AWS_SECRET_ACCESS_KEY"
+	s3SessionTokenEnv    = "AWS_SESSION_TOKEN"
+	s3RegionEnv          = "AWS_REGION"
+
+	s3DefaultRegion = "us-east-1"
+
+	// s3PartSize is the minimum size of a part of a multipart upload, except for the last one
+	s3PartSize = 5 * Mebi
+
+	s3Service          = "s3"
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3DateFormat       = "20060102T150405Z"
+)
+
+type s3Credentials struct {
+	accessKeyId     string
+	secretAccessKey string
+	sessionToken    string
+	region          string
+}
+
+func getS3CredentialsFromEnv() (s3Credentials, error) {
+	credentials := s3Credentials{
+		accessKeyId:     os.Getenv(s3AccessKeyIdEnv),
+		secretAccessKey: os.Getenv(s3SecretAccessKeyEnv),
+		sessionToken:    os.Getenv(s3SessionTokenEnv),
+		region:          os.Getenv(s3RegionEnv),
+	}
+
+	if credentials.accessKeyId == "" || credentials.secretAccessKey == "" {
+		return credentials, errors.Errorf("%s and %s have to be set to upload to S3", s3AccessKeyIdEnv, s3SecretAccessKeyEnv)
+	}
+
+	if credentials.region == "" {
+		credentials.region = s3DefaultRegion
+	}
+
+	return credentials, nil
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+	Parts   []s3CompletedPart `xml:"Part"`
+}
+
+type s3CompletedPart struct {
+	ETag       string `xml:"ETag"`
+	PartNumber int    `xml:"PartNumber"`
+}
+
+type s3InitiateMultipartUploadResult struct {
+	UploadId string `xml:"UploadId"`
+}
+
+// s3Upload uploads the archive in parts of s3PartSize using a multipart upload, so only one part has to be kept in memory.
+// The object is addressed path-style, which is supported by AWS as well as S3-compatible stores like MinIO.
+type s3Upload struct {
+	ctx         context.Context
+	httpClient  *http.Client
+	objectUrl   *url.URL
+	now         func() time.Time
+	uploadId    string
+	credentials s3Credentials
+	buffer      bytes.Buffer
+	parts       []s3CompletedPart
+}
+
+func newS3Upload(ctx context.Context, httpClient *http.Client, credentials s3Credentials, endpoint string, target *url.URL) (*s3Upload, error) {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", credentials.region)
+	}
+
+	bucket := target.Host
+	key := strings.TrimPrefix(target.Path, "/")
+
+	if bucket == "" || key == "" {
+		return nil, errors.Errorf("invalid S3 url '%s', expected s3://<bucket>/<key>", target.String())
+	}
+
+	objectUrl, err := url.Parse(strings.TrimSuffix(endpoint, "/") + "/" + bucket + "/" + key)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid S3 endpoint")
+	}
+
+	upload := &s3Upload{
+		ctx:         ctx,
+		httpClient:  httpClient,
+		credentials: credentials,
+		objectUrl:   objectUrl,
+		now:         time.Now,
+	}
+
+	err = upload.initiate()
+	if err != nil {
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (upload *s3Upload) Write(p []byte) (int, error) {
+	n, _ := upload.buffer.Write(p)
+
+	for upload.buffer.Len() >= s3PartSize {
+		err := upload.uploadPart(upload.buffer.Next(s3PartSize))
+		if err != nil {
+			upload.abort()
+
+			return 0, err
+		}
+	}
+
+	return n, nil
+}
+
+// Close uploads the remaining data and completes the upload, the object is only visible in the bucket afterwards.
+func (upload *s3Upload) Close() error {
+	if upload.buffer.Len() > 0 || len(upload.parts) == 0 {
+		err := upload.uploadPart(upload.buffer.Bytes())
+		if err != nil {
+			upload.abort()
+
+			return err
+		}
+	}
+
+	body, err := xml.Marshal(s3CompleteMultipartUpload{Parts: upload.parts})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = upload.do(http.MethodPost, url.Values{"uploadId": {upload.uploadId}}, body)
+	if err != nil {
+		upload.abort()
+
+		return errors.WithMessage(err, "failed to complete S3 upload")
+	}
+
+	return nil
+}
+
+// Abort removes the already uploaded parts, so the object never becomes visible in the bucket.
+func (upload *s3Upload) Abort(_ error) {
+	upload.abort()
+}
+
+func (upload *s3Upload) initiate() error {
+	response, err := upload.do(http.MethodPost, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return errors.WithMessage(err, "failed to start S3 upload")
+	}
+
+	var result s3InitiateMultipartUploadResult
+
+	err = xml.Unmarshal(response, &result)
+	if err != nil || result.UploadId == "" {
+		return errors.New("failed to start S3 upload, no upload id returned")
+	}
+
+	upload.uploadId = result.UploadId
+
+	return nil
+}
+
+func (upload *s3Upload) uploadPart(part []byte) error {
+	partNumber := len(upload.parts) + 1
+	query := url.Values{
+		"partNumber": {strconv.Itoa(partNumber)},
+		"uploadId":   {upload.uploadId},
+	}
+
+	request, err := upload.newRequest(http.MethodPut, query, part)
+	if err != nil {
+		return err
+	}
+
+	response, err := upload.httpClient.Do(request)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to upload part %d", partNumber)
+	}
+
+	defer response.Body.Close()
+
+	err = checkUploadResponse(response)
+	if err != nil {
+		return err
+	}
+
+	upload.parts = append(upload.parts, s3CompletedPart{PartNumber: partNumber, ETag: response.Header.Get("ETag")})
+
+	return nil
+}
+
+// abort removes the already uploaded parts, errors are ignored as the upload already failed.
+func (upload *s3Upload) abort() {
+	if upload.uploadId == "" {
+		return
+	}
+
+	_, _ = upload.do(http.MethodDelete, url.Values{"uploadId": {upload.uploadId}}, nil)
+	upload.uploadId = ""
+}
+
+func (upload *s3Upload) do(method string, query url.Values, body []byte) ([]byte, error) {
+	request, err := upload.newRequest(method, query, body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := upload.httpClient.Do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	defer response.Body.Close()
+
+	err = checkUploadResponse(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(response.Body)
+}
+
+func (upload *s3Upload) newRequest(method string, query url.Values, body []byte) (*http.Request, error) {
+	requestUrl := *upload.objectUrl
+	requestUrl.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(upload.ctx, method, requestUrl.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	signS3Request(request, body, upload.credentials, upload.now())
+
+	return request, nil
+}
+
+// signS3Request adds the AWS Signature Version 4 to the request, all headers set on the request are signed.
+func signS3Request(request *http.Request, body []byte, credentials s3Credentials, now time.Time) {
+	amzDate := now.UTC().Format(s3DateFormat)
+	date := amzDate[:8]
+	payloadHash := sha256Hex(body)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	if credentials.sessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", credentials.sessionToken)
+	}
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+
+	sort.Strings(headerNames)
+
+	canonicalHeaders := strings.Builder{}
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		canonicalS3Query(request.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, credentials.region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{s3SigningAlgorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSha256([]byte("AWS4"+credentials.secretAccessKey), date)
+	signingKey = hmacSha256(signingKey, credentials.region)
+	signingKey = hmacSha256(signingKey, s3Service)
+	signingKey = hmacSha256(signingKey, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm, credentials.accessKeyId, scope, signedHeaders, signature))
+}
+
+func canonicalS3Query(query url.Values) string {
+	// url.Values.Encode sorts by key, but encodes spaces as '+', which is not allowed by the signature
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
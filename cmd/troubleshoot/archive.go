@@ -0,0 +1,162 @@
+package troubleshoot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/klauspost/compress/zip"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	// archiveManifestsDirectory and archiveManifestsFileExtension have to match the layout of the support archive
+	archiveManifestsDirectory     = "manifests/"
+	archiveManifestsFileExtension = ".yaml"
+
+	archiveSkipReason = "can't be checked with a support archive"
+)
+
+// RunTroubleshootCmdFromArchive runs the checks against the manifests contained in a support archive.
+// Checks that need a connection to the cluster, the Dynatrace API or a registry are reported as skipped.
+func RunTroubleshootCmdFromArchive(ctx context.Context, log logd.Logger, namespaceName string, archivePath string) *Report {
+	report := NewReport()
+
+	logNewCheckf(log, "using support archive '%s'", archivePath)
+
+	apiReader, err := NewArchiveAPIReader(archivePath)
+	if err != nil {
+		logErrorf(log, "failed to read support archive (%v)", err)
+		report.failed(nil, archiveCheckName, err, "Provide a support archive created by the 'support-archive' command.")
+
+		return report
+	}
+
+	report.skipped(nil, oneAgentAPMCheckName, archiveSkipReason)
+
+	dks := checkPrerequisites(ctx, log, report, apiReader, namespaceName)
+
+	for _, dk := range dks {
+		runArchiveChecksForDynakube(ctx, log, report, apiReader, dk)
+	}
+
+	return report
+}
+
+// NewArchiveAPIReader creates a client.Reader serving the manifests of the support archive.
+// Manifests of kinds not known to the operator are ignored.
+func NewArchiveAPIReader(archivePath string) (client.Reader, error) {
+	archive, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer archive.Close()
+
+	decoder := serializer.NewCodecFactory(scheme.Scheme).UniversalDeserializer()
+
+	var objects []client.Object
+
+	for _, file := range archive.File {
+		if !strings.HasPrefix(file.Name, archiveManifestsDirectory) || !strings.HasSuffix(file.Name, archiveManifestsFileExtension) {
+			continue
+		}
+
+		manifest, err := readArchiveFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		decoded, _, err := decoder.Decode(manifest, nil, nil)
+		if err != nil {
+			continue
+		}
+
+		object, ok := decoded.(client.Object)
+		if !ok {
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(objects...).
+		Build(), nil
+}
+
+func readArchiveFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not open '%s' in support archive", file.Name)
+	}
+	defer reader.Close()
+
+	manifest, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not read '%s' in support archive", file.Name)
+	}
+
+	return manifest, nil
+}
+
+// runArchiveChecksForDynakube runs the checks that only need the manifests of the cluster.
+// Secrets are not part of the support archive by default, so the checks relying on them are only run if they were added.
+func runArchiveChecksForDynakube(ctx context.Context, baseLog logd.Logger, report *Report, apiReader client.Reader, dk dynakube.DynaKube) {
+	log := baseLog.WithName(dynakubeCheckLoggerName)
+
+	logNewCheckf(log, "checking if '%s:%s' Dynakube is configured correctly", dk.Namespace, dk.Name)
+
+	if isSecretInArchive(ctx, apiReader, dk.Namespace, dk.Tokens()) {
+		_, err := checkIfDynatraceApiSecretHasApiToken(ctx, baseLog, apiReader, &dk)
+		if err != nil {
+			logErrorf(log, "%v", err)
+			report.failed(&dk, apiTokenCheckName, err, apiTokenRemediation(&dk))
+		} else {
+			report.passed(&dk, apiTokenCheckName, "secret token 'apiToken' exists")
+		}
+	} else {
+		logInfof(log, "'%s:%s' secret is not part of the support archive", dk.Namespace, dk.Tokens())
+		report.skipped(&dk, apiTokenCheckName, fmt.Sprintf("'%s:%s' secret is not part of the support archive", dk.Namespace, dk.Tokens()))
+	}
+
+	report.skipped(&dk, tokenScopesCheckName, archiveSkipReason)
+	report.skipped(&dk, apiUrlCheckName, archiveSkipReason)
+
+	if isSecretInArchive(ctx, apiReader, dk.Namespace, dk.PullSecretName()) {
+		pullSecret, err := checkPullSecretExists(ctx, baseLog, apiReader, &dk)
+		if err == nil {
+			err = checkPullSecretHasRequiredTokens(baseLog, &dk, pullSecret)
+		}
+
+		if err != nil {
+			logErrorf(log, "%v", err)
+			report.failed(&dk, pullSecretCheckName, err, pullSecretRemediation)
+		} else {
+			report.passed(&dk, pullSecretCheckName, fmt.Sprintf("pull secret '%s:%s' is valid", dk.Namespace, dk.PullSecretName()))
+		}
+	} else {
+		logInfof(log, "'%s:%s' pull secret is not part of the support archive", dk.Namespace, dk.PullSecretName())
+		report.skipped(&dk, pullSecretCheckName, fmt.Sprintf("'%s:%s' pull secret is not part of the support archive", dk.Namespace, dk.PullSecretName()))
+	}
+
+	report.skipped(&dk, imagePullCheckName, archiveSkipReason)
+	report.skipped(&dk, connectivityCheckName, archiveSkipReason)
+
+	// the proxy check depends on the environment the troubleshoot command is running in
+	report.skipped(&dk, proxyCheckName, archiveSkipReason)
+}
+
+func isSecretInArchive(ctx context.Context, apiReader client.Reader, namespace, name string) bool {
+	var secret corev1.Secret
+
+	return apiReader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &secret) == nil
+}
@@ -0,0 +1,139 @@
+package troubleshoot
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/klauspost/compress/zip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRunTroubleshootCmdFromArchive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("static checks are run against the archived manifests", func(t *testing.T) {
+		archivePath := createTestArchive(t, map[string]client.Object{
+			"manifests/dynatrace/namespace-dynatrace.yaml":    testBuildArchivedNamespace(),
+			"manifests/dynatrace/dynakube/dynakube.yaml":      testBuildArchivedDynakube(),
+			"manifests/dynatrace/secret/dynakube.yaml":        testBuildArchivedSecret(testDynakube, "apiToken"),
+			"manifests/crds/customresourcedefinition-dk.yaml": nil,
+			"logs/dynatrace-operator/operator.log":            nil,
+		})
+
+		report := RunTroubleshootCmdFromArchive(ctx, getNullLogger(t), testNamespace, archivePath)
+
+		assertCheckStatus(t, report, "", oneAgentAPMCheckName, CheckStatusSkipped)
+		assertCheckStatus(t, report, "", namespaceCheckName, CheckStatusPassed)
+		assertCheckStatus(t, report, "", crdCheckName, CheckStatusPassed)
+
+		dk := testNamespace + ":" + testDynakube
+		assertCheckStatus(t, report, dk, apiTokenCheckName, CheckStatusPassed)
+		assertCheckStatus(t, report, dk, tokenScopesCheckName, CheckStatusSkipped)
+		assertCheckStatus(t, report, dk, pullSecretCheckName, CheckStatusSkipped)
+		assertCheckStatus(t, report, dk, imagePullCheckName, CheckStatusSkipped)
+		assert.False(t, report.Failed())
+	})
+	t.Run("invalid secret in archive fails", func(t *testing.T) {
+		archivePath := createTestArchive(t, map[string]client.Object{
+			"manifests/dynatrace/namespace-dynatrace.yaml": testBuildArchivedNamespace(),
+			"manifests/dynatrace/dynakube/dynakube.yaml":   testBuildArchivedDynakube(),
+			"manifests/dynatrace/secret/dynakube.yaml":     testBuildArchivedSecret(testDynakube, "paasToken"),
+		})
+
+		report := RunTroubleshootCmdFromArchive(ctx, getNullLogger(t), testNamespace, archivePath)
+
+		assertCheckStatus(t, report, testNamespace+":"+testDynakube, apiTokenCheckName, CheckStatusFailed)
+		assert.True(t, report.Failed())
+	})
+	t.Run("missing namespace in archive fails", func(t *testing.T) {
+		archivePath := createTestArchive(t, map[string]client.Object{
+			"manifests/dynatrace/dynakube/dynakube.yaml": testBuildArchivedDynakube(),
+		})
+
+		report := RunTroubleshootCmdFromArchive(ctx, getNullLogger(t), testNamespace, archivePath)
+
+		assertCheckStatus(t, report, "", namespaceCheckName, CheckStatusFailed)
+		assert.True(t, report.Failed())
+	})
+	t.Run("archive not found", func(t *testing.T) {
+		report := RunTroubleshootCmdFromArchive(ctx, getNullLogger(t), testNamespace, filepath.Join(t.TempDir(), "missing.zip"))
+
+		assertCheckStatus(t, report, "", archiveCheckName, CheckStatusFailed)
+	})
+}
+
+func createTestArchive(t *testing.T, files map[string]client.Object) string {
+	archivePath := filepath.Join(t.TempDir(), "support-archive.zip")
+
+	archiveFile, err := os.Create(archivePath)
+	require.NoError(t, err)
+
+	defer archiveFile.Close()
+
+	writer := zip.NewWriter(archiveFile)
+
+	for fileName, object := range files {
+		content := []byte("not a manifest")
+
+		if object != nil {
+			content, err = yaml.Marshal(object)
+			require.NoError(t, err)
+		}
+
+		fileWriter, err := writer.Create(fileName)
+		require.NoError(t, err)
+
+		_, err = fileWriter.Write(content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+
+	return archivePath
+}
+
+func testBuildArchivedNamespace() *corev1.Namespace {
+	namespace := testBuildNamespace(testNamespace)
+	namespace.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"}
+
+	return namespace
+}
+
+func testBuildArchivedDynakube() *dynakube.DynaKube {
+	dk := testNewDynakubeBuilder(testNamespace, testDynakube).withApiUrl(testApiUrl).build()
+	dk.TypeMeta = metav1.TypeMeta{APIVersion: v1beta3.GroupVersion.String(), Kind: "DynaKube"}
+	dk.ResourceVersion = "12345"
+
+	return dk
+}
+
+func testBuildArchivedSecret(name string, tokenName string) *corev1.Secret {
+	secret := testNewSecretBuilder(testNamespace, name).dataAppend(tokenName, testApiToken).build()
+	secret.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}
+	delete(secret.Data, name)
+
+	return secret
+}
+
+func assertCheckStatus(t *testing.T, report *Report, dk string, check string, status CheckStatus) {
+	t.Helper()
+
+	for _, result := range report.Results {
+		if result.DynaKube == dk && result.Check == check {
+			assert.Equal(t, status, result.Status, result.Message)
+
+			return
+		}
+	}
+
+	assert.Failf(t, "check not found", "check '%s' for '%s' not in report", check, dk)
+}
@@ -2,8 +2,10 @@ package troubleshoot
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/cmd/config"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
@@ -26,11 +28,20 @@ const (
 	dynakubeFlagShorthand  = "d"
 	namespaceFlagName      = "namespace"
 	namespaceFlagShorthand = "n"
+	outputFlagName         = "output"
+	outputFlagShorthand    = "o"
+	fromArchiveFlagName    = "from-archive"
+	fromNodesFlagName      = "from-nodes"
+	nodeSelectorFlagName   = "node-selector"
 )
 
 var (
-	dynakubeFlagValue  string
-	namespaceFlagValue string
+	dynakubeFlagValue     string
+	namespaceFlagValue    string
+	outputFlagValue       string
+	fromArchiveFlagValue  string
+	fromNodesFlagValue    bool
+	nodeSelectorFlagValue string
 )
 
 type CommandBuilder struct {
@@ -49,11 +60,13 @@ func (builder CommandBuilder) SetConfigProvider(provider config.Provider) Comman
 
 func (builder CommandBuilder) Build() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  use,
-		RunE: builder.buildRun(),
+		Use:          use,
+		RunE:         builder.buildRun(),
+		SilenceUsage: true,
 	}
 
 	addFlags(cmd)
+	cmd.AddCommand(newNodeProbeCommand())
 
 	return cmd
 }
@@ -61,6 +74,10 @@ func (builder CommandBuilder) Build() *cobra.Command {
 func addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&dynakubeFlagValue, dynakubeFlagName, dynakubeFlagShorthand, "", "Specify a different Dynakube name.")
 	cmd.PersistentFlags().StringVarP(&namespaceFlagValue, namespaceFlagName, namespaceFlagShorthand, env.DefaultNamespace(), "Specify a different Namespace.")
+	cmd.PersistentFlags().StringVarP(&outputFlagValue, outputFlagName, outputFlagShorthand, outputFormatText, "Output format of the check results, one of: "+strings.Join(outputFormats, ", ")+".")
+	cmd.PersistentFlags().StringVar(&fromArchiveFlagValue, fromArchiveFlagName, "", "Run the checks against the manifests of a support archive instead of a cluster. Checks that need a connection to the cluster, the Dynatrace API or a registry are skipped.")
+	cmd.PersistentFlags().BoolVar(&fromNodesFlagValue, fromNodesFlagName, false, "Additionally check the connectivity from the nodes, by running short-lived probe pods using the operator image on every node.")
+	cmd.PersistentFlags().StringVar(&nodeSelectorFlagValue, nodeSelectorFlagName, "", "Label selector to limit the nodes checked by '--"+fromNodesFlagName+"'.")
 }
 
 func clusterOptions(opts *cluster.Options) {
@@ -69,51 +86,118 @@ func clusterOptions(opts *cluster.Options) {
 
 func (builder CommandBuilder) buildRun() func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		version.LogVersion()
-		logd.LogBaseLoggerSettings()
-
-		kubeConfig, err := builder.configProvider.GetConfig()
+		err := validateOutputFormat(outputFlagValue)
 		if err != nil {
 			return err
 		}
 
-		log := NewTroubleshootLoggerToWriter(os.Stdout)
+		// the report is printed to stdout in case of a machine-readable format, so the human-readable output must not end up there
+		logOutput := os.Stderr
+		if outputFlagValue != outputFormatText {
+			logd.SetOutput(os.Stderr)
+		} else {
+			logOutput = os.Stdout
+
+			version.LogVersion()
+			logd.LogBaseLoggerSettings()
+		}
+
+		log := NewTroubleshootLoggerToWriter(logOutput)
+
+		var report *Report
+
+		if fromArchiveFlagValue != "" {
+			report = RunTroubleshootCmdFromArchive(cmd.Context(), log, namespaceFlagValue, fromArchiveFlagValue)
+		} else {
+			kubeConfig, err := builder.configProvider.GetConfig()
+			if err != nil {
+				return err
+			}
+
+			report = RunTroubleshootCmd(cmd.Context(), log, namespaceFlagValue, kubeConfig)
+		}
+
+		err = writeReport(os.Stdout, report, outputFlagValue)
+		if err != nil {
+			return err
+		}
 
-		RunTroubleshootCmd(cmd.Context(), log, namespaceFlagValue, kubeConfig)
+		if report.Failed() {
+			return errors.New("troubleshoot checks failed")
+		}
 
 		return nil
 	}
 }
 
-func RunTroubleshootCmd(ctx context.Context, log logd.Logger, namespaceName string, kubeConfig *rest.Config) {
+func RunTroubleshootCmd(ctx context.Context, log logd.Logger, namespaceName string, kubeConfig *rest.Config) *Report {
+	report := NewReport()
+
 	err := checkOneAgentAPM(log, kubeConfig)
 	if err != nil {
 		logErrorf(log, "prerequisite checks failed, aborting (%v)", err)
+		report.failed(nil, oneAgentAPMCheckName, err, "Delete the OneAgentAPM objects or uninstall the OneAgent Operator before installing the Dynatrace Operator.")
 
-		return
+		return report
 	}
 
+	report.passed(nil, oneAgentAPMCheckName, "OneAgentAPM does not exist")
+
 	apiReader, err := GetK8SClusterAPIReader(kubeConfig)
 	if err != nil {
-		return
+		logErrorf(log, "failed to connect to the cluster (%v)", err)
+		report.failed(nil, clusterCheckName, err, "Verify that the kubeconfig is valid and the cluster is reachable.")
+
+		return report
 	}
 
-	err = checkNamespace(ctx, log, apiReader, namespaceName)
+	dks := checkPrerequisites(ctx, log, report, apiReader, namespaceName)
+
+	runChecksForAllDynakubes(ctx, log, report, apiReader, &http.Client{}, dks)
+
+	if fromNodesFlagValue && len(dks) > 0 {
+		runner, err := newNodeProbeRunner(kubeConfig, apiReader, namespaceName, nodeSelectorFlagValue)
+		if err != nil {
+			logErrorf(log, "failed to connect to the cluster (%v)", err)
+			report.failed(nil, nodeProbeCheckName, err, "Verify that the kubeconfig is valid and the cluster is reachable.")
+
+			return report
+		}
+
+		runner.run(ctx, log, report, dks)
+	}
+
+	return report
+}
+
+// checkPrerequisites checks the namespace and the CRD and returns the Dynakubes to check, which is empty if any of the checks failed.
+func checkPrerequisites(ctx context.Context, log logd.Logger, report *Report, apiReader client.Reader, namespaceName string) []dynakube.DynaKube {
+	err := checkNamespace(ctx, log, apiReader, namespaceName)
 	if err != nil {
 		logErrorf(log, "prerequisite checks failed, aborting (%v)", err)
+		report.failed(nil, namespaceCheckName, err, fmt.Sprintf("Specify the namespace of the Dynatrace Operator by providing the '--%s <namespace>' parameter.", namespaceFlagName))
 
-		return
+		return nil
 	}
 
+	report.passed(nil, namespaceCheckName, fmt.Sprintf("using namespace '%s'", namespaceName))
+
 	dks, err := getDynakubes(ctx, log, apiReader, namespaceName, dynakubeFlagValue)
 
-	if checkCRD(log, err) != nil {
+	if err := checkCRD(log, err); err != nil {
 		logErrorf(log, "error during getting dynakubes: %v", err)
+		report.failed(nil, crdCheckName, err, "Install the CRDs matching the version of the Dynatrace Operator. "+dynakubeNotValidMessage())
+
+		return nil
+	}
 
-		return
+	report.passed(nil, crdCheckName, "CRD for Dynakube exists")
+
+	if len(dks) == 0 {
+		report.failed(nil, dynakubeCheckName, errors.Errorf("no Dynakubes found in namespace '%s'", namespaceName), dynakubeNotValidMessage())
 	}
 
-	runChecksForAllDynakubes(ctx, log, apiReader, &http.Client{}, dks)
+	return dks
 }
 
 func GetK8SClusterAPIReader(kubeConfig *rest.Config) (client.Reader, error) {
@@ -125,22 +209,22 @@ func GetK8SClusterAPIReader(kubeConfig *rest.Config) (client.Reader, error) {
 	return k8scluster.GetAPIReader(), nil
 }
 
-func runChecksForAllDynakubes(ctx context.Context, baseLog logd.Logger, apiReader client.Reader, httpClient *http.Client, dynakubes []dynakube.DynaKube) {
+func runChecksForAllDynakubes(ctx context.Context, baseLog logd.Logger, report *Report, apiReader client.Reader, httpClient *http.Client, dynakubes []dynakube.DynaKube) {
 	for _, dk := range dynakubes {
-		err := runChecksForDynakube(ctx, baseLog, apiReader, httpClient, dk)
+		err := runChecksForDynakube(ctx, baseLog, report, apiReader, httpClient, dk)
 		if err != nil {
 			logErrorf(baseLog, "Error in DynaKube %s/%s", dk.Namespace, dk.Name)
 		}
 	}
 }
 
-func runChecksForDynakube(ctx context.Context, baseLog logd.Logger, apiReader client.Reader, httpClient *http.Client, dk dynakube.DynaKube) error {
+func runChecksForDynakube(ctx context.Context, baseLog logd.Logger, report *Report, apiReader client.Reader, httpClient *http.Client, dk dynakube.DynaKube) error {
 	log := baseLog.WithName(dynakubeCheckLoggerName)
 
 	logNewCheckf(log, "checking if '%s:%s' Dynakube is configured correctly", dk.Namespace, dk.Name)
 	logInfof(log, "using '%s:%s' Dynakube", dk.Namespace, dk.Name)
 
-	pullSecret, err := checkDynakube(ctx, baseLog, apiReader, &dk)
+	pullSecret, err := checkDynakube(ctx, baseLog, report, apiReader, &dk)
 	if err != nil {
 		return errors.Wrapf(err, "'%s:%s' Dynakube isn't valid. %s",
 			dk.Namespace, dk.Name, dynakubeNotValidMessage())
@@ -150,20 +234,26 @@ func runChecksForDynakube(ctx context.Context, baseLog logd.Logger, apiReader cl
 
 	keychain, err := dockerkeychain.NewDockerKeychain(ctx, apiReader, pullSecret)
 	if err != nil {
+		report.failed(&dk, pullSecretCheckName, err, pullSecretRemediation)
+
 		return err
 	}
 
 	transport, err := createTransport(ctx, apiReader, &dk, httpClient)
 	if err != nil {
+		report.failed(&dk, imagePullCheckName, err, "Verify the proxy and trustedCAs settings of the Dynakube.")
+
 		return err
 	}
 
-	err = verifyAllImagesAvailable(ctx, log, keychain, transport, &dk)
+	err = verifyAllImagesAvailable(ctx, log, report, keychain, transport, &dk)
 	if err != nil {
 		return err
 	}
 
-	return checkProxySettings(ctx, log, apiReader, &dk)
+	checkConnectivity(ctx, baseLog, report, transport, &dk)
+
+	return checkProxySettings(ctx, log, report, apiReader, &dk)
 }
 
 func createTransport(ctx context.Context, apiReader client.Reader, dk *dynakube.DynaKube, httpClient *http.Client) (*http.Transport, error) {
@@ -2,13 +2,19 @@ package troubleshoot
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestTroubleshootCommandBuilder(t *testing.T) {
@@ -39,6 +45,65 @@ func TestTroubleshootCommandBuilder(t *testing.T) {
 		assert.Len(t, dynakubes, 1)
 		assert.Equal(t, testDynakube, dynakubes[0].Name)
 	})
+
+	t.Run("machine-readable output keeps stdout free of logs", func(t *testing.T) {
+		archivePath := createTestArchive(t, map[string]client.Object{
+			"manifests/dynatrace/namespace-dynatrace.yaml": testBuildArchivedNamespace(),
+			"manifests/dynatrace/dynakube/dynakube.yaml":   testBuildArchivedDynakube(),
+			"manifests/dynatrace/secret/dynakube.yaml":     testBuildArchivedSecret(testDynakube, "apiToken"),
+		})
+		stdout := redirectStdout(t)
+
+		setFlagValue(t, &outputFlagValue, outputFormatJson)
+		setFlagValue(t, &fromArchiveFlagValue, archivePath)
+		setFlagValue(t, &namespaceFlagValue, testNamespace)
+
+		cmd := &cobra.Command{}
+		cmd.SetContext(context.Background())
+
+		err := NewTroubleshootCommandBuilder().buildRun()(cmd, nil)
+		require.NoError(t, err)
+
+		logd.Get().Info("log line after the report")
+
+		output, err := os.ReadFile(stdout.Name())
+		require.NoError(t, err)
+
+		var report Report
+		require.NoError(t, json.Unmarshal(output, &report))
+		assert.NotContains(t, string(output), "log line after the report")
+	})
+}
+
+// redirectStdout replaces os.Stdout, and the output of logd, which defaults to it, by a file for the duration of the test.
+func redirectStdout(t *testing.T) *os.File {
+	t.Helper()
+
+	stdout, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	require.NoError(t, err)
+
+	originalStdout := os.Stdout
+	os.Stdout = stdout
+	logd.SetOutput(stdout)
+
+	t.Cleanup(func() {
+		os.Stdout = originalStdout
+		logd.SetOutput(originalStdout)
+		stdout.Close()
+	})
+
+	return stdout
+}
+
+func setFlagValue(t *testing.T, flagValue *string, value string) {
+	t.Helper()
+
+	originalValue := *flagValue
+	*flagValue = value
+
+	t.Cleanup(func() {
+		*flagValue = originalValue
+	})
 }
 
 func buildTestDynakube() dynakube.DynaKube {
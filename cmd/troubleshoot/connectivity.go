@@ -0,0 +1,285 @@
+package troubleshoot
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	agconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/activegate"
+	oaconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/pkg/errors"
+)
+
+const (
+	connectivityTimeout = 10 * time.Second
+
+	connectivityTcpRemediation           = "Verify that the endpoint is reachable from the cluster and not blocked by a firewall, and that the proxy settings of the Dynakube are correct."
+	connectivityUnknownCARemediation     = "Add the CA certificate of the endpoint to the trustedCAs of the Dynakube."
+	connectivityHostnameRemediation      = "The certificate of the endpoint is not valid for its host name, verify the certificate of the endpoint."
+	connectivityExpiredRemediation       = "Renew the certificate of the endpoint."
+	connectivityTlsRemediation           = "Verify the TLS configuration of the endpoint and the trustedCAs of the Dynakube."
+	connectivitySkipCertCheckRemediation = "Certificate errors are ignored, because skipCertCheck is enabled in the Dynakube."
+)
+
+type endpointLatency struct {
+	tcp time.Duration
+	tls time.Duration
+}
+
+// checkConnectivity dials every OneAgent communication host and ActiveGate endpoint stored in the status of the Dynakube,
+// using the same proxy and trustedCAs settings as for pulling images.
+func checkConnectivity(ctx context.Context, baseLog logd.Logger, report *Report, transport *http.Transport, dk *dynakube.DynaKube) {
+	log := baseLog.WithName("connectivity")
+
+	logNewCheckf(log, "checking connectivity to the communication endpoints ...")
+
+	hosts := getConnectivityHosts(dk)
+	if len(hosts) == 0 {
+		logWarningf(log, "no communication endpoints found in the status of the Dynakube")
+		report.warning(dk, connectivityCheckName, "no communication endpoints found in the status of the Dynakube",
+			"The endpoints are retrieved from the Dynatrace API by the operator, verify that the Dynakube has been reconciled.")
+
+		return
+	}
+
+	for _, host := range hosts {
+		checkEndpointConnectivity(ctx, log, report, transport, dk, host)
+	}
+}
+
+func checkEndpointConnectivity(ctx context.Context, log logd.Logger, report *Report, transport *http.Transport, dk *dynakube.DynaKube, host dtclient.CommunicationHost) {
+	endpoint := fmt.Sprintf("%s://%s", host.Protocol, endpointAddress(host))
+	checkName := connectivityCheckName + "/" + endpoint
+
+	latency, err := dialEndpoint(ctx, transport, host)
+	if err == nil {
+		message := fmt.Sprintf("%s is reachable (%s)", endpoint, latency)
+		logOkf(log, "%s", message)
+		report.passed(dk, checkName, message)
+
+		return
+	}
+
+	message := fmt.Sprintf("%s is not reachable: %v", endpoint, err)
+	remediation := connectivityRemediation(err)
+
+	var verificationErr *tls.CertificateVerificationError
+	if errors.As(err, &verificationErr) && dk.Spec.SkipCertCheck {
+		logWarningf(log, "%s", message)
+		report.warning(dk, checkName, message, connectivitySkipCertCheckRemediation)
+
+		return
+	}
+
+	logErrorf(log, "%s", message)
+	report.failed(dk, checkName, errors.New(message), remediation)
+}
+
+// getConnectivityHosts returns the OneAgent communication hosts and ActiveGate endpoints without duplicates.
+func getConnectivityHosts(dk *dynakube.DynaKube) []dtclient.CommunicationHost {
+	var hosts []dtclient.CommunicationHost
+
+	known := map[dtclient.CommunicationHost]bool{}
+
+	for _, host := range append(oaconnectioninfo.GetCommunicationHosts(dk), agconnectioninfo.GetEndpointsAsCommunicationHosts(dk)...) {
+		if !known[host] {
+			known[host] = true
+
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts
+}
+
+func endpointAddress(host dtclient.CommunicationHost) string {
+	return net.JoinHostPort(host.Host, strconv.FormatUint(uint64(host.Port), 10))
+}
+
+func (latency endpointLatency) String() string {
+	if latency.tls == 0 {
+		return fmt.Sprintf("tcp: %s", latency.tcp.Round(time.Millisecond))
+	}
+
+	return fmt.Sprintf("tcp: %s, tls: %s", latency.tcp.Round(time.Millisecond), latency.tls.Round(time.Millisecond))
+}
+
+func dialEndpoint(ctx context.Context, transport *http.Transport, host dtclient.CommunicationHost) (endpointLatency, error) {
+	var latency endpointLatency
+
+	ctx, cancel := context.WithTimeout(ctx, connectivityTimeout)
+	defer cancel()
+
+	address := endpointAddress(host)
+
+	start := time.Now()
+
+	conn, err := dialTcp(ctx, transport, host.Protocol, address)
+	if err != nil {
+		return latency, errors.WithMessage(err, "tcp connection failed")
+	}
+	defer conn.Close()
+
+	latency.tcp = time.Since(start)
+
+	if host.Protocol != "https" {
+		return latency, nil
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName: host.Host,
+		RootCAs:    rootCAs(transport),
+		MinVersion: tls.VersionTLS12,
+	})
+
+	start = time.Now()
+
+	err = tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		return latency, errors.WithMessage(err, "tls handshake failed")
+	}
+
+	latency.tls = time.Since(start)
+
+	return latency, nil
+}
+
+// dialTcp connects to the address, tunneling the connection via HTTP CONNECT if the transport has a proxy configured for it.
+func dialTcp(ctx context.Context, transport *http.Transport, protocol, address string) (net.Conn, error) {
+	var dialer net.Dialer
+
+	proxyUrl, err := getProxyForEndpoint(transport, protocol, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyUrl == nil {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddress(proxyUrl))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to connect to proxy %s", proxyUrl.Redacted())
+	}
+
+	if proxyUrl.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{
+			ServerName: proxyUrl.Hostname(),
+			RootCAs:    rootCAs(transport),
+			MinVersion: tls.VersionTLS12,
+		})
+	}
+
+	err = connectViaProxy(ctx, conn, proxyUrl, address)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func connectViaProxy(ctx context.Context, conn net.Conn, proxyUrl *url.URL, address string) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{}) //nolint:errcheck
+	}
+
+	connectRequest := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+
+	if proxyUrl.User != nil {
+		password, _ := proxyUrl.User.Password()
+		credentials := proxyUrl.User.Username() + ":" + password
+		connectRequest.Header.Set("Proxy-Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+
+	err := connectRequest.Write(conn)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to send CONNECT to proxy %s", proxyUrl.Redacted())
+	}
+
+	response, err := http.ReadResponse(bufio.NewReader(conn), connectRequest)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to read CONNECT response of proxy %s", proxyUrl.Redacted())
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("proxy %s refused CONNECT: %s", proxyUrl.Redacted(), response.Status)
+	}
+
+	return nil
+}
+
+func getProxyForEndpoint(transport *http.Transport, protocol, address string) (*url.URL, error) {
+	if transport == nil || transport.Proxy == nil {
+		return nil, nil
+	}
+
+	proxyUrl, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: protocol, Host: address}})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to determine proxy")
+	}
+
+	return proxyUrl, nil
+}
+
+func proxyAddress(proxyUrl *url.URL) string {
+	if proxyUrl.Port() != "" {
+		return proxyUrl.Host
+	}
+
+	if proxyUrl.Scheme == "https" {
+		return net.JoinHostPort(proxyUrl.Hostname(), "443")
+	}
+
+	return net.JoinHostPort(proxyUrl.Hostname(), "80")
+}
+
+func rootCAs(transport *http.Transport) *x509.CertPool {
+	if transport == nil || transport.TLSClientConfig == nil {
+		return nil
+	}
+
+	return transport.TLSClientConfig.RootCAs
+}
+
+// connectivityRemediation translates the most common TLS errors into a hint how to fix them.
+func connectivityRemediation(err error) string {
+	var (
+		unknownAuthorityErr x509.UnknownAuthorityError
+		hostnameErr         x509.HostnameError
+		invalidErr          x509.CertificateInvalidError
+		verificationErr     *tls.CertificateVerificationError
+	)
+
+	switch {
+	case errors.As(err, &unknownAuthorityErr):
+		return connectivityUnknownCARemediation
+	case errors.As(err, &hostnameErr):
+		return connectivityHostnameRemediation
+	case errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired:
+		return connectivityExpiredRemediation
+	case errors.As(err, &verificationErr):
+		return connectivityTlsRemediation
+	}
+
+	return connectivityTcpRemediation
+}
@@ -0,0 +1,203 @@
+package troubleshoot
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	stdlog "log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/oneagent"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckConnectivity(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	// the failing handshakes are expected, so they shouldn't pollute the test output
+	server.Config.ErrorLog = stdlog.New(io.Discard, "", 0)
+	server.StartTLS()
+
+	defer server.Close()
+
+	serverHost := getTestCommunicationHost(t, server.URL)
+
+	t.Run("endpoint is reachable", func(t *testing.T) {
+		dk := createConnectivityTestDynakube(serverHost)
+		report := NewReport()
+
+		checkConnectivity(ctx, getNullLogger(t), report, createTrustingTransport(server), dk)
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, CheckStatusPassed, report.Results[0].Status, report.Results[0].Message)
+		assert.Contains(t, report.Results[0].Message, "tls:")
+	})
+	t.Run("unknown CA", func(t *testing.T) {
+		dk := createConnectivityTestDynakube(serverHost)
+		report := NewReport()
+
+		checkConnectivity(ctx, getNullLogger(t), report, &http.Transport{}, dk)
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, CheckStatusFailed, report.Results[0].Status)
+		assert.Equal(t, connectivityUnknownCARemediation, report.Results[0].Remediation)
+	})
+	t.Run("unknown CA is only a warning with skipCertCheck", func(t *testing.T) {
+		dk := createConnectivityTestDynakube(serverHost)
+		dk.Spec.SkipCertCheck = true
+		report := NewReport()
+
+		checkConnectivity(ctx, getNullLogger(t), report, &http.Transport{}, dk)
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, CheckStatusWarning, report.Results[0].Status)
+		assert.False(t, report.Failed())
+	})
+	t.Run("hostname mismatch", func(t *testing.T) {
+		host := serverHost
+		host.Host = "localhost"
+		dk := createConnectivityTestDynakube(host)
+		report := NewReport()
+
+		checkConnectivity(ctx, getNullLogger(t), report, createTrustingTransport(server), dk)
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, CheckStatusFailed, report.Results[0].Status)
+		assert.Equal(t, connectivityHostnameRemediation, report.Results[0].Remediation)
+	})
+	t.Run("endpoint is not reachable", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		port := listener.Addr().(*net.TCPAddr).Port
+		require.NoError(t, listener.Close())
+
+		dk := createConnectivityTestDynakube(dtclient.CommunicationHost{Protocol: "https", Host: "127.0.0.1", Port: uint32(port)}) //nolint:gosec
+		report := NewReport()
+
+		checkConnectivity(ctx, getNullLogger(t), report, &http.Transport{}, dk)
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, CheckStatusFailed, report.Results[0].Status)
+		assert.Equal(t, connectivityTcpRemediation, report.Results[0].Remediation)
+	})
+	t.Run("endpoint is reached via proxy", func(t *testing.T) {
+		var connectRequests atomic.Int32
+
+		proxy := httptest.NewServer(createConnectProxyHandler(&connectRequests))
+		defer proxy.Close()
+
+		proxyUrl, err := url.Parse(proxy.URL)
+		require.NoError(t, err)
+
+		transport := createTrustingTransport(server)
+		transport.Proxy = http.ProxyURL(proxyUrl)
+
+		dk := createConnectivityTestDynakube(serverHost)
+		report := NewReport()
+
+		checkConnectivity(ctx, getNullLogger(t), report, transport, dk)
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, CheckStatusPassed, report.Results[0].Status, report.Results[0].Message)
+		assert.Equal(t, int32(1), connectRequests.Load())
+	})
+	t.Run("no endpoints in status", func(t *testing.T) {
+		report := NewReport()
+
+		checkConnectivity(ctx, getNullLogger(t), report, &http.Transport{}, &dynakube.DynaKube{})
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, CheckStatusWarning, report.Results[0].Status)
+	})
+}
+
+func TestGetConnectivityHosts(t *testing.T) {
+	dk := &dynakube.DynaKube{}
+	dk.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts = []oneagent.CommunicationHostStatus{
+		{Protocol: "https", Host: "tenant.dynatrace.com", Port: 443},
+		{Protocol: "https", Host: "10.0.0.1", Port: 9999},
+	}
+	dk.Status.ActiveGate.ConnectionInfo.Endpoints = "https://10.0.0.1:9999/communication,https://10.0.0.2:9999/communication"
+
+	hosts := getConnectivityHosts(dk)
+
+	assert.Len(t, hosts, 3)
+}
+
+func TestConnectivityRemediation(t *testing.T) {
+	assert.Equal(t, connectivityExpiredRemediation, connectivityRemediation(x509.CertificateInvalidError{Reason: x509.Expired}))
+	assert.Equal(t, connectivityUnknownCARemediation, connectivityRemediation(x509.UnknownAuthorityError{}))
+	assert.Equal(t, connectivityHostnameRemediation, connectivityRemediation(x509.HostnameError{}))
+	assert.Equal(t, connectivityTcpRemediation, connectivityRemediation(io.EOF))
+}
+
+func createConnectivityTestDynakube(host dtclient.CommunicationHost) *dynakube.DynaKube {
+	dk := &dynakube.DynaKube{}
+	dk.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts = []oneagent.CommunicationHostStatus{
+		{Protocol: host.Protocol, Host: host.Host, Port: host.Port},
+	}
+
+	return dk
+}
+
+func getTestCommunicationHost(t *testing.T, serverUrl string) dtclient.CommunicationHost {
+	parsedUrl, err := url.Parse(serverUrl)
+	require.NoError(t, err)
+
+	port, err := strconv.ParseUint(parsedUrl.Port(), 10, 32)
+	require.NoError(t, err)
+
+	return dtclient.CommunicationHost{Protocol: "https", Host: parsedUrl.Hostname(), Port: uint32(port)}
+}
+
+func createTrustingTransport(server *httptest.Server) *http.Transport {
+	return server.Client().Transport.(*http.Transport).Clone()
+}
+
+func createConnectProxyHandler(connectRequests *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		connectRequests.Add(1)
+
+		target, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			target.Close()
+
+			return
+		}
+
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+
+		go func() {
+			defer conn.Close()
+			defer target.Close()
+
+			_, _ = io.Copy(target, conn)
+		}()
+
+		_, _ = io.Copy(conn, target)
+	})
+}
@@ -24,35 +24,59 @@ const (
 
 const dynakubeCheckLoggerName = "dynakube"
 
-func checkDynakube(ctx context.Context, baseLog logd.Logger, apiReader client.Reader, dk *dynakube.DynaKube) (corev1.Secret, error) {
+const pullSecretRemediation = "The pull secret is created by the operator, check the operator logs. A custom pull secret must contain a valid '" + dtpullsecret.DockerConfigJson + "'."
+
+func checkDynakube(ctx context.Context, baseLog logd.Logger, report *Report, apiReader client.Reader, dk *dynakube.DynaKube) (corev1.Secret, error) {
 	dynatraceApiSecretTokens, err := checkIfDynatraceApiSecretHasApiToken(ctx, baseLog, apiReader, dk)
 	if err != nil {
+		report.failed(dk, apiTokenCheckName, err, apiTokenRemediation(dk))
+
 		return corev1.Secret{}, err
 	}
 
+	report.passed(dk, apiTokenCheckName, "secret token 'apiToken' exists")
+
 	err = checkDynatraceApiTokenScopes(ctx, baseLog, apiReader, dynatraceApiSecretTokens, dk)
 	if err != nil {
+		report.failed(dk, tokenScopesCheckName, err, "Add the missing scopes to the tokens, the required scopes depend on the features enabled in the Dynakube.")
+
 		return corev1.Secret{}, err
 	}
 
+	report.passed(dk, tokenScopesCheckName, "token scopes are valid")
+
 	err = checkApiUrlForLatestAgentVersion(ctx, baseLog, apiReader, dk, dynatraceApiSecretTokens)
 	if err != nil {
+		report.failed(dk, apiUrlCheckName, err, "Verify that the apiUrl of the Dynakube is correct and reachable from the cluster.")
+
 		return corev1.Secret{}, err
 	}
 
+	report.passed(dk, apiUrlCheckName, "API token is valid, can pull latest agent version")
+
 	pullSecret, err := checkPullSecretExists(ctx, baseLog, apiReader, dk)
 	if err != nil {
+		report.failed(dk, pullSecretCheckName, err, pullSecretRemediation)
+
 		return corev1.Secret{}, err
 	}
 
 	err = checkPullSecretHasRequiredTokens(baseLog, dk, pullSecret)
 	if err != nil {
+		report.failed(dk, pullSecretCheckName, err, pullSecretRemediation)
+
 		return corev1.Secret{}, err
 	}
 
+	report.passed(dk, pullSecretCheckName, fmt.Sprintf("pull secret '%s:%s' is valid", dk.Namespace, dk.PullSecretName()))
+
 	return pullSecret, nil
 }
 
+func apiTokenRemediation(dk *dynakube.DynaKube) string {
+	return fmt.Sprintf("Create the '%s:%s' secret containing the '%s' token.", dk.Namespace, dk.Tokens(), dtclient.ApiToken)
+}
+
 func getSelectedDynakube(ctx context.Context, apiReader client.Reader, namespaceName, dynakubeName string) (dynakube.DynaKube, error) {
 	var dk dynakube.DynaKube
 	err := apiReader.Get(
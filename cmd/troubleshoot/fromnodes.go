@@ -0,0 +1,361 @@
+package troubleshoot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/cmd/remote_command"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	nodeProbeComponentLabel = "troubleshoot-node-probe"
+	nodeProbeContainerName  = "probe"
+	nodeProbePodNamePrefix  = "dynatrace-troubleshoot-probe-"
+
+	// nodeProbeIdleTime limits the lifetime of the probe pods, in case the troubleshoot command is interrupted before cleaning them up
+	nodeProbeIdleTime         = 5 * time.Minute
+	nodeProbeStartTimeout     = 2 * time.Minute
+	nodeProbeStartPollingTime = 2 * time.Second
+
+	nodeProbeRemediation = "The endpoint can't be reached from the node, verify the DNS configuration, egress NAT, firewall and node-level proxy settings of the node."
+)
+
+type nodeProbeRunner struct {
+	client       client.Client
+	apiReader    client.Reader
+	executor     remote_command.Executor
+	kubeConfig   *rest.Config
+	namespace    string
+	nodeSelector string
+
+	startTimeout time.Duration
+	pollInterval time.Duration
+}
+
+func newNodeProbeRunner(kubeConfig *rest.Config, apiReader client.Reader, namespace, nodeSelector string) (*nodeProbeRunner, error) {
+	kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &nodeProbeRunner{
+		client:       kubeClient,
+		apiReader:    apiReader,
+		executor:     remote_command.DefaultExecutor{},
+		kubeConfig:   kubeConfig,
+		namespace:    namespace,
+		nodeSelector: nodeSelector,
+		startTimeout: nodeProbeStartTimeout,
+		pollInterval: nodeProbeStartPollingTime,
+	}, nil
+}
+
+// run starts a probe pod on every selected node, probes the endpoints of the Dynakubes from within them and adds the results to the report.
+func (runner *nodeProbeRunner) run(ctx context.Context, baseLog logd.Logger, report *Report, dynakubes []dynakube.DynaKube) {
+	log := baseLog.WithName("nodeprobe")
+
+	logNewCheckf(log, "checking connectivity from the nodes ...")
+
+	nodes, err := runner.getNodes(ctx)
+	if err != nil {
+		logErrorf(log, "failed to list nodes: %v", err)
+		report.failed(nil, nodeProbeCheckName, err, fmt.Sprintf("Verify the '--%s' parameter.", nodeSelectorFlagName))
+
+		return
+	}
+
+	podTemplate, err := runner.buildPodTemplate(ctx)
+	if err != nil {
+		logErrorf(log, "failed to prepare probe pods: %v", err)
+		report.failed(nil, nodeProbeCheckName, err, "The probe pods use the image of the operator, verify that the operator is deployed in the namespace.")
+
+		return
+	}
+
+	podNames := map[string]string{}
+
+	defer runner.deletePods(log, podNames)
+
+	for _, node := range nodes {
+		pod := podTemplate.DeepCopy()
+		pod.Spec.NodeName = node.Name
+
+		err := runner.client.Create(ctx, pod)
+		if err != nil {
+			logErrorf(log, "failed to create probe pod on node '%s': %v", node.Name, err)
+			report.failed(nil, nodeProbeCheckName+"/"+node.Name, errors.WithStack(err), "Verify that the permissions to create pods in the namespace are granted.")
+
+			continue
+		}
+
+		podNames[node.Name] = pod.Name
+	}
+
+	for _, node := range nodes {
+		podName, ok := podNames[node.Name]
+		if !ok {
+			continue
+		}
+
+		err := runner.waitForPod(ctx, podName)
+		if err != nil {
+			logErrorf(log, "probe pod on node '%s' did not start: %v", node.Name, err)
+			report.failed(nil, nodeProbeCheckName+"/"+node.Name, err, "Verify that the operator image can be pulled on the node and that the node is schedulable.")
+
+			continue
+		}
+
+		for i := range dynakubes {
+			runner.probeFromNode(ctx, log, report, node.Name, podName, &dynakubes[i])
+		}
+	}
+}
+
+func (runner *nodeProbeRunner) probeFromNode(ctx context.Context, log logd.Logger, report *Report, nodeName, podName string, dk *dynakube.DynaKube) {
+	command := []string{version.AppName, use, nodeProbeUse}
+	for _, target := range getNodeProbeTargets(dk) {
+		command = append(command, "--"+nodeProbeTargetFlag, target)
+	}
+
+	var (
+		stdOut, stdErr *bytes.Buffer
+		err            error
+	)
+
+	if dk.HasProxy() {
+		proxyUrl, proxyErr := dk.Proxy(ctx, runner.apiReader)
+		if proxyErr != nil {
+			report.failed(dk, nodeProbeCheckName+"/"+nodeName, proxyErr, "Verify that the proxy value or the referenced secret of the Dynakube is valid.")
+
+			return
+		}
+
+		// the proxy may contain credentials, so it is passed via stdin instead of being visible in the arguments of the process
+		command = append(command, "--"+nodeProbeProxyFromStdinFlag)
+		stdOut, stdErr, err = runner.executor.ExecWithStdin(ctx, runner.kubeConfig, podName, runner.namespace, nodeProbeContainerName, command, strings.NewReader(proxyUrl))
+	} else {
+		stdOut, stdErr, err = runner.executor.Exec(ctx, runner.kubeConfig, podName, runner.namespace, nodeProbeContainerName, command)
+	}
+
+	if err != nil {
+		err = errors.WithMessagef(err, "failed to run probe on node '%s': %s", nodeName, stdErrString(stdErr))
+		logErrorf(log, "%v", err)
+		report.failed(dk, nodeProbeCheckName+"/"+nodeName, err, "Verify that the permissions to exec into pods in the namespace are granted.")
+
+		return
+	}
+
+	var results []nodeProbeResult
+
+	err = json.Unmarshal(stdOut.Bytes(), &results)
+	if err != nil {
+		report.failed(dk, nodeProbeCheckName+"/"+nodeName, errors.WithMessage(err, "failed to parse probe results"), "Verify that the operator image matches the version of the troubleshoot command.")
+
+		return
+	}
+
+	for _, result := range results {
+		checkName := nodeProbeCheckName + "/" + nodeName + "/" + result.Target
+
+		if result.Error != "" {
+			logErrorf(log, "node '%s' can't reach %s: %s", nodeName, result.Target, result.Error)
+			report.failed(dk, checkName, errors.New(result.Error), nodeProbeRemediation)
+
+			continue
+		}
+
+		message := fmt.Sprintf("%s is reachable from node '%s' (dns: %dms, tcp: %dms)", result.Target, nodeName, result.DnsLatencyMs, result.TcpLatencyMs)
+		logOkf(log, "%s", message)
+		report.passed(dk, checkName, message)
+	}
+}
+
+// getNodeProbeTargets returns the tenant, the communication endpoints and the registries of the images used by the Dynakube.
+func getNodeProbeTargets(dk *dynakube.DynaKube) []string {
+	var targets []string
+
+	addTarget := func(target string) {
+		for _, existing := range targets {
+			if existing == target {
+				return
+			}
+		}
+
+		targets = append(targets, target)
+	}
+
+	if apiHost, err := dtclient.ParseEndpoint(dk.ApiUrl()); err == nil {
+		addTarget(fmt.Sprintf("%s://%s", apiHost.Protocol, endpointAddress(apiHost)))
+	}
+
+	for _, host := range getConnectivityHosts(dk) {
+		addTarget(fmt.Sprintf("%s://%s", host.Protocol, endpointAddress(host)))
+	}
+
+	for _, comp := range []component{componentOneAgent, componentCodeModules, componentActiveGate} {
+		image, _ := comp.getImage(dk)
+		if image == "" {
+			continue
+		}
+
+		reference, err := name.ParseReference(image)
+		if err != nil {
+			continue
+		}
+
+		addTarget(reference.Context().Registry.Scheme() + "://" + reference.Context().RegistryStr())
+	}
+
+	return targets
+}
+
+func (runner *nodeProbeRunner) getNodes(ctx context.Context) ([]corev1.Node, error) {
+	selector, err := k8slabels.Parse(runner.nodeSelector)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var nodes corev1.NodeList
+
+	err = runner.apiReader.List(ctx, &nodes, client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if len(nodes.Items) == 0 {
+		return nil, errors.Errorf("no nodes match the selector '%s'", runner.nodeSelector)
+	}
+
+	return nodes.Items, nil
+}
+
+// buildPodTemplate creates a pod using the image and pull secrets of the operator.
+// It uses the host network, as OneAgents are running in the host network as well.
+func (runner *nodeProbeRunner) buildPodTemplate(ctx context.Context) (*corev1.Pod, error) {
+	var operatorDeployment appsv1.Deployment
+
+	err := runner.apiReader.Get(ctx, client.ObjectKey{Name: version.AppName, Namespace: runner.namespace}, &operatorDeployment)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get operator deployment")
+	}
+
+	if len(operatorDeployment.Spec.Template.Spec.Containers) == 0 {
+		return nil, errors.New("operator deployment has no containers")
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: nodeProbePodNamePrefix,
+			Namespace:    runner.namespace,
+			Labels: map[string]string{
+				labels.AppNameLabel:      version.AppName,
+				labels.AppComponentLabel: nodeProbeComponentLabel,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:    nodeProbeContainerName,
+					Image:   operatorDeployment.Spec.Template.Spec.Containers[0].Image,
+					Command: []string{version.AppName},
+					Args:    []string{use, nodeProbeUse, "--" + nodeProbeIdleFlagName, nodeProbeIdleTime.String()},
+					SecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(false),
+						ReadOnlyRootFilesystem:   ptr.To(true),
+						RunAsNonRoot:             ptr.To(true),
+						Capabilities: &corev1.Capabilities{
+							Drop: []corev1.Capability{"ALL"},
+						},
+						SeccompProfile: &corev1.SeccompProfile{
+							Type: corev1.SeccompProfileTypeRuntimeDefault,
+						},
+					},
+				},
+			},
+			ImagePullSecrets:              operatorDeployment.Spec.Template.Spec.ImagePullSecrets,
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			HostNetwork:                   true,
+			DNSPolicy:                     corev1.DNSClusterFirstWithHostNet,
+			AutomountServiceAccountToken:  ptr.To(false),
+			ActiveDeadlineSeconds:         ptr.To(int64((nodeProbeIdleTime + nodeProbeStartTimeout).Seconds())),
+			TerminationGracePeriodSeconds: ptr.To(int64(0)),
+			Tolerations: []corev1.Toleration{
+				{Operator: corev1.TolerationOpExists},
+			},
+		},
+	}, nil
+}
+
+func (runner *nodeProbeRunner) waitForPod(ctx context.Context, podName string) error {
+	ctx, cancel := context.WithTimeout(ctx, runner.startTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(runner.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		var pod corev1.Pod
+
+		err := runner.apiReader.Get(ctx, client.ObjectKey{Name: podName, Namespace: runner.namespace}, &pod)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			return nil
+		case corev1.PodFailed, corev1.PodSucceeded:
+			return errors.Errorf("probe pod terminated: %s %s", pod.Status.Reason, pod.Status.Message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("probe pod is still in phase '%s'", pod.Status.Phase)
+		case <-ticker.C:
+		}
+	}
+}
+
+// deletePods uses its own context, so the pods are also cleaned up if the context of the command got canceled.
+func (runner *nodeProbeRunner) deletePods(log logd.Logger, podNames map[string]string) {
+	for nodeName, podName := range podNames {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName,
+				Namespace: runner.namespace,
+			},
+		}
+
+		err := runner.client.Delete(context.Background(), pod)
+		if client.IgnoreNotFound(err) != nil {
+			logWarningf(log, "failed to delete probe pod '%s' on node '%s': %v", podName, nodeName, err)
+		}
+	}
+}
+
+func stdErrString(stdErr fmt.Stringer) string {
+	if stdErr == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(stdErr.String())
+}
@@ -2,7 +2,9 @@ package troubleshoot
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
@@ -10,6 +12,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -30,27 +33,30 @@ type Auths struct {
 
 type ImagePullFunc func(image string) error
 
-func verifyAllImagesAvailable(ctx context.Context, baseLog logd.Logger, keychain authn.Keychain, transport *http.Transport, dk *dynakube.DynaKube) error {
+func verifyAllImagesAvailable(ctx context.Context, baseLog logd.Logger, report *Report, keychain authn.Keychain, transport *http.Transport, dk *dynakube.DynaKube) error {
 	log := baseLog.WithName("imagepull")
 
 	imagePullFunc := CreateImagePullFunc(ctx, keychain, transport)
 
 	if dk.OneAgent().IsDaemonsetRequired() {
-		verifyImageIsAvailable(log, imagePullFunc, dk, componentOneAgent, false)
-		verifyImageIsAvailable(log, imagePullFunc, dk, componentCodeModules, true)
+		verifyImageIsAvailable(log, report, imagePullFunc, dk, componentOneAgent, false)
+		verifyImageIsAvailable(log, report, imagePullFunc, dk, componentCodeModules, true)
 	}
 
 	if dk.ActiveGate().IsEnabled() {
-		verifyImageIsAvailable(log, imagePullFunc, dk, componentActiveGate, false)
+		verifyImageIsAvailable(log, report, imagePullFunc, dk, componentActiveGate, false)
 	}
 
 	return nil
 }
 
-func verifyImageIsAvailable(log logd.Logger, pullImage ImagePullFunc, dk *dynakube.DynaKube, comp component, proxyWarning bool) {
+func verifyImageIsAvailable(log logd.Logger, report *Report, pullImage ImagePullFunc, dk *dynakube.DynaKube, comp component, proxyWarning bool) {
+	checkName := imagePullCheckName + "/" + comp.String()
+
 	image, isCustomImage := comp.getImage(dk)
 	if comp.SkipImageCheck(image) {
 		logErrorf(log, "Unknown %s image", comp.String())
+		report.failed(dk, checkName, errors.Errorf("Unknown %s image", comp.String()), "Verify that the Dynakube has been reconciled by the operator or configure a custom image.")
 
 		return
 	}
@@ -60,23 +66,38 @@ func verifyImageIsAvailable(log logd.Logger, pullImage ImagePullFunc, dk *dynaku
 
 	if image == "" {
 		logInfof(log, "No %s image configured", componentName)
+		report.passed(dk, checkName, fmt.Sprintf("No %s image configured", componentName))
 
 		return
 	}
 
+	var warnings []string
+
 	if dk.HasProxy() && proxyWarning {
-		logWarningf(log, "Proxy setting in Dynakube is ignored for %s image due to technical limitations.", componentName)
+		warnings = append(warnings, fmt.Sprintf("Proxy setting in Dynakube is ignored for %s image due to technical limitations.", componentName))
 	}
 
 	if getEnvProxySettings() != nil {
-		logWarningf(log, "Proxy settings in environment might interfere when pulling %s image in troubleshoot mode.", componentName)
+		warnings = append(warnings, fmt.Sprintf("Proxy settings in environment might interfere when pulling %s image in troubleshoot mode.", componentName))
+	}
+
+	for _, warning := range warnings {
+		logWarningf(log, "%s", warning)
 	}
 
 	err := pullImage(image)
 	if err != nil {
 		logErrorf(log, "Pulling %s image %s failed: %v", componentName, image, err)
+		report.failed(dk, checkName, errors.Wrapf(err, "Pulling %s image %s failed", componentName, image), "Verify that the image exists and that the pull secret grants access to the registry.")
 	} else {
-		logOkf(log, "%s image %s can be successfully pulled", componentName, image)
+		message := fmt.Sprintf("%s image %s can be successfully pulled", componentName, image)
+		logOkf(log, "%s", message)
+
+		if len(warnings) > 0 {
+			report.warning(dk, checkName, message+". "+strings.Join(warnings, " "), "Configure the proxy on node level for pulling images.")
+		} else {
+			report.passed(dk, checkName, message)
+		}
 	}
 }
 
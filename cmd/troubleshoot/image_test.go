@@ -170,12 +170,12 @@ func TestImagePullable(t *testing.T) {
 			logOutput := runWithTestLogger(func(log logd.Logger) {
 				ctx := context.Background()
 				clt := fake.NewClient(secret)
-				pullSecret, _ := checkDynakube(ctx, log, clt, test.dk)
+				pullSecret, _ := checkDynakube(ctx, log, NewReport(), clt, test.dk)
 				keychain, _ := dockerkeychain.NewDockerKeychain(context.Background(), fake.NewClient(secret), pullSecret)
 
 				transport, _ := createTransport(ctx, clt, test.dk, dockerServer.Client())
 				pullImage := CreateImagePullFunc(ctx, keychain, transport)
-				verifyImageIsAvailable(log, pullImage, test.dk, test.component, test.proxyWarning)
+				verifyImageIsAvailable(log, NewReport(), pullImage, test.dk, test.component, test.proxyWarning)
 			})
 
 			require.NotContains(t, logOutput, "failed")
@@ -262,12 +262,12 @@ func TestImageNotPullable(t *testing.T) {
 			logOutput := runWithTestLogger(func(log logd.Logger) {
 				ctx := context.Background()
 				clt := fake.NewClient(secret)
-				pullSecret, _ := checkDynakube(ctx, log, clt, test.dk)
+				pullSecret, _ := checkDynakube(ctx, log, NewReport(), clt, test.dk)
 				keychain, _ := dockerkeychain.NewDockerKeychain(context.Background(), fake.NewClient(secret), pullSecret)
 
 				transport, _ := createTransport(ctx, clt, test.dk, dockerServer.Client())
 				pullImage := CreateImagePullFunc(ctx, keychain, transport)
-				verifyImageIsAvailable(log, pullImage, test.dk, test.component, false)
+				verifyImageIsAvailable(log, NewReport(), pullImage, test.dk, test.component, false)
 			})
 
 			require.Contains(t, logOutput, "failed")
@@ -302,12 +302,12 @@ func TestOneAgentCodeModulesImageNotPullable(t *testing.T) {
 		logOutput := runWithTestLogger(func(log logd.Logger) {
 			ctx := context.Background()
 			clt := fake.NewClient(secret)
-			pullSecret, _ := checkDynakube(ctx, log, clt, &dk)
+			pullSecret, _ := checkDynakube(ctx, log, NewReport(), clt, &dk)
 			keychain, _ := dockerkeychain.NewDockerKeychain(context.Background(), fake.NewClient(secret), pullSecret)
 
 			transport, _ := createTransport(ctx, clt, &dk, dockerServer.Client())
 			pullImage := CreateImagePullFunc(ctx, keychain, transport)
-			verifyImageIsAvailable(log, pullImage, &dk, componentCodeModules, true)
+			verifyImageIsAvailable(log, NewReport(), pullImage, &dk, componentCodeModules, true)
 		})
 		assert.Contains(t, logOutput, "failed")
 		assert.Contains(t, logOutput, "no such host")
@@ -323,11 +323,11 @@ func TestOneAgentCodeModulesImageNotPullable(t *testing.T) {
 		logOutput := runWithTestLogger(func(log logd.Logger) {
 			ctx := context.Background()
 			clt := fake.NewClient(secret)
-			pullSecret, _ := checkDynakube(ctx, log, clt, &dk)
+			pullSecret, _ := checkDynakube(ctx, log, NewReport(), clt, &dk)
 			keychain, _ := dockerkeychain.NewDockerKeychain(context.Background(), fake.NewClient(secret), pullSecret)
 			transport, _ := createTransport(ctx, clt, &dk, dockerServer.Client())
 			pullImage := CreateImagePullFunc(ctx, keychain, transport)
-			verifyImageIsAvailable(log, pullImage, &dk, componentCodeModules, false)
+			verifyImageIsAvailable(log, NewReport(), pullImage, &dk, componentCodeModules, false)
 		})
 		assert.NotContains(t, logOutput, "Unknown OneAgentCodeModules image")
 	})
@@ -0,0 +1,149 @@
+package troubleshoot
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	nodeProbeUse                = "node-probe"
+	nodeProbeTargetFlag         = "target"
+	nodeProbeProxyFromStdinFlag = "proxy-from-stdin"
+	nodeProbeIdleFlagName       = "idle"
+)
+
+var (
+	nodeProbeTargetsFlagValue        []string
+	nodeProbeProxyFromStdinFlagValue bool
+	nodeProbeIdleFlagValue           time.Duration
+)
+
+// nodeProbeResult is printed by the node-probe command running on a node, and read back by the troubleshoot command.
+type nodeProbeResult struct {
+	Target            string   `json:"target"`
+	ResolvedAddresses []string `json:"resolvedAddresses,omitempty"`
+	DnsLatencyMs      int64    `json:"dnsLatencyMs,omitempty"`
+	TcpLatencyMs      int64    `json:"tcpLatencyMs,omitempty"`
+	Error             string   `json:"error,omitempty"`
+}
+
+// newNodeProbeCommand creates the command run in the probe pods on the nodes, it is not meant to be used directly.
+// With --idle, it only keeps the pod running until the probes are executed in it.
+func newNodeProbeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    nodeProbeUse,
+		Hidden: true,
+		RunE:   runNodeProbe,
+	}
+
+	cmd.Flags().StringSliceVar(&nodeProbeTargetsFlagValue, nodeProbeTargetFlag, nil, "URL of an endpoint to probe.")
+	cmd.Flags().BoolVar(&nodeProbeProxyFromStdinFlagValue, nodeProbeProxyFromStdinFlag, false, "Read the proxy to connect to the endpoints from stdin.")
+	cmd.Flags().DurationVar(&nodeProbeIdleFlagValue, nodeProbeIdleFlagName, 0, "Keep running for the given duration without probing.")
+
+	return cmd
+}
+
+func runNodeProbe(cmd *cobra.Command, _ []string) error {
+	if nodeProbeIdleFlagValue > 0 {
+		select {
+		case <-time.After(nodeProbeIdleFlagValue):
+		case <-cmd.Context().Done():
+		}
+
+		return nil
+	}
+
+	transport := &http.Transport{}
+
+	if nodeProbeProxyFromStdinFlagValue {
+		proxy, err := readProxy(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	results := make([]nodeProbeResult, 0, len(nodeProbeTargetsFlagValue))
+	for _, target := range nodeProbeTargetsFlagValue {
+		results = append(results, probeTarget(cmd.Context(), transport, target))
+	}
+
+	return errors.WithStack(json.NewEncoder(cmd.OutOrStdout()).Encode(results))
+}
+
+// readProxy reads the proxy URL from the given reader, it is not passed as argument, as it may contain credentials.
+func readProxy(reader io.Reader) (*url.URL, error) {
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read proxy from stdin")
+	}
+
+	rawProxy := strings.TrimSpace(string(raw))
+	if rawProxy == "" {
+		return nil, errors.New("no proxy was passed via stdin")
+	}
+
+	proxy, err := url.Parse(rawProxy)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return proxy, nil
+}
+
+// probeTarget resolves the host of the target and opens a tcp connection to it.
+// TLS is not verified, as the trustedCAs of the Dynakube are not available on the node, this is covered by the connectivity check.
+func probeTarget(ctx context.Context, transport *http.Transport, target string) nodeProbeResult {
+	result := nodeProbeResult{Target: target}
+
+	host, err := dtclient.ParseEndpoint(target)
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectivityTimeout)
+	defer cancel()
+
+	if net.ParseIP(host.Host) == nil {
+		start := time.Now()
+
+		addresses, err := net.DefaultResolver.LookupHost(ctx, host.Host)
+		if err != nil && transport.Proxy == nil {
+			result.Error = errors.WithMessage(err, "dns lookup failed").Error()
+
+			return result
+		}
+
+		// with a proxy, the host only has to be resolvable by the proxy
+		result.ResolvedAddresses = addresses
+		result.DnsLatencyMs = time.Since(start).Milliseconds()
+	}
+
+	start := time.Now()
+
+	conn, err := dialTcp(ctx, transport, host.Protocol, endpointAddress(host))
+	if err != nil {
+		result.Error = errors.WithMessage(err, "tcp connection failed").Error()
+
+		return result
+	}
+
+	_ = conn.Close()
+
+	result.TcpLatencyMs = time.Since(start).Milliseconds()
+
+	return result
+}
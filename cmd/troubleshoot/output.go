@@ -0,0 +1,143 @@
+package troubleshoot
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	outputFormatText  = "text"
+	outputFormatJson  = "json"
+	outputFormatYaml  = "yaml"
+	outputFormatJUnit = "junit"
+
+	junitClusterSuiteName = "cluster"
+)
+
+var outputFormats = []string{outputFormatText, outputFormatJson, outputFormatYaml, outputFormatJUnit}
+
+func validateOutputFormat(format string) error {
+	if !slices.Contains(outputFormats, format) {
+		return errors.Errorf("unsupported output format '%s', must be one of: %s", format, strings.Join(outputFormats, ", "))
+	}
+
+	return nil
+}
+
+// writeReport prints the report in the given machine-readable format, the text format is printed by the logger while the checks are running.
+func writeReport(out io.Writer, report *Report, format string) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch format {
+	case outputFormatJson:
+		data, err = json.MarshalIndent(report, "", "  ")
+	case outputFormatYaml:
+		data, err = yaml.Marshal(report)
+	case outputFormatJUnit:
+		data, err = xml.MarshalIndent(newJUnitTestSuites(report), "", "  ")
+		if err == nil {
+			data = append([]byte(xml.Header), data...)
+		}
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = fmt.Fprintln(out, string(data))
+
+	return errors.WithStack(err)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// newJUnitTestSuites creates a test suite per DynaKube, checks that are not specific to a DynaKube are grouped into the cluster suite.
+func newJUnitTestSuites(report *Report) junitTestSuites {
+	suites := junitTestSuites{Name: use}
+
+	for _, result := range report.Results {
+		suiteName := result.DynaKube
+		if suiteName == "" {
+			suiteName = junitClusterSuiteName
+		}
+
+		index := slices.IndexFunc(suites.Suites, func(suite junitTestSuite) bool {
+			return suite.Name == suiteName
+		})
+		if index < 0 {
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: suiteName})
+			index = len(suites.Suites) - 1
+		}
+
+		testCase := junitTestCase{
+			Name:      result.Check,
+			ClassName: use + "." + suiteName,
+		}
+
+		switch result.Status {
+		case CheckStatusFailed:
+			testCase.Failure = &junitFailure{
+				Message: result.Message,
+				Text:    result.Remediation,
+			}
+			suites.Suites[index].Failures++
+			suites.Failures++
+		case CheckStatusWarning:
+			testCase.SystemOut = strings.TrimSpace(result.Message + "\n" + result.Remediation)
+		case CheckStatusSkipped:
+			testCase.Skipped = &junitSkipped{Message: result.Message}
+			suites.Suites[index].Skipped++
+		case CheckStatusPassed:
+			testCase.SystemOut = result.Message
+		}
+
+		suites.Suites[index].TestCases = append(suites.Suites[index].TestCases, testCase)
+		suites.Suites[index].Tests++
+		suites.Tests++
+	}
+
+	return suites
+}
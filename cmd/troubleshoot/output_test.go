@@ -0,0 +1,96 @@
+package troubleshoot
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func createTestReport() *Report {
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDynakube,
+			Namespace: testNamespace,
+		},
+	}
+
+	report := NewReport()
+	report.passed(nil, namespaceCheckName, "using namespace 'dynatrace'")
+	report.passed(dk, apiTokenCheckName, "secret token 'apiToken' exists")
+	report.warning(dk, proxyCheckName, "HTTP_PROXY is set in environment.", "set the proxy on node level")
+	report.failed(dk, imagePullCheckName+"/"+componentOneAgent.String(), errors.New("unauthorized"), "check the pull secret")
+
+	return report
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, format := range outputFormats {
+		require.NoError(t, validateOutputFormat(format))
+	}
+
+	require.Error(t, validateOutputFormat("xml"))
+}
+
+func TestReportFailed(t *testing.T) {
+	t.Run("report with failed check", func(t *testing.T) {
+		assert.True(t, createTestReport().Failed())
+	})
+	t.Run("warnings are not failures", func(t *testing.T) {
+		report := NewReport()
+		report.passed(nil, namespaceCheckName, "ok")
+		report.warning(nil, proxyCheckName, "warning", "")
+
+		assert.False(t, report.Failed())
+	})
+}
+
+func TestWriteReport(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeReport(&out, createTestReport(), outputFormatJson))
+
+		var report Report
+		require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+		assert.Equal(t, createTestReport().Results, report.Results)
+	})
+	t.Run("yaml", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeReport(&out, createTestReport(), outputFormatYaml))
+
+		var report Report
+		require.NoError(t, yaml.Unmarshal(out.Bytes(), &report))
+		assert.Equal(t, createTestReport().Results, report.Results)
+		assert.Contains(t, out.String(), "status: failed")
+	})
+	t.Run("junit", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeReport(&out, createTestReport(), outputFormatJUnit))
+
+		var suites junitTestSuites
+		require.NoError(t, xml.Unmarshal(out.Bytes(), &suites))
+		assert.Equal(t, 4, suites.Tests)
+		assert.Equal(t, 1, suites.Failures)
+		require.Len(t, suites.Suites, 2)
+		assert.Equal(t, junitClusterSuiteName, suites.Suites[0].Name)
+		assert.Equal(t, testNamespace+":"+testDynakube, suites.Suites[1].Name)
+		require.Len(t, suites.Suites[1].TestCases, 3)
+
+		failure := suites.Suites[1].TestCases[2].Failure
+		require.NotNil(t, failure)
+		assert.Equal(t, "unauthorized", failure.Message)
+		assert.Equal(t, "check the pull secret", failure.Text)
+	})
+	t.Run("text output is printed by the logger", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, writeReport(&out, createTestReport(), outputFormatText))
+		assert.Empty(t, out.String())
+	})
+}
@@ -2,73 +2,96 @@ package troubleshoot
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/pkg/errors"
 	"golang.org/x/net/http/httpproxy"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func checkProxySettings(ctx context.Context, baseLog logd.Logger, apiReader client.Reader, dk *dynakube.DynaKube) error {
+func checkProxySettings(ctx context.Context, baseLog logd.Logger, report *Report, apiReader client.Reader, dk *dynakube.DynaKube) error {
 	log := baseLog.WithName("proxy")
 
 	var proxyURL string
 
 	logNewCheckf(log, "Analyzing proxy settings ...")
 
+	var warnings []string
+
 	proxySettingsAvailable := false
 	if dk.HasProxy() {
 		proxySettingsAvailable = true
 
 		logInfof(log, "Reminder: Proxy settings in the Dynakube do not apply to pulling of pod images. Please set your proxy on accordingly on node level.")
-		logWarningf(log, "Proxy settings in the Dynakube are ignored for codeModules images due to technical limitations.")
+		warnings = append(warnings, logProxyWarningf(log, "Proxy settings in the Dynakube are ignored for codeModules images due to technical limitations."))
 
 		var err error
 
 		proxyURL, err = getProxyURL(ctx, apiReader, dk)
 		if err != nil {
 			logErrorf(log, "Unexpected error when reading proxy settings from Dynakube: %v", err)
+			report.failed(dk, proxyCheckName, errors.Wrap(err, "Unexpected error when reading proxy settings from Dynakube"), "Verify that the proxy value or the referenced secret of the Dynakube is valid.")
 
 			return nil
 		}
 	}
 
-	if checkEnvironmentProxySettings(log, proxyURL) {
+	envWarnings, envProxySettingsAvailable := checkEnvironmentProxySettings(log, proxyURL)
+	if envProxySettingsAvailable {
 		proxySettingsAvailable = true
+		warnings = append(warnings, envWarnings...)
 	}
 
 	if !proxySettingsAvailable {
 		logOkf(log, "No proxy settings found.")
+		report.passed(dk, proxyCheckName, "No proxy settings found.")
+
+		return nil
 	}
 
+	report.warning(dk, proxyCheckName, strings.Join(warnings, " "), "Proxy settings in the Dynakube do not apply to pulling of pod images, set the proxy on node level.")
+
 	return nil
 }
 
-func checkEnvironmentProxySettings(log logd.Logger, proxyURL string) bool {
+func checkEnvironmentProxySettings(log logd.Logger, proxyURL string) ([]string, bool) {
 	envProxy := getEnvProxySettings()
 	if envProxy == nil {
-		return false
+		return nil, false
 	}
 
 	logInfof(log, "Searching environment for proxy settings ...")
 
+	var warnings []string
+
 	if envProxy.HTTPProxy != "" {
-		logWarningf(log, "HTTP_PROXY is set in environment. This setting will be used by the operator for codeModule image pulls.")
+		warnings = append(warnings, logProxyWarningf(log, "HTTP_PROXY is set in environment. This setting will be used by the operator for codeModule image pulls."))
 
 		if proxySettingsDiffer(envProxy.HTTPProxy, proxyURL) {
-			logWarningf(log, "Proxy settings in the Dynakube and HTTP_PROXY differ.")
+			warnings = append(warnings, logProxyWarningf(log, "Proxy settings in the Dynakube and HTTP_PROXY differ."))
 		}
 	}
 
 	if envProxy.HTTPSProxy != "" {
-		logWarningf(log, "HTTPS_PROXY is set in environment. This setting will be used by the operator for codeModule image pulls.")
+		warnings = append(warnings, logProxyWarningf(log, "HTTPS_PROXY is set in environment. This setting will be used by the operator for codeModule image pulls."))
 
 		if proxySettingsDiffer(envProxy.HTTPSProxy, proxyURL) {
-			logWarningf(log, "Proxy settings in the Dynakube and HTTPS_PROXY differ.")
+			warnings = append(warnings, logProxyWarningf(log, "Proxy settings in the Dynakube and HTTPS_PROXY differ."))
 		}
 	}
 
-	return true
+	return warnings, true
+}
+
+// logProxyWarningf logs the warning and returns it, so it can be added to the report.
+func logProxyWarningf(log logd.Logger, format string, v ...any) string {
+	message := fmt.Sprintf(format, v...)
+	logWarningf(log, "%s", message)
+
+	return message
 }
 
 func proxySettingsDiffer(envProxy, dynakubeProxy string) bool {
@@ -17,8 +17,9 @@ func TestCheckProxySettings(t *testing.T) {
 		t.Setenv("HTTP_PROXY", "")
 		t.Setenv("HTTPS_PROXY", "")
 
+		report := NewReport()
 		logOutput := runWithTestLogger(func(logger logd.Logger) {
-			checkProxySettings(context.Background(), logger, nil, &dynakube.DynaKube{})
+			checkProxySettings(context.Background(), logger, report, nil, &dynakube.DynaKube{})
 		})
 
 		require.NotContains(t, logOutput, "Unexpected error")
@@ -26,13 +27,17 @@ func TestCheckProxySettings(t *testing.T) {
 		assert.NotContains(t, logOutput, "HTTPS_PROXY")
 		assert.NotContains(t, logOutput, "Dynakube")
 		assert.Contains(t, logOutput, "No proxy settings found.")
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, CheckStatusPassed, report.Results[0].Status)
 	})
 	t.Run("HTTP_PROXY", func(t *testing.T) {
 		t.Setenv("HTTP_PROXY", "foobar:1234")
 		t.Setenv("HTTPS_PROXY", "")
 
+		report := NewReport()
 		logOutput := runWithTestLogger(func(logger logd.Logger) {
-			checkProxySettings(context.Background(), logger, nil, &dynakube.DynaKube{})
+			checkProxySettings(context.Background(), logger, report, nil, &dynakube.DynaKube{})
 		})
 
 		require.NotContains(t, logOutput, "Unexpected error")
@@ -40,13 +45,17 @@ func TestCheckProxySettings(t *testing.T) {
 		assert.NotContains(t, logOutput, "HTTPS_PROXY")
 		assert.NotContains(t, logOutput, "Dynakube")
 		assert.NotContains(t, logOutput, "No proxy settings found.")
+
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, CheckStatusWarning, report.Results[0].Status)
+		assert.Contains(t, report.Results[0].Message, "HTTP_PROXY")
 	})
 	t.Run("HTTPS_PROXY", func(t *testing.T) {
 		t.Setenv("HTTP_PROXY", "")
 		t.Setenv("HTTPS_PROXY", "foobar:1234")
 
 		logOutput := runWithTestLogger(func(logger logd.Logger) {
-			checkProxySettings(context.Background(), logger, nil, &dynakube.DynaKube{})
+			checkProxySettings(context.Background(), logger, NewReport(), nil, &dynakube.DynaKube{})
 		})
 
 		require.NotContains(t, logOutput, "Unexpected error")
@@ -64,7 +73,7 @@ func TestCheckProxySettings(t *testing.T) {
 			build()
 
 		logOutput := runWithTestLogger(func(logger logd.Logger) {
-			checkProxySettings(context.Background(), logger, nil, &dk)
+			checkProxySettings(context.Background(), logger, NewReport(), nil, &dk)
 		})
 
 		require.NotContains(t, logOutput, "Unexpected error")
@@ -94,7 +103,7 @@ func TestCheckProxySettings(t *testing.T) {
 			build()
 
 		logOutput := runWithTestLogger(func(logger logd.Logger) {
-			checkProxySettings(context.Background(), logger, clt, &dk)
+			checkProxySettings(context.Background(), logger, NewReport(), clt, &dk)
 		})
 
 		require.NotContains(t, logOutput, "Unexpected error")
@@ -112,7 +121,7 @@ func TestCheckProxySettings(t *testing.T) {
 			build()
 
 		logOutput := runWithTestLogger(func(logger logd.Logger) {
-			checkProxySettings(context.Background(), logger, nil, &dk)
+			checkProxySettings(context.Background(), logger, NewReport(), nil, &dk)
 		})
 
 		require.NotContains(t, logOutput, "Unexpected error")
@@ -0,0 +1,93 @@
+package troubleshoot
+
+import (
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+)
+
+const (
+	oneAgentAPMCheckName  = "oneAgentAPM"
+	clusterCheckName      = "cluster"
+	namespaceCheckName    = "namespace"
+	crdCheckName          = "crd"
+	dynakubeCheckName     = "dynakube"
+	apiTokenCheckName     = "apiToken"
+	tokenScopesCheckName  = "tokenScopes"
+	apiUrlCheckName       = "apiUrl"
+	pullSecretCheckName   = "pullSecret"
+	imagePullCheckName    = "imagepull"
+	proxyCheckName        = "proxy"
+	connectivityCheckName = "connectivity"
+	archiveCheckName      = "archive"
+	nodeProbeCheckName    = "node"
+)
+
+type CheckStatus string
+
+const (
+	CheckStatusPassed  CheckStatus = "passed"
+	CheckStatusWarning CheckStatus = "warning"
+	CheckStatusFailed  CheckStatus = "failed"
+	CheckStatusSkipped CheckStatus = "skipped"
+)
+
+// CheckResult is the outcome of a single check, DynaKube is empty for checks that are not specific to a DynaKube.
+type CheckResult struct {
+	Check       string      `json:"check"`
+	DynaKube    string      `json:"dynakube,omitempty"`
+	Status      CheckStatus `json:"status"`
+	Message     string      `json:"message"`
+	Remediation string      `json:"remediation,omitempty"`
+}
+
+// Report collects the results of all checks run by the troubleshoot command, so they can be printed in a machine-readable format.
+type Report struct {
+	Results []CheckResult `json:"results"`
+}
+
+func NewReport() *Report {
+	return &Report{Results: []CheckResult{}}
+}
+
+// Failed returns true if any check failed.
+func (report *Report) Failed() bool {
+	for _, result := range report.Results {
+		if result.Status == CheckStatusFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (report *Report) passed(dk *dynakube.DynaKube, check, message string) {
+	report.add(dk, check, CheckStatusPassed, message, "")
+}
+
+func (report *Report) warning(dk *dynakube.DynaKube, check, message, remediation string) {
+	report.add(dk, check, CheckStatusWarning, message, remediation)
+}
+
+func (report *Report) failed(dk *dynakube.DynaKube, check string, err error, remediation string) {
+	report.add(dk, check, CheckStatusFailed, err.Error(), remediation)
+}
+
+func (report *Report) skipped(dk *dynakube.DynaKube, check, reason string) {
+	report.add(dk, check, CheckStatusSkipped, reason, "")
+}
+
+func (report *Report) add(dk *dynakube.DynaKube, check string, status CheckStatus, message, remediation string) {
+	result := CheckResult{
+		Check:       check,
+		Status:      status,
+		Message:     message,
+		Remediation: remediation,
+	}
+
+	if dk != nil {
+		result.DynaKube = fmt.Sprintf("%s:%s", dk.Namespace, dk.Name)
+	}
+
+	report.Results = append(report.Results, result)
+}
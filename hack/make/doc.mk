@@ -6,6 +6,7 @@ doc: doc/api-ref doc/gen-gomarkdoc
 doc/api-ref: manifests prerequisites/python
 	source local/.venv/bin/activate && python3 ./hack/doc/custom_resource_params_to_md.py ./config/crd/bases/dynatrace.com_dynakubes.yaml > ./doc/api/dynakube-api-ref.md
 	source local/.venv/bin/activate && python3 ./hack/doc/custom_resource_params_to_md.py ./config/crd/bases/dynatrace.com_edgeconnects.yaml > ./doc/api/edgeconnect-api-ref.md
+	source local/.venv/bin/activate && python3 ./hack/doc/custom_resource_params_to_md.py ./config/crd/bases/dynatrace.com_injectionpolicies.yaml > ./doc/api/injectionpolicy-api-ref.md
 
 ## Create a table containing permissions needed by Operator components
 doc/permissions: manifests prerequisites/python
@@ -19,6 +19,7 @@ package scheme
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1"
 	_ "github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/edgeconnect"
+	_ "github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/injectionpolicy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2"
 	_ "github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha2/edgeconnect"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1"
@@ -0,0 +1,16 @@
+package pdb
+
+import "k8s.io/apimachinery/pkg/util/intstr"
+
+// +kubebuilder:object:generate=true
+type Spec struct {
+	// Number or percentage of pods that must still be available after an eviction.
+	// Mutually exclusive with maxUnavailable.
+	// +kubebuilder:validation:Optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Number or percentage of pods that can be unavailable after an eviction.
+	// Mutually exclusive with minAvailable.
+	// +kubebuilder:validation:Optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
@@ -0,0 +1,84 @@
+// +kubebuilder:object:generate=true
+// +groupName=dynatrace.com
+// +versionName=v1alpha1
+// +kubebuilder:validation:Optional
+package injectionpolicy
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// InjectionPolicySpec defines the injection settings for the pods selected by the InjectionPolicy.
+// Annotations set on a pod take precedence over the settings of the policy.
+type InjectionPolicySpec struct {
+	// Selects the pods of the namespace the policy applies to by their labels. If not set, all pods of the namespace are selected.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Restricts the policy to pods owned by workloads of the given kinds, e.g. Deployment, StatefulSet, DaemonSet, Job or CronJob.
+	// Kinds are matched case-insensitively, use Pod for pods without a workload as owner.
+	OwnerKinds []string `json:"ownerKinds,omitempty"`
+
+	// Enables or disables the OneAgent injection for the selected pods.
+	OneAgent *bool `json:"oneAgent,omitempty"`
+
+	// Enables or disables the metadata-enrichment for the selected pods.
+	MetadataEnrichment *bool `json:"metadataEnrichment,omitempty"`
+
+	// Code module technologies to download, e.g. java,nginx (the default value is: all)
+	Technologies []string `json:"technologies,omitempty"`
+
+	// Code modules flavor to download.
+	// +kubebuilder:validation:Enum=default;musl
+	Flavor string `json:"flavor,omitempty"`
+
+	// Containers of the selected pods that are excluded from the injection.
+	ExcludedContainers []string `json:"excludedContainers,omitempty"`
+
+	// Controls what the init container does on failures, "fail" lets the pod fail to start, "silent" ignores the failure.
+	// +kubebuilder:validation:Enum=silent;fail
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+// InjectionPolicyStatus reports the pods the InjectionPolicy was applied to.
+type InjectionPolicyStatus struct {
+	// Names of the pods the policy was applied to, limited to the first 100 names
+	MatchedPods []string `json:"matchedPods,omitempty"`
+
+	// Number of pods the policy was applied to
+	MatchedPodCount int `json:"matchedPodCount"`
+
+	// The generation of the policy the status was computed for
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InjectionPolicy configures the injection into the pods of a namespace without changing their manifests.
+// If several policies select a pod, the one whose name sorts first is applied.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=injectionpolicies,scope=Namespaced,categories=dynatrace,shortName=ip
+// +kubebuilder:printcolumn:name="Matched",type=integer,JSONPath=`.status.matchedPodCount`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:storageversion
+type InjectionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InjectionPolicySpec   `json:"spec,omitempty"`
+	Status InjectionPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InjectionPolicyList contains a list of InjectionPolicy
+type InjectionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InjectionPolicy `json:"items"`
+}
+
+func init() {
+	v1alpha1.SchemeBuilder.Register(&InjectionPolicy{}, &InjectionPolicyList{})
+}
@@ -0,0 +1,148 @@
+//go:build !ignore_autogenerated
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package injectionpolicy
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectionPolicy) DeepCopyInto(out *InjectionPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectionPolicy.
+func (in *InjectionPolicy) DeepCopy() *InjectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InjectionPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectionPolicyList) DeepCopyInto(out *InjectionPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InjectionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectionPolicyList.
+func (in *InjectionPolicyList) DeepCopy() *InjectionPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectionPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InjectionPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectionPolicySpec) DeepCopyInto(out *InjectionPolicySpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OwnerKinds != nil {
+		in, out := &in.OwnerKinds, &out.OwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OneAgent != nil {
+		in, out := &in.OneAgent, &out.OneAgent
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MetadataEnrichment != nil {
+		in, out := &in.MetadataEnrichment, &out.MetadataEnrichment
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Technologies != nil {
+		in, out := &in.Technologies, &out.Technologies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedContainers != nil {
+		in, out := &in.ExcludedContainers, &out.ExcludedContainers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectionPolicySpec.
+func (in *InjectionPolicySpec) DeepCopy() *InjectionPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectionPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectionPolicyStatus) DeepCopyInto(out *InjectionPolicyStatus) {
+	*out = *in
+	if in.MatchedPods != nil {
+		in, out := &in.MatchedPods, &out.MatchedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectionPolicyStatus.
+func (in *InjectionPolicyStatus) DeepCopy() *InjectionPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectionPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -72,6 +72,28 @@ func (ag *Spec) GetReplicas() int32 {
 	return *ag.Replicas
 }
 
+func (ag *Spec) IsAutoscalingEnabled() bool {
+	return ag.Autoscaling != nil
+}
+
+// GetMinReplicas returns the lower replica limit of the autoscaler, defaults to 1.
+func (ag *Spec) GetMinReplicas() int32 {
+	if ag.Autoscaling == nil || ag.Autoscaling.MinReplicas == nil {
+		return 1
+	}
+
+	return *ag.Autoscaling.MinReplicas
+}
+
+// GetMaxReplicas returns the highest number of replicas the ActiveGate can have, considering autoscaling.
+func (ag *Spec) GetMaxReplicas() int32 {
+	if ag.IsAutoscalingEnabled() {
+		return ag.Autoscaling.MaxReplicas
+	}
+
+	return ag.GetReplicas()
+}
+
 func (ag *Spec) GetServiceAccountName() string {
 	return "dynatrace-" + ag.GetServiceAccountOwner()
 }
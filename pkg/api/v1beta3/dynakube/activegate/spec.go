@@ -1,6 +1,7 @@
 package activegate
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -86,6 +87,16 @@ type Spec struct {
 	// +kubebuilder:validation:Optional
 	PersistentVolumeClaim *corev1.PersistentVolumeClaimSpec `json:"persistentVolumeClaim,omitempty"`
 
+	// Adds a PodDisruptionBudget for the ActiveGate pods
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PodDisruptionBudget",order=41,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	PodDisruptionBudget *pdb.Spec `json:"podDisruptionBudget,omitempty"`
+
+	// Adds a HorizontalPodAutoscaler for the ActiveGate, the replicas setting is ignored while it is configured
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Autoscaling",order=42,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	Autoscaling *AutoscalingSpec `json:"autoscaling,omitempty"`
+
 	name   string
 	apiUrl string
 
@@ -120,6 +131,31 @@ type Spec struct {
 
 // +kubebuilder:object:generate=true
 
+type AutoscalingSpec struct {
+
+	// Lower limit for the number of ActiveGate replicas, defaults to 1
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Upper limit for the number of ActiveGate replicas
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Target average CPU utilization of the ActiveGate pods, in percent of the requested CPU
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+
+	// Target average memory utilization of the ActiveGate pods, in percent of the requested memory
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
 // CapabilityProperties is a struct which can be embedded by ActiveGate capabilities
 // Such as KubernetesMonitoring or Routing
 // It encapsulates common properties.
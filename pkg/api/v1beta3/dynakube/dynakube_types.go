@@ -97,6 +97,11 @@ type DynaKubeSpec struct { //nolint:revive
 	// +kubebuilder:validation:Optional
 	TelemetryService *telemetryservice.Spec `json:"telemetryService,omitempty"`
 
+	// When an (empty) NetworkPoliciesSpec is provided, the operator creates NetworkPolicies for the components it deploys.
+	// The rules are derived from the ports of the components and the Dynatrace endpoints known to the operator.
+	// +kubebuilder:validation:Optional
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+
 	// General configuration about OneAgent instances.
 	// You can't enable more than one module (classicFullStack, cloudNativeFullStack, hostMonitoring, or applicationMonitoring).
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OneAgent",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
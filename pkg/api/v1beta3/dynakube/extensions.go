@@ -2,6 +2,7 @@ package dynakube
 
 import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/image"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -51,6 +52,10 @@ type ExtensionExecutionControllerSpec struct {
 	// Selects EmptyDir volume to be storage device
 	// +kubebuilder:validation:Optional
 	UseEphemeralVolume bool `json:"useEphemeralVolume,omitempty"`
+
+	// Adds a PodDisruptionBudget for the ExtensionExecutionController pods
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *pdb.Spec `json:"podDisruptionBudget,omitempty"`
 }
 
 type OpenTelemetryCollectorSpec struct {
@@ -85,4 +90,8 @@ type OpenTelemetryCollectorSpec struct {
 	// Adds TopologySpreadConstraints for the OtelCollector pods
 	// +kubebuilder:validation:Optional
 	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Adds a PodDisruptionBudget for the OtelCollector pods
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *pdb.Spec `json:"podDisruptionBudget,omitempty"`
 }
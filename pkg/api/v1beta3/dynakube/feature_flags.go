@@ -21,9 +21,11 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
@@ -68,6 +70,10 @@ const (
 	AnnotationInjectionFailurePolicy       = AnnotationFeaturePrefix + "injection-failure-policy"
 	AnnotationFeatureInitContainerSeccomp  = AnnotationFeaturePrefix + "init-container-seccomp-profile"
 	AnnotationFeatureEnforcementMode       = AnnotationFeaturePrefix + "enforcement-mode"
+	AnnotationFeatureDriftPolicy           = AnnotationFeaturePrefix + "drift-policy"
+	AnnotationFeatureInjectionImageInclude = AnnotationFeaturePrefix + "injection-image-include"
+	AnnotationFeatureInjectionImageExclude = AnnotationFeaturePrefix + "injection-image-exclude"
+	AnnotationFeatureShortLivedWorkloadEnv = AnnotationFeaturePrefix + "short-lived-workload-env"
 
 	// CSI.
 	AnnotationFeatureMaxFailedCsiMountAttempts = AnnotationFeaturePrefix + "max-csi-mount-attempts"
@@ -79,6 +85,7 @@ const (
 	truePhrase   = "true"
 	silentPhrase = "silent"
 	failPhrase   = "fail"
+	revertPhrase = "revert"
 )
 
 const (
@@ -295,3 +302,50 @@ func (dk *DynaKube) FeatureInitContainerSeccomp() bool {
 func (dk *DynaKube) FeatureEnforcementMode() bool {
 	return dk.getFeatureFlagRaw(AnnotationFeatureEnforcementMode) != falsePhrase
 }
+
+// FeatureRevertDrift is a feature flag to revert changes made to the live objects managed by the operator immediately,
+// instead of only reporting them in the Drift condition.
+func (dk *DynaKube) FeatureRevertDrift() bool {
+	return dk.getFeatureFlagRaw(AnnotationFeatureDriftPolicy) == revertPhrase
+}
+
+// FeatureInjectionImageIncludes is a feature flag to only inject into containers whose image repository matches one of
+// the comma-separated glob patterns, e.g. "*/my-team/*,docker.io/library/*".
+func (dk *DynaKube) FeatureInjectionImageIncludes() []string {
+	return splitPatterns(dk.getFeatureFlagRaw(AnnotationFeatureInjectionImageInclude))
+}
+
+// FeatureInjectionImageExcludes is a feature flag to not inject into containers whose image repository matches one of
+// the comma-separated glob patterns, e.g. "*istio/proxyv2,*fluent-bit". Excludes take precedence over includes.
+func (dk *DynaKube) FeatureInjectionImageExcludes() []string {
+	return splitPatterns(dk.getFeatureFlagRaw(AnnotationFeatureInjectionImageExclude))
+}
+
+// FeatureShortLivedWorkloadEnv is a feature flag to set additional environment variables for the OneAgent in containers of
+// short-lived workloads (Jobs and CronJobs), as comma-separated list of NAME=VALUE pairs, e.g. "DT_LOGLEVELCON=info".
+func (dk *DynaKube) FeatureShortLivedWorkloadEnv() []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+
+	for _, pair := range splitPatterns(dk.getFeatureFlagRaw(AnnotationFeatureShortLivedWorkloadEnv)) {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+
+		envVars = append(envVars, corev1.EnvVar{Name: strings.TrimSpace(name), Value: strings.TrimSpace(value)})
+	}
+
+	return envVars
+}
+
+func splitPatterns(raw string) []string {
+	var patterns []string
+
+	for _, pattern := range strings.Split(raw, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	return patterns
+}
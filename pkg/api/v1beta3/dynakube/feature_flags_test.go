@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -319,3 +320,77 @@ func TestIsOneAgentPrivileged(t *testing.T) {
 		assert.False(t, dk.FeatureOneAgentPrivileged())
 	})
 }
+
+func TestRevertDrift(t *testing.T) {
+	t.Run("is false by default", func(t *testing.T) {
+		dk := DynaKube{}
+
+		assert.False(t, dk.FeatureRevertDrift())
+	})
+	t.Run("is true when policy is revert", func(t *testing.T) {
+		dk := DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					AnnotationFeatureDriftPolicy: "revert",
+				},
+			},
+		}
+
+		assert.True(t, dk.FeatureRevertDrift())
+	})
+	t.Run("is false when policy is report", func(t *testing.T) {
+		dk := DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					AnnotationFeatureDriftPolicy: "report",
+				},
+			},
+		}
+
+		assert.False(t, dk.FeatureRevertDrift())
+	})
+}
+
+func TestInjectionImagePatterns(t *testing.T) {
+	t.Run("are empty by default", func(t *testing.T) {
+		dk := DynaKube{}
+
+		assert.Empty(t, dk.FeatureInjectionImageIncludes())
+		assert.Empty(t, dk.FeatureInjectionImageExcludes())
+	})
+	t.Run("are split and trimmed", func(t *testing.T) {
+		dk := DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					AnnotationFeatureInjectionImageInclude: "*/my-team/*",
+					AnnotationFeatureInjectionImageExclude: " *istio/proxyv2 ,, *fluent-bit",
+				},
+			},
+		}
+
+		assert.Equal(t, []string{"*/my-team/*"}, dk.FeatureInjectionImageIncludes())
+		assert.Equal(t, []string{"*istio/proxyv2", "*fluent-bit"}, dk.FeatureInjectionImageExcludes())
+	})
+}
+
+func TestShortLivedWorkloadEnv(t *testing.T) {
+	t.Run("is empty by default", func(t *testing.T) {
+		dk := DynaKube{}
+
+		assert.Empty(t, dk.FeatureShortLivedWorkloadEnv())
+	})
+	t.Run("parses name value pairs and skips invalid ones", func(t *testing.T) {
+		dk := DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					AnnotationFeatureShortLivedWorkloadEnv: "DT_LOGLEVELCON=info, INVALID ,=value,DT_EMPTY=",
+				},
+			},
+		}
+
+		assert.Equal(t, []corev1.EnvVar{
+			{Name: "DT_LOGLEVELCON", Value: "info"},
+			{Name: "DT_EMPTY", Value: ""},
+		}, dk.FeatureShortLivedWorkloadEnv())
+	})
+}
@@ -0,0 +1,5 @@
+package dynakube
+
+// +kubebuilder:validation:Optional
+type NetworkPoliciesSpec struct {
+}
@@ -0,0 +1,5 @@
+package dynakube
+
+func (dk *DynaKube) IsNetworkPoliciesEnabled() bool {
+	return dk.Spec.NetworkPolicies != nil
+}
@@ -19,6 +19,7 @@ limitations under the License.
 package dynakube
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/kspm"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/logmonitoring"
@@ -121,6 +122,11 @@ func (in *DynaKubeSpec) DeepCopyInto(out *DynaKubeSpec) {
 		*out = new(telemetryservice.Spec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = new(NetworkPoliciesSpec)
+		**out = **in
+	}
 	in.OneAgent.DeepCopyInto(&out.OneAgent)
 	in.Templates.DeepCopyInto(&out.Templates)
 	in.ActiveGate.DeepCopyInto(&out.ActiveGate)
@@ -234,6 +240,11 @@ func (in *ExtensionExecutionControllerSpec) DeepCopyInto(out *ExtensionExecution
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(pdb.Spec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionExecutionControllerSpec.
@@ -302,6 +313,21 @@ func (in *MetadataEnrichmentStatus) DeepCopy() *MetadataEnrichmentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPoliciesSpec) DeepCopyInto(out *NetworkPoliciesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPoliciesSpec.
+func (in *NetworkPoliciesSpec) DeepCopy() *NetworkPoliciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPoliciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSpec) {
 	*out = *in
@@ -340,6 +366,11 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(pdb.Spec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryCollectorSpec.
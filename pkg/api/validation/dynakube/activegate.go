@@ -19,7 +19,13 @@ Make sure you don't duplicate an Activegate capability in your custom resource.
 `
 	errorActiveGateInvalidPVCConfiguration = ` DynaKube specifies a PVC for the ActiveGate while ephemeral volume is also enabled. These settings are mutually exclusive, please choose only one.`
 
+	errorActiveGateInvalidAutoscalingReplicas = `The DynaKube's specification sets minReplicas higher than maxReplicas for the ActiveGate autoscaling.`
+
+	errorActiveGateAutoscalingMissingTarget = `The DynaKube's specification enables autoscaling for the ActiveGate, but neither targetCPUUtilization nor targetMemoryUtilization is set.`
+
 	warningMissingActiveGateMemoryLimit = `ActiveGate specification missing memory limits. Can cause excess memory usage.`
+
+	warningActiveGateReplicasIgnored = `The DynaKube's specification sets replicas for the ActiveGate while autoscaling is enabled. The replicas setting will be ignored.`
 )
 
 func duplicateActiveGateCapabilities(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
@@ -82,3 +88,33 @@ func mutuallyExclusiveActiveGatePVsettings(_ context.Context, _ *Validator, dk *
 
 	return ""
 }
+
+func invalidActiveGateAutoscaling(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if !dk.ActiveGate().IsEnabled() || !dk.ActiveGate().IsAutoscalingEnabled() {
+		return ""
+	}
+
+	autoscaling := dk.Spec.ActiveGate.Autoscaling
+
+	if dk.ActiveGate().GetMinReplicas() > autoscaling.MaxReplicas {
+		log.Info("requested dynakube has invalid autoscaling replicas for ActiveGate", "name", dk.Name, "namespace", dk.Namespace)
+
+		return errorActiveGateInvalidAutoscalingReplicas
+	}
+
+	if autoscaling.TargetCPUUtilization == nil && autoscaling.TargetMemoryUtilization == nil {
+		log.Info("requested dynakube has no autoscaling target for ActiveGate", "name", dk.Name, "namespace", dk.Namespace)
+
+		return errorActiveGateAutoscalingMissingTarget
+	}
+
+	return ""
+}
+
+func ignoredActiveGateReplicas(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	if dk.ActiveGate().IsEnabled() && dk.ActiveGate().IsAutoscalingEnabled() && dk.Spec.ActiveGate.Replicas != nil {
+		return warningActiveGateReplicasIgnored
+	}
+
+	return ""
+}
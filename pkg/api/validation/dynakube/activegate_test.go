@@ -6,8 +6,11 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/activegate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 )
 
 func TestDuplicateActiveGateCapabilities(t *testing.T) {
@@ -130,3 +133,59 @@ func TestActiveGatePVCSettings(t *testing.T) {
 			})
 	})
 }
+
+func TestActiveGateAutoscaling(t *testing.T) {
+	newDynakube := func(autoscaling *activegate.AutoscalingSpec) *dynakube.DynaKube {
+		return &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testApiUrl,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.RoutingCapability.DisplayName,
+					},
+					CapabilityProperties: activegate.CapabilityProperties{
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceMemory: *resource.NewQuantity(1, resource.BinarySI),
+							},
+						},
+					},
+					Autoscaling: autoscaling,
+				},
+			},
+		}
+	}
+
+	t.Run(`valid autoscaling`, func(t *testing.T) {
+		assertAllowedWithoutWarnings(t, newDynakube(&activegate.AutoscalingSpec{
+			MinReplicas:          ptr.To(int32(2)),
+			MaxReplicas:          5,
+			TargetCPUUtilization: ptr.To(int32(80)),
+		}))
+	})
+	t.Run(`minReplicas higher than maxReplicas`, func(t *testing.T) {
+		assertDenied(t, []string{errorActiveGateInvalidAutoscalingReplicas}, newDynakube(&activegate.AutoscalingSpec{
+			MinReplicas:          ptr.To(int32(3)),
+			MaxReplicas:          2,
+			TargetCPUUtilization: ptr.To(int32(80)),
+		}))
+	})
+	t.Run(`missing target`, func(t *testing.T) {
+		assertDenied(t, []string{errorActiveGateAutoscalingMissingTarget}, newDynakube(&activegate.AutoscalingSpec{
+			MaxReplicas: 2,
+		}))
+	})
+	t.Run(`replicas are ignored`, func(t *testing.T) {
+		dk := newDynakube(&activegate.AutoscalingSpec{
+			MaxReplicas:             2,
+			TargetMemoryUtilization: ptr.To(int32(80)),
+		})
+		dk.Spec.ActiveGate.Replicas = ptr.To(int32(3))
+
+		warnings, err := assertAllowed(t, dk)
+		require.NoError(t, err)
+		require.Len(t, warnings, 1)
+		assert.Equal(t, warningActiveGateReplicasIgnored, warnings[0])
+	})
+}
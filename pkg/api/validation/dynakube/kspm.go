@@ -13,7 +13,7 @@ const (
 )
 
 func tooManyAGReplicas(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
-	if dk.KSPM().IsEnabled() && dk.ActiveGate().GetReplicas() > 1 {
+	if dk.KSPM().IsEnabled() && dk.ActiveGate().GetMaxReplicas() > 1 {
 		return errorTooManyAGReplicas
 	}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/kspm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestTooManyAGReplicas(t *testing.T) {
@@ -63,6 +64,34 @@ func TestTooManyAGReplicas(t *testing.T) {
 				},
 			})
 	})
+	t.Run("activegate with autoscaling above 1 replica and kspm enabled", func(t *testing.T) {
+		assertDenied(t,
+			[]string{errorTooManyAGReplicas},
+			&dynakube.DynaKube{
+				ObjectMeta: defaultDynakubeObjectMeta,
+				Spec: dynakube.DynaKubeSpec{
+					APIURL: testApiUrl,
+					Kspm:   &kspm.Spec{},
+					ActiveGate: activegate.Spec{
+						Capabilities: []activegate.CapabilityDisplayName{
+							activegate.KubeMonCapability.DisplayName,
+						},
+						Autoscaling: &activegate.AutoscalingSpec{
+							MaxReplicas:          2,
+							TargetCPUUtilization: ptr.To(int32(80)),
+						},
+					},
+					Templates: dynakube.TemplatesSpec{
+						KspmNodeConfigurationCollector: kspm.NodeConfigurationCollectorSpec{
+							ImageRef: image.Ref{
+								Repository: "repo/image",
+								Tag:        "version",
+							},
+						},
+					},
+				},
+			})
+	})
 }
 
 func TestMissingKSPMDependency(t *testing.T) {
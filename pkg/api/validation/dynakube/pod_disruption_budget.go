@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+)
+
+const (
+	errorConflictingPodDisruptionBudget = `The DynaKube's specification sets both minAvailable and maxUnavailable for the PodDisruptionBudget of the %s. These settings are mutually exclusive, please choose only one.`
+)
+
+type podDisruptionBudgetOwner struct {
+	spec *pdb.Spec
+	name string
+}
+
+func conflictingPodDisruptionBudgetSettings(_ context.Context, _ *Validator, dk *dynakube.DynaKube) string {
+	owners := []podDisruptionBudgetOwner{
+		{name: "ActiveGate", spec: dk.Spec.ActiveGate.PodDisruptionBudget},
+		{name: "ExtensionExecutionController", spec: dk.Spec.Templates.ExtensionExecutionController.PodDisruptionBudget},
+		{name: "OpenTelemetryCollector", spec: dk.Spec.Templates.OpenTelemetryCollector.PodDisruptionBudget},
+	}
+
+	for _, owner := range owners {
+		if owner.spec != nil && owner.spec.MinAvailable != nil && owner.spec.MaxUnavailable != nil {
+			log.Info("requested dynakube has conflicting pod disruption budget settings", "name", dk.Name, "namespace", dk.Namespace, "component", owner.name)
+
+			return fmt.Sprintf(errorConflictingPodDisruptionBudget, owner.name)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/activegate"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestConflictingPodDisruptionBudgetSettings(t *testing.T) {
+	minAvailable := intstr.FromInt32(1)
+	maxUnavailable := intstr.FromString("50%")
+
+	t.Run(`minAvailable only`, func(t *testing.T) {
+		assertAllowedWithWarnings(t, 1, &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testApiUrl,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.RoutingCapability.DisplayName,
+					},
+					PodDisruptionBudget: &pdb.Spec{MinAvailable: &minAvailable},
+				},
+			},
+		})
+	})
+	t.Run(`both settings for ActiveGate`, func(t *testing.T) {
+		assertDenied(t, []string{fmt.Sprintf(errorConflictingPodDisruptionBudget, "ActiveGate")}, &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testApiUrl,
+				ActiveGate: activegate.Spec{
+					Capabilities: []activegate.CapabilityDisplayName{
+						activegate.RoutingCapability.DisplayName,
+					},
+					PodDisruptionBudget: &pdb.Spec{MinAvailable: &minAvailable, MaxUnavailable: &maxUnavailable},
+				},
+			},
+		})
+	})
+	t.Run(`both settings for OpenTelemetryCollector`, func(t *testing.T) {
+		assertDenied(t, []string{fmt.Sprintf(errorConflictingPodDisruptionBudget, "OpenTelemetryCollector")}, &dynakube.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynakube.DynaKubeSpec{
+				APIURL: testApiUrl,
+				Templates: dynakube.TemplatesSpec{
+					OpenTelemetryCollector: dynakube.OpenTelemetryCollectorSpec{
+						PodDisruptionBudget: &pdb.Spec{MinAvailable: &minAvailable, MaxUnavailable: &maxUnavailable},
+					},
+				},
+			},
+		})
+	})
+}
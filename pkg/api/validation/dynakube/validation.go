@@ -39,6 +39,7 @@ var (
 		invalidActiveGateCapabilities,
 		duplicateActiveGateCapabilities,
 		mutuallyExclusiveActiveGatePVsettings,
+		invalidActiveGateAutoscaling,
 		invalidActiveGateProxyUrl,
 		conflictingOneAgentConfiguration,
 		conflictingOneAgentNodeSelector,
@@ -52,6 +53,7 @@ var (
 		imageFieldHasTenantImage,
 		extensionControllerImage,
 		extensionControllerPVCStorageDevice,
+		conflictingPodDisruptionBudgetSettings,
 		tooManyAGReplicas,
 		missingKSPMImage,
 		missingLogMonitoringImage,
@@ -62,6 +64,7 @@ var (
 	}
 	validatorWarningFuncs = []validatorFunc{
 		missingActiveGateMemoryLimit,
+		ignoredActiveGateReplicas,
 		unsupportedOneAgentImage,
 		conflictingHostGroupSettings,
 		deprecatedFeatureFlag,
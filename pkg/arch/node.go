@@ -0,0 +1,92 @@
+package arch
+
+import (
+	"context"
+
+	containerv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NodeLabel is the well-known label of a Node that contains its architecture, it uses the same values as the Image Registry.
+const NodeLabel = corev1.LabelArchStable
+
+var imageToDynatraceArch = map[string]string{
+	AMDImage:   ArchX86,
+	ARMImage:   ArchARM,
+	PPCLEImage: ArchPPCLE,
+	S390Image:  ArchS390,
+}
+
+// IsSupported checks if code modules are available for the given architecture, in the format of the Image Registry.
+func IsSupported(imageArch string) bool {
+	_, ok := imageToDynatraceArch[imageArch]
+
+	return ok
+}
+
+// DynatraceArch returns the architecture for the DynatraceAPI of the given architecture, in the format of the Image Registry.
+// Unsupported architectures fall back to the architecture of this binary.
+func DynatraceArch(imageArch string) string {
+	if dtArch, ok := imageToDynatraceArch[imageArch]; ok {
+		return dtArch
+	}
+
+	return Arch
+}
+
+// FlavorFor returns the default flavor of the code modules for the given architecture, only x86 has a multidistro flavor.
+func FlavorFor(imageArch string) string {
+	if DynatraceArch(imageArch) == ArchX86 {
+		return FlavorMultidistro
+	}
+
+	return FlavorDefault
+}
+
+// PlatformFor returns the platform to pull images for the given architecture, unsupported architectures fall back to ImagePlatform.
+func PlatformFor(imageArch string) containerv1.Platform {
+	if !IsSupported(imageArch) {
+		return ImagePlatform
+	}
+
+	return containerv1.Platform{
+		OS:           DefaultImageOS,
+		Architecture: imageArch,
+	}
+}
+
+// FromLabels returns the architecture set via the NodeLabel in the given labels or node selector, if it is supported.
+func FromLabels(labels map[string]string) (string, bool) {
+	imageArch, ok := labels[NodeLabel]
+	if !ok || !IsSupported(imageArch) {
+		return "", false
+	}
+
+	return imageArch, true
+}
+
+// ForNode returns the architecture of the given Node, in the format of the Image Registry.
+// If the Node is unknown or has no supported architecture label, the architecture of this binary is returned.
+func ForNode(ctx context.Context, apiReader client.Reader, nodeName string) (string, error) {
+	if nodeName == "" {
+		return ImageArch, nil
+	}
+
+	var node corev1.Node
+
+	err := apiReader.Get(ctx, client.ObjectKey{Name: nodeName}, &node)
+	if k8serrors.IsNotFound(err) {
+		return ImageArch, nil
+	} else if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	if imageArch, ok := FromLabels(node.Labels); ok {
+		return imageArch, nil
+	}
+
+	return ImageArch, nil
+}
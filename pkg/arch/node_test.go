@@ -0,0 +1,70 @@
+package arch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDynatraceArch(t *testing.T) {
+	assert.Equal(t, ArchX86, DynatraceArch(AMDImage))
+	assert.Equal(t, ArchARM, DynatraceArch(ARMImage))
+	assert.Equal(t, ArchPPCLE, DynatraceArch(PPCLEImage))
+	assert.Equal(t, ArchS390, DynatraceArch(S390Image))
+	assert.Equal(t, Arch, DynatraceArch("riscv64"))
+}
+
+func TestFlavorFor(t *testing.T) {
+	assert.Equal(t, FlavorMultidistro, FlavorFor(AMDImage))
+	assert.Equal(t, FlavorDefault, FlavorFor(ARMImage))
+	assert.Equal(t, Flavor, FlavorFor(ImageArch))
+}
+
+func TestPlatformFor(t *testing.T) {
+	assert.Equal(t, ARMImage, PlatformFor(ARMImage).Architecture)
+	assert.Equal(t, DefaultImageOS, PlatformFor(ARMImage).OS)
+	assert.Equal(t, ImagePlatform, PlatformFor(""))
+}
+
+func TestForNode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("architecture from node label", func(t *testing.T) {
+		clt := fake.NewClient(createNode("node", map[string]string{NodeLabel: S390Image}))
+
+		imageArch, err := ForNode(ctx, clt, "node")
+		require.NoError(t, err)
+		assert.Equal(t, S390Image, imageArch)
+	})
+	t.Run("no label => architecture of the binary", func(t *testing.T) {
+		clt := fake.NewClient(createNode("node", nil))
+
+		imageArch, err := ForNode(ctx, clt, "node")
+		require.NoError(t, err)
+		assert.Equal(t, ImageArch, imageArch)
+	})
+	t.Run("unknown node => architecture of the binary", func(t *testing.T) {
+		imageArch, err := ForNode(ctx, fake.NewClient(), "node")
+		require.NoError(t, err)
+		assert.Equal(t, ImageArch, imageArch)
+	})
+	t.Run("no node name => architecture of the binary", func(t *testing.T) {
+		imageArch, err := ForNode(ctx, fake.NewClient(), "")
+		require.NoError(t, err)
+		assert.Equal(t, ImageArch, imageArch)
+	})
+}
+
+func createNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
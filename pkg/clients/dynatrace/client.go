@@ -162,6 +162,8 @@ func NewClient(url, apiToken, paasToken string, opts ...Option) (Client, error)
 		opt(dc)
 	}
 
+	dc.httpClient.Transport = newInstrumentedTransport(dc.httpClient.Transport, dc.url, dc.dynakubeName)
+
 	return dc, nil
 }
 
@@ -241,3 +243,10 @@ func HostGroup(hostGroup string) Option {
 		c.hostGroup = hostGroup
 	}
 }
+
+// DynakubeName creates an Option that sets the name of the DynaKube the client is used for, it is only used to label the request metrics.
+func DynakubeName(dynakubeName string) Option {
+	return func(c *dynatraceClient) {
+		c.dynakubeName = dynakubeName
+	}
+}
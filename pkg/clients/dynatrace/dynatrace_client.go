@@ -46,6 +46,8 @@ type dynatraceClient struct {
 	networkZone string
 
 	hostGroup string
+
+	dynakubeName string
 }
 
 type tokenType int
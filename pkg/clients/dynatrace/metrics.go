@@ -0,0 +1,122 @@
+package dynatrace
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	tracerName = "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+
+	dynakubeLabel   = "dynakube"
+	endpointLabel   = "endpoint"
+	methodLabel     = "method"
+	statusCodeLabel = "status_code"
+
+	// externalEndpoint is used for requests that do not target the Dynatrace API, e.g. a user provided installer URL.
+	externalEndpoint = "external"
+	// failedStatusCode is used when no response was received, e.g. because of a timeout.
+	failedStatusCode = "error"
+
+	versionPathSegment = "version"
+	versionPlaceholder = "{version}"
+)
+
+var (
+	requestsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_client",
+		Name:      "requests_total",
+		Help:      "Number of requests sent to the Dynatrace API",
+	}, []string{dynakubeLabel, endpointLabel, methodLabel, statusCodeLabel})
+
+	requestDurationMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_client",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of requests sent to the Dynatrace API in seconds",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+	}, []string{dynakubeLabel, endpointLabel, methodLabel, statusCodeLabel})
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestsMetric, requestDurationMetric)
+}
+
+// instrumentedTransport records metrics and, if a global OpenTelemetry TracerProvider is configured, a span for every request.
+type instrumentedTransport struct {
+	base     http.RoundTripper
+	baseUrl  string
+	dynakube string
+}
+
+func newInstrumentedTransport(base http.RoundTripper, baseUrl, dynakube string) *instrumentedTransport {
+	return &instrumentedTransport{
+		base:     base,
+		baseUrl:  baseUrl,
+		dynakube: dynakube,
+	}
+}
+
+func (transport *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := endpointFromUrl(transport.baseUrl, req.URL.String())
+
+	ctx, span := otel.GetTracerProvider().Tracer(tracerName).Start(req.Context(), req.Method+" "+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.template", endpoint),
+			attribute.String("dynatrace.dynakube", transport.dynakube),
+		))
+	defer span.End()
+
+	start := time.Now()
+	resp, err := transport.base.RoundTrip(req.WithContext(ctx))
+	duration := time.Since(start).Seconds()
+
+	statusCode := failedStatusCode
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		statusCode = strconv.Itoa(resp.StatusCode)
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+
+	requestsMetric.WithLabelValues(transport.dynakube, endpoint, req.Method, statusCode).Inc()
+	requestDurationMetric.WithLabelValues(transport.dynakube, endpoint, req.Method, statusCode).Observe(duration)
+
+	return resp, err
+}
+
+// endpointFromUrl strips the API base URL and query from the given URL, so it can be used as a metric label without exploding its cardinality.
+func endpointFromUrl(baseUrl, rawUrl string) string {
+	if !strings.HasPrefix(rawUrl, baseUrl) {
+		return externalEndpoint
+	}
+
+	path, _, _ := strings.Cut(strings.TrimPrefix(rawUrl, baseUrl), "?")
+
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] == versionPathSegment {
+			segments[i] = versionPlaceholder
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,89 @@
+package dynatrace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointFromUrl(t *testing.T) {
+	const testBaseUrl = "https://test.dev.dynatracelabs.com/api"
+
+	testCases := []struct {
+		title    string
+		url      string
+		expected string
+	}{
+		{
+			title:    "query is removed",
+			url:      testBaseUrl + "/v1/deployment/installer/agent/unix/paas/latest/metainfo?bitness=64&flavor=default",
+			expected: "/v1/deployment/installer/agent/unix/paas/latest/metainfo",
+		},
+		{
+			title:    "version is replaced",
+			url:      testBaseUrl + "/v1/deployment/installer/agent/unix/paas/version/1.2.3.4-5?flavor=default",
+			expected: "/v1/deployment/installer/agent/unix/paas/version/" + versionPlaceholder,
+		},
+		{
+			title:    "url without query is kept",
+			url:      testBaseUrl + "/v2/settings/objects",
+			expected: "/v2/settings/objects",
+		},
+		{
+			title:    "url outside of the api is external",
+			url:      "https://installer.example.com/oneagent.zip",
+			expected: externalEndpoint,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, endpointFromUrl(testBaseUrl, testCase.url))
+		})
+	}
+}
+
+func TestInstrumentedTransport(t *testing.T) {
+	const testDynakubeName = "test-instrumented-transport"
+
+	t.Cleanup(func() {
+		requestsMetric.DeletePartialMatch(prometheus.Labels{dynakubeLabel: testDynakubeName})
+		requestDurationMetric.DeletePartialMatch(prometheus.Labels{dynakubeLabel: testDynakubeName})
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/api/v2/apiTokens/lookup" {
+			writer.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dtc, err := NewClient(server.URL+"/api", apiToken, paasToken, DynakubeName(testDynakubeName))
+	require.NoError(t, err)
+
+	client := dtc.(*dynatraceClient)
+
+	sendTestRequest := func(url string) {
+		resp, err := client.makeRequest(context.Background(), url, dynatraceApiToken)
+		require.NoError(t, err)
+		utils.CloseBodyAfterRequest(resp)
+	}
+
+	sendTestRequest(client.getActiveGateConnectionInfoUrl())
+	sendTestRequest(client.getActiveGateConnectionInfoUrl())
+	sendTestRequest(client.getTokensLookupUrl())
+
+	assert.InDelta(t, 2, testutil.ToFloat64(requestsMetric.WithLabelValues(testDynakubeName, "/v1/deployment/installer/gateway/connectioninfo", http.MethodGet, "200")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(requestsMetric.WithLabelValues(testDynakubeName, "/v2/apiTokens/lookup", http.MethodGet, "429")), 0)
+}
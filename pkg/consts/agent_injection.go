@@ -15,6 +15,7 @@ const (
 
 	AgentInstallerUrlEnv     = "INSTALLER_URL"
 	AgentInstallerFlavorEnv  = "FLAVOR"
+	AgentInstallerArchEnv    = "ARCH"
 	AgentInstallerTechEnv    = "TECHNOLOGIES"
 	AgentInstallerVersionEnv = "VERSION"
 
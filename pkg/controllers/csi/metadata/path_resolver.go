@@ -39,8 +39,13 @@ func (pr PathResolver) AgentJobWorkDirForJob(jobName string) string {
 	return filepath.Join(pr.AgentJobWorkDirBase(), jobName)
 }
 
-func (pr PathResolver) AgentSharedBinaryDirForAgent(versionOrDigest string) string {
-	return filepath.Join(pr.AgentSharedBinaryDirBase(), versionOrDigest)
+// AgentSharedBinaryDirForArch is the cache of the code modules for one architecture, in the format of the Image Registry.
+func (pr PathResolver) AgentSharedBinaryDirForArch(imageArch string) string {
+	return filepath.Join(pr.AgentSharedBinaryDirBase(), imageArch)
+}
+
+func (pr PathResolver) AgentSharedBinaryDirForAgent(versionOrDigest, imageArch string) string {
+	return filepath.Join(pr.AgentSharedBinaryDirForArch(imageArch), versionOrDigest)
 }
 
 func (pr PathResolver) LatestAgentBinaryForDynaKube(dynakubeName string) string {
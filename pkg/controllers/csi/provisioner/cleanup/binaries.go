@@ -1,9 +1,11 @@
 package cleanup
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"golang.org/x/exp/maps"
 )
@@ -34,20 +36,43 @@ func (c *Cleaner) removeOldSharedBinaries(keptBins map[string]bool) {
 	}
 
 	for _, dir := range sharedBins {
-		sharedBinPath := c.path.AgentSharedBinaryDirForAgent(dir.Name())
+		if arch.IsSupported(dir.Name()) {
+			c.removeOldSharedBinariesForArch(dir.Name(), keptBins)
 
-		_, ok := keptBins[sharedBinPath]
-		if !ok {
-			err := c.fs.RemoveAll(sharedBinPath)
-			if err != nil {
-				log.Error(err, "failed to remove shared binary", "path", sharedBinPath)
+			continue
+		}
 
-				continue
-			}
+		// binaries that were downloaded before the shared binaries were split per architecture
+		c.removeOldSharedBinary(filepath.Join(c.path.AgentSharedBinaryDirBase(), dir.Name()), keptBins)
+	}
+}
 
-			log.Info("removed old shared binary", "path", sharedBinPath)
-		}
+func (c *Cleaner) removeOldSharedBinariesForArch(imageArch string, keptBins map[string]bool) {
+	sharedBins, err := c.fs.ReadDir(c.path.AgentSharedBinaryDirForArch(imageArch))
+	if err != nil {
+		log.Info("failed to list the shared binaries directory of an architecture, skipping it", "arch", imageArch)
+
+		return
+	}
+
+	for _, dir := range sharedBins {
+		c.removeOldSharedBinary(c.path.AgentSharedBinaryDirForAgent(dir.Name(), imageArch), keptBins)
+	}
+}
+
+func (c *Cleaner) removeOldSharedBinary(sharedBinPath string, keptBins map[string]bool) {
+	if _, ok := keptBins[sharedBinPath]; ok {
+		return
 	}
+
+	err := c.fs.RemoveAll(sharedBinPath)
+	if err != nil {
+		log.Error(err, "failed to remove shared binary", "path", sharedBinPath)
+
+		return
+	}
+
+	log.Info("removed old shared binary", "path", sharedBinPath)
 }
 
 func (c *Cleaner) removeOldBinarySymlinks(dks []dynakube.DynaKube, fsState fsState) {
@@ -2,10 +2,12 @@ package cleanup
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -45,7 +47,7 @@ func TestRemoveOldSharedBinaries(t *testing.T) {
 		for _, folder := range agentVersions {
 			cleaner.createSharedBinDir(t, folder)
 
-			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(folder)
+			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(folder, arch.ImageArch)
 			exists, _ := cleaner.fs.Exists(expectedDir)
 			require.True(t, exists)
 		}
@@ -53,7 +55,7 @@ func TestRemoveOldSharedBinaries(t *testing.T) {
 		cleaner.removeOldSharedBinaries(keptBins)
 
 		for _, folder := range agentVersions {
-			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(folder)
+			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(folder, arch.ImageArch)
 			exists, _ := cleaner.fs.Exists(expectedDir)
 			require.False(t, exists)
 		}
@@ -64,8 +66,8 @@ func TestRemoveOldSharedBinaries(t *testing.T) {
 		cleaner.fs.MkdirAll(cleaner.path.AgentSharedBinaryDirBase(), os.ModePerm)
 
 		keptBins := map[string]bool{
-			cleaner.path.AgentSharedBinaryDirForAgent("test1"): true,
-			cleaner.path.AgentSharedBinaryDirForAgent("test2"): true,
+			cleaner.path.AgentSharedBinaryDirForAgent("test1", arch.ImageArch): true,
+			cleaner.path.AgentSharedBinaryDirForAgent("test2", arch.ImageArch): true,
 		}
 		agentVersions := []string{"test1", "test2"}
 		orphans := []string{"o1", "o2"}
@@ -73,7 +75,7 @@ func TestRemoveOldSharedBinaries(t *testing.T) {
 		for _, version := range append(agentVersions, orphans...) {
 			cleaner.createSharedBinDir(t, version)
 
-			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(version)
+			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(version, arch.ImageArch)
 			exists, _ := cleaner.fs.Exists(expectedDir)
 			require.True(t, exists)
 		}
@@ -81,17 +83,43 @@ func TestRemoveOldSharedBinaries(t *testing.T) {
 		cleaner.removeOldSharedBinaries(keptBins)
 
 		for _, folder := range agentVersions {
-			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(folder)
+			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(folder, arch.ImageArch)
 			exists, _ := cleaner.fs.Exists(expectedDir)
 			require.True(t, exists)
 		}
 
 		for _, folder := range orphans {
-			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(folder)
+			expectedDir := cleaner.path.AgentSharedBinaryDirForAgent(folder, arch.ImageArch)
 			exists, _ := cleaner.fs.Exists(expectedDir)
 			require.False(t, exists)
 		}
 	})
+
+	t.Run("keptBins set -> remove orphans of all architectures and old flat binaries", func(t *testing.T) {
+		cleaner := createCleaner(t)
+
+		keptAMD := cleaner.path.AgentSharedBinaryDirForAgent("test1", arch.AMDImage)
+		keptARM := cleaner.path.AgentSharedBinaryDirForAgent("test1", arch.ARMImage)
+		keptFlat := filepath.Join(cleaner.path.AgentSharedBinaryDirBase(), "kept")
+		orphanARM := cleaner.path.AgentSharedBinaryDirForAgent("o1", arch.ARMImage)
+		orphanFlat := filepath.Join(cleaner.path.AgentSharedBinaryDirBase(), "o2")
+
+		for _, dir := range []string{keptAMD, keptARM, keptFlat, orphanARM, orphanFlat} {
+			require.NoError(t, cleaner.fs.MkdirAll(dir, os.ModePerm))
+		}
+
+		cleaner.removeOldSharedBinaries(map[string]bool{keptAMD: true, keptARM: true, keptFlat: true})
+
+		for _, dir := range []string{keptAMD, keptARM, keptFlat} {
+			exists, _ := cleaner.fs.Exists(dir)
+			assert.True(t, exists, dir)
+		}
+
+		for _, dir := range []string{orphanARM, orphanFlat} {
+			exists, _ := cleaner.fs.Exists(dir)
+			assert.False(t, exists, dir)
+		}
+	})
 }
 
 func TestCollectStillMountedBins(t *testing.T) {
@@ -280,7 +308,7 @@ func createBaseDk(t *testing.T, name, apiUrl string) dynakube.DynaKube {
 func (c *Cleaner) createSharedBinDir(t *testing.T, version string) {
 	t.Helper()
 
-	binDir := c.path.AgentSharedBinaryDirForAgent(version)
+	binDir := c.path.AgentSharedBinaryDirForAgent(version, arch.ImageArch)
 	err := c.fs.MkdirAll(binDir, os.ModePerm)
 	require.NoError(t, err)
 }
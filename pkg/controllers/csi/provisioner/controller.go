@@ -63,6 +63,10 @@ type OneAgentProvisioner struct {
 	jobInstallerBuilder    jobInstallerBuilder
 	cleaner                *cleanup.Cleaner
 	path                   metadata.PathResolver
+
+	// nodeName is the Node the provisioner runs on, nodeArch is its architecture once resolved
+	nodeName string
+	nodeArch string
 }
 
 // NewOneAgentProvisioner returns a new OneAgentProvisioner
@@ -80,6 +84,7 @@ func NewOneAgentProvisioner(mgr manager.Manager, opts dtcsi.CSIOptions) *OneAgen
 		imageInstallerBuilder:  image.NewImageInstaller,
 		jobInstallerBuilder:    job.NewInstaller,
 		cleaner:                cleanup.New(afero.Afero{Fs: fs}, mgr.GetAPIReader(), path, mount.New("")),
+		nodeName:               opts.NodeId,
 	}
 }
 
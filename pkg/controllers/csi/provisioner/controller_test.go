@@ -11,6 +11,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/provisioner/cleanup"
@@ -375,7 +376,7 @@ func createPMCSecret(t *testing.T, dk *dynakube.DynaKube) *corev1.Secret {
 func createPMCSourceFile(t *testing.T, prov OneAgentProvisioner, dk *dynakube.DynaKube) {
 	t.Helper()
 
-	targetDir := prov.getTargetDir(*dk)
+	targetDir := prov.getTargetDir(*dk, arch.ImageArch)
 
 	pmcPath := filepath.Join(targetDir, processmoduleconfig.RuxitAgentProcPath)
 	pmcDir := filepath.Dir(pmcPath)
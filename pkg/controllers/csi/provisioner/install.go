@@ -3,7 +3,6 @@ package csiprovisioner
 import (
 	"context"
 	"encoding/base64"
-	"errors"
 	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
@@ -16,6 +15,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/symlink"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/url"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/processmoduleconfig"
+	"github.com/pkg/errors"
 )
 
 const notReadyRequeueDuration = 30 * time.Second
@@ -23,14 +23,25 @@ const notReadyRequeueDuration = 30 * time.Second
 var errNotReady = errors.New("download job is not ready yet")
 
 func (provisioner *OneAgentProvisioner) installAgent(ctx context.Context, dk dynakube.DynaKube) error {
-	agentInstaller, err := provisioner.getInstaller(ctx, dk)
+	nodeArch, err := provisioner.getNodeArch(ctx)
+	if err != nil {
+		log.Info("failed to determine the architecture of the node", "node", provisioner.nodeName)
+
+		return err
+	}
+
+	agentInstaller, err := provisioner.getInstaller(ctx, dk, nodeArch)
 	if err != nil {
 		log.Info("failed to create CodeModule installer", "dk", dk.GetName())
 
 		return err
 	}
 
-	targetDir := provisioner.getTargetDir(dk)
+	if err := provisioner.fs.MkdirAll(provisioner.path.AgentSharedBinaryDirForArch(nodeArch), 0755); err != nil {
+		return errors.WithMessagef(err, "failed to create directory %s", provisioner.path.AgentSharedBinaryDirForArch(nodeArch))
+	}
+
+	targetDir := provisioner.getTargetDir(dk, nodeArch)
 
 	ready, err := agentInstaller.InstallAgent(ctx, targetDir)
 	if err != nil {
@@ -49,7 +60,25 @@ func (provisioner *OneAgentProvisioner) installAgent(ctx context.Context, dk dyn
 	return provisioner.setupAgentConfigDir(ctx, dk, targetDir)
 }
 
-func (provisioner *OneAgentProvisioner) getInstaller(ctx context.Context, dk dynakube.DynaKube) (installer.Installer, error) {
+// getNodeArch returns the architecture of the node the provisioner runs on, so the right code modules are downloaded in mixed-architecture clusters.
+// The architecture of a node doesn't change, so it is only looked up once.
+func (provisioner *OneAgentProvisioner) getNodeArch(ctx context.Context) (string, error) {
+	if provisioner.nodeArch != "" {
+		return provisioner.nodeArch, nil
+	}
+
+	nodeArch, err := arch.ForNode(ctx, provisioner.apiReader, provisioner.nodeName)
+	if err != nil {
+		return "", err
+	}
+
+	log.Info("determined architecture of the node", "node", provisioner.nodeName, "arch", nodeArch)
+	provisioner.nodeArch = nodeArch
+
+	return nodeArch, nil
+}
+
+func (provisioner *OneAgentProvisioner) getInstaller(ctx context.Context, dk dynakube.DynaKube, nodeArch string) (installer.Installer, error) {
 	switch {
 	case dk.FeatureDownloadViaJob():
 		return provisioner.getJobInstaller(ctx, dk), nil
@@ -59,6 +88,7 @@ func (provisioner *OneAgentProvisioner) getInstaller(ctx context.Context, dk dyn
 			ApiReader:    provisioner.apiReader,
 			Dynakube:     &dk,
 			PathResolver: provisioner.path,
+			Arch:         nodeArch,
 		}
 
 		imageInstaller, err := provisioner.imageInstallerBuilder(ctx, provisioner.fs, props)
@@ -76,8 +106,8 @@ func (provisioner *OneAgentProvisioner) getInstaller(ctx context.Context, dk dyn
 		props := &url.Properties{
 			Os:            dtclient.OsUnix,
 			Type:          dtclient.InstallerTypePaaS,
-			Arch:          arch.Arch,
-			Flavor:        arch.Flavor,
+			Arch:          arch.DynatraceArch(nodeArch),
+			Flavor:        arch.FlavorFor(nodeArch),
 			Technologies:  []string{"all"},
 			TargetVersion: dk.OneAgent().GetCodeModulesVersion(),
 			SkipMetadata:  true,
@@ -108,7 +138,7 @@ func (provisioner *OneAgentProvisioner) getJobInstaller(ctx context.Context, dk
 	return provisioner.jobInstallerBuilder(ctx, provisioner.fs, props)
 }
 
-func (provisioner *OneAgentProvisioner) getTargetDir(dk dynakube.DynaKube) string {
+func (provisioner *OneAgentProvisioner) getTargetDir(dk dynakube.DynaKube, nodeArch string) string {
 	var dirName string
 
 	if dk.OneAgent().GetCustomCodeModulesImage() != "" {
@@ -119,7 +149,7 @@ func (provisioner *OneAgentProvisioner) getTargetDir(dk dynakube.DynaKube) strin
 		dirName = dk.OneAgent().GetCodeModulesVersion()
 	}
 
-	return provisioner.path.AgentSharedBinaryDirForAgent(dirName)
+	return provisioner.path.AgentSharedBinaryDirForAgent(dirName, nodeArch)
 }
 
 func (provisioner *OneAgentProvisioner) createLatestVersionSymlink(dk dynakube.DynaKube, targetDir string) error {
@@ -1,10 +1,15 @@
 package csiprovisioner
 
 import (
+	"context"
 	"encoding/base64"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetTargetDir(t *testing.T) {
@@ -12,7 +17,7 @@ func TestGetTargetDir(t *testing.T) {
 		prov := createProvisioner(t)
 		dk := createDynaKubeWithVersion(t)
 
-		targetDir := prov.getTargetDir(*dk)
+		targetDir := prov.getTargetDir(*dk, arch.ImageArch)
 		require.Contains(t, targetDir, dk.OneAgent().GetCodeModulesVersion())
 	})
 
@@ -21,7 +26,64 @@ func TestGetTargetDir(t *testing.T) {
 		dk := createDynaKubeWithImage(t)
 
 		expectedDir := base64.StdEncoding.EncodeToString([]byte(dk.OneAgent().GetCodeModulesImage()))
-		targetDir := prov.getTargetDir(*dk)
+		targetDir := prov.getTargetDir(*dk, arch.ImageArch)
 		require.Contains(t, targetDir, expectedDir)
 	})
+
+	t.Run("folder is in the cache of the architecture", func(t *testing.T) {
+		prov := createProvisioner(t)
+		dk := createDynaKubeWithVersion(t)
+
+		targetDir := prov.getTargetDir(*dk, arch.ARMImage)
+		assert.Equal(t, prov.path.AgentSharedBinaryDirForAgent(dk.OneAgent().GetCodeModulesVersion(), arch.ARMImage), targetDir)
+		assert.NotEqual(t, prov.getTargetDir(*dk, arch.AMDImage), targetDir)
+	})
+}
+
+func TestGetNodeArch(t *testing.T) {
+	const nodeName = "test-node"
+
+	t.Run("architecture of the node is used", func(t *testing.T) {
+		prov := createProvisioner(t, createNode(nodeName, arch.S390Image))
+		prov.nodeName = nodeName
+
+		nodeArch, err := prov.getNodeArch(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, arch.S390Image, nodeArch)
+		assert.Equal(t, arch.S390Image, prov.nodeArch)
+	})
+	t.Run("architecture is only looked up once", func(t *testing.T) {
+		prov := createProvisioner(t)
+		prov.nodeName = nodeName
+		prov.nodeArch = arch.PPCLEImage
+
+		nodeArch, err := prov.getNodeArch(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, arch.PPCLEImage, nodeArch)
+	})
+	t.Run("unknown node => architecture of the binary", func(t *testing.T) {
+		prov := createProvisioner(t)
+		prov.nodeName = nodeName
+
+		nodeArch, err := prov.getNodeArch(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, arch.ImageArch, nodeArch)
+	})
+	t.Run("unsupported architecture label => architecture of the binary", func(t *testing.T) {
+		prov := createProvisioner(t, createNode(nodeName, "riscv64"))
+		prov.nodeName = nodeName
+
+		nodeArch, err := prov.getNodeArch(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, arch.ImageArch, nodeArch)
+	})
+}
+
+func createNode(name, imageArch string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{arch.NodeLabel: imageArch},
+		},
+	}
 }
@@ -0,0 +1,13 @@
+package horizontalpodautoscaler
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+const (
+	ActiveGateHorizontalPodAutoscalerConditionType string = "ActiveGateHorizontalPodAutoscaler"
+)
+
+var (
+	log = logd.Get().WithName("activegate-horizontal-pod-autoscaler")
+)
@@ -0,0 +1,102 @@
+package horizontalpodautoscaler
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	k8shpa "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/horizontalpodautoscaler"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ controllers.Reconciler = &Reconciler{}
+
+// Reconciler manages the HorizontalPodAutoscaler of the ActiveGate StatefulSet, it is created if autoscaling is configured and removed otherwise.
+type Reconciler struct {
+	client     client.Client
+	apiReader  client.Reader
+	dk         *dynakube.DynaKube
+	capability capability.Capability
+}
+
+func NewReconciler(clt client.Client, apiReader client.Reader, dk *dynakube.DynaKube, capability capability.Capability) *Reconciler {
+	return &Reconciler{
+		client:     clt,
+		apiReader:  apiReader,
+		dk:         dk,
+		capability: capability,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	if !r.capability.Enabled() || !r.dk.ActiveGate().IsAutoscalingEnabled() {
+		return r.removeOnce(ctx)
+	}
+
+	return r.createOrUpdate(ctx)
+}
+
+func (r *Reconciler) name() string {
+	return capability.CalculateStatefulSetName(r.capability, r.dk.Name)
+}
+
+func (r *Reconciler) removeOnce(ctx context.Context) error {
+	if meta.FindStatusCondition(*r.dk.Conditions(), ActiveGateHorizontalPodAutoscalerConditionType) == nil {
+		return nil
+	}
+	defer meta.RemoveStatusCondition(r.dk.Conditions(), ActiveGateHorizontalPodAutoscalerConditionType)
+
+	toDelete := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.name(),
+			Namespace: r.dk.Namespace,
+		},
+	}
+
+	err := k8shpa.Query(r.client, r.apiReader, log).Delete(ctx, toDelete)
+	if err != nil {
+		log.Error(err, "failed to clean up horizontal pod autoscaler", "name", r.name())
+
+		return nil
+	}
+
+	return nil
+}
+
+func (r *Reconciler) createOrUpdate(ctx context.Context) error {
+	autoscaling := r.dk.Spec.ActiveGate.Autoscaling
+	target := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Name:       r.name(),
+	}
+
+	desired, err := k8shpa.Build(r.dk, r.name(), target,
+		k8shpa.SetReplicas(r.dk.ActiveGate().GetMinReplicas(), autoscaling.MaxReplicas),
+		k8shpa.SetResourceUtilization(corev1.ResourceCPU, autoscaling.TargetCPUUtilization),
+		k8shpa.SetResourceUtilization(corev1.ResourceMemory, autoscaling.TargetMemoryUtilization),
+	)
+	if err != nil {
+		conditions.SetHorizontalPodAutoscalerGenFailed(r.dk.Conditions(), ActiveGateHorizontalPodAutoscalerConditionType, err)
+
+		return err
+	}
+
+	_, err = k8shpa.Query(r.client, r.apiReader, log).CreateOrUpdate(ctx, desired)
+	if err != nil {
+		log.Info("failed to create/update horizontal pod autoscaler", "name", r.name())
+		conditions.SetKubeApiError(r.dk.Conditions(), ActiveGateHorizontalPodAutoscalerConditionType, err)
+
+		return err
+	}
+
+	conditions.SetHorizontalPodAutoscalerCreated(r.dk.Conditions(), ActiveGateHorizontalPodAutoscalerConditionType, r.name())
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package horizontalpodautoscaler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testName      = "test-name"
+	testNamespace = "test-namespace"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create horizontal pod autoscaler", func(t *testing.T) {
+		dk := createDynakube(&activegate.AutoscalingSpec{
+			MinReplicas:             ptr.To(int32(2)),
+			MaxReplicas:             5,
+			TargetMemoryUtilization: ptr.To(int32(70)),
+		})
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk, capability.NewMultiCapability(dk)).Reconcile(ctx)
+		require.NoError(t, err)
+
+		hpa := getHorizontalPodAutoscaler(t, clt)
+		assert.Equal(t, "StatefulSet", hpa.Spec.ScaleTargetRef.Kind)
+		assert.Equal(t, testName+"-activegate", hpa.Spec.ScaleTargetRef.Name)
+		assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		require.Len(t, hpa.Spec.Metrics, 1)
+		assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[0].Resource.Name)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), ActiveGateHorizontalPodAutoscalerConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	})
+	t.Run("minReplicas defaults to 1", func(t *testing.T) {
+		dk := createDynakube(&activegate.AutoscalingSpec{
+			MaxReplicas:          3,
+			TargetCPUUtilization: ptr.To(int32(80)),
+		})
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk, capability.NewMultiCapability(dk)).Reconcile(ctx)
+		require.NoError(t, err)
+
+		hpa := getHorizontalPodAutoscaler(t, clt)
+		assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+	})
+	t.Run("remove horizontal pod autoscaler if autoscaling is disabled", func(t *testing.T) {
+		dk := createDynakube(&activegate.AutoscalingSpec{
+			MaxReplicas:          3,
+			TargetCPUUtilization: ptr.To(int32(80)),
+		})
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk, capability.NewMultiCapability(dk)).Reconcile(ctx)
+		require.NoError(t, err)
+
+		dk.Spec.ActiveGate.Autoscaling = nil
+
+		err = NewReconciler(clt, clt, dk, capability.NewMultiCapability(dk)).Reconcile(ctx)
+		require.NoError(t, err)
+
+		err = clt.Get(ctx, client.ObjectKey{Name: testName + "-activegate", Namespace: testNamespace}, &autoscalingv2.HorizontalPodAutoscaler{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), ActiveGateHorizontalPodAutoscalerConditionType))
+	})
+}
+
+func createDynakube(autoscaling *activegate.AutoscalingSpec) *dynakube.DynaKube {
+	return &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: dynakube.DynaKubeSpec{
+			ActiveGate: activegate.Spec{
+				Capabilities: []activegate.CapabilityDisplayName{
+					activegate.RoutingCapability.DisplayName,
+				},
+				Autoscaling: autoscaling,
+			},
+		},
+	}
+}
+
+func getHorizontalPodAutoscaler(t *testing.T, clt client.Client) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := clt.Get(context.Background(), client.ObjectKey{Name: testName + "-activegate", Namespace: testNamespace}, hpa)
+	require.NoError(t, err)
+
+	return hpa
+}
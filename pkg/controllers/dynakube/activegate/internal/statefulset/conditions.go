@@ -1,3 +1,6 @@
 package statefulset
 
-const ActiveGateStatefulSetConditionType string = "ActiveGateStatefulSet"
+const (
+	ActiveGateStatefulSetConditionType         string = "ActiveGateStatefulSet"
+	ActiveGatePodDisruptionBudgetConditionType string = "ActiveGatePodDisruptionBudget"
+)
@@ -4,20 +4,28 @@ import (
 	"hash/fnv"
 	"strconv"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/value"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/authtoken"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/customproperties"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/horizontalpodautoscaler"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/drift"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/poddisruptionbudget"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/statefulset"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -56,7 +64,27 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 		return err
 	}
 
-	return nil
+	err = r.podDisruptionBudgetReconciler().Reconcile(ctx)
+	if err != nil {
+		return err
+	}
+
+	return horizontalpodautoscaler.NewReconciler(r.client, r.apiReader, r.dk, r.capability).Reconcile(ctx)
+}
+
+func (r *Reconciler) podDisruptionBudgetReconciler() *poddisruptionbudget.Reconciler {
+	return NewPodDisruptionBudgetReconciler(r.client, r.apiReader, r.dk, r.capability, r.dk.Spec.ActiveGate.PodDisruptionBudget)
+}
+
+// NewPodDisruptionBudgetReconciler creates the reconciler for the PodDisruptionBudget of the given capability's StatefulSet, passing a nil spec removes it.
+func NewPodDisruptionBudgetReconciler(clt client.Client, apiReader client.Reader, dk *dynakube.DynaKube, agCapability capability.Capability, spec *pdb.Spec) *poddisruptionbudget.Reconciler {
+	appLabels := labels.NewAppLabels(labels.ActiveGateComponentLabel, dk.Name, agCapability.ShortName(), "")
+
+	return poddisruptionbudget.NewReconciler(clt, apiReader, dk, spec,
+		capability.CalculateStatefulSetName(agCapability, dk.Name),
+		ActiveGatePodDisruptionBudgetConditionType,
+		appLabels.BuildMatchLabels(),
+	)
 }
 
 func (r *Reconciler) manageStatefulSet(ctx context.Context) error {
@@ -67,7 +95,16 @@ func (r *Reconciler) manageStatefulSet(ctx context.Context) error {
 		return err
 	}
 
-	updated, err := statefulset.Query(r.client, r.apiReader, log).WithOwner(r.dk).CreateOrUpdate(ctx, desiredSts)
+	if r.dk.ActiveGate().IsAutoscalingEnabled() {
+		err = r.keepCurrentReplicas(ctx, desiredSts)
+		if err != nil {
+			conditions.SetKubeApiError(r.dk.Conditions(), ActiveGateStatefulSetConditionType, err)
+
+			return err
+		}
+	}
+
+	updated, err := statefulset.Query(r.client, r.apiReader, log).WithOwner(r.dk).WithDriftHandler(drift.NewHandler(r.dk)).CreateOrUpdate(ctx, desiredSts)
 	if err != nil {
 		conditions.SetKubeApiError(r.dk.Conditions(), ActiveGateStatefulSetConditionType, err)
 
@@ -79,6 +116,28 @@ func (r *Reconciler) manageStatefulSet(ctx context.Context) error {
 	return nil
 }
 
+// keepCurrentReplicas sets the replicas of the desired StatefulSet to the ones chosen by the HorizontalPodAutoscaler.
+// The hash annotation is added beforehand, so scaling by the HorizontalPodAutoscaler does not cause an update.
+func (r *Reconciler) keepCurrentReplicas(ctx context.Context, desiredSts *appsv1.StatefulSet) error {
+	err := hasher.AddAnnotation(desiredSts)
+	if err != nil {
+		return err
+	}
+
+	currentSts, err := statefulset.Query(r.client, r.apiReader, log).Get(ctx, types.NamespacedName{Name: desiredSts.Name, Namespace: desiredSts.Namespace})
+	if k8serrors.IsNotFound(err) {
+		desiredSts.Spec.Replicas = ptr.To(r.dk.ActiveGate().GetMinReplicas())
+
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	desiredSts.Spec.Replicas = currentSts.Spec.Replicas
+
+	return nil
+}
+
 func (r *Reconciler) buildDesiredStatefulSet(ctx context.Context) (*appsv1.StatefulSet, error) {
 	kubeUID := types.UID(r.dk.Status.KubeSystemUUID)
 
@@ -86,7 +86,7 @@ func (statefulSetBuilder Builder) getBaseObjectMeta() metav1.ObjectMeta {
 
 func (statefulSetBuilder Builder) getBaseSpec() appsv1.StatefulSetSpec {
 	return appsv1.StatefulSetSpec{
-		Replicas:            statefulSetBuilder.capability.Properties().Replicas,
+		Replicas:            statefulSetBuilder.getReplicas(),
 		PodManagementPolicy: appsv1.ParallelPodManagement,
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
@@ -99,6 +99,15 @@ func (statefulSetBuilder Builder) getBaseSpec() appsv1.StatefulSetSpec {
 	}
 }
 
+// getReplicas returns nil while autoscaling is enabled, as the replicas are managed by the HorizontalPodAutoscaler.
+func (statefulSetBuilder Builder) getReplicas() *int32 {
+	if statefulSetBuilder.dynakube.ActiveGate().IsAutoscalingEnabled() {
+		return nil
+	}
+
+	return statefulSetBuilder.capability.Properties().Replicas
+}
+
 func (statefulSetBuilder Builder) addLabels(sts *appsv1.StatefulSet) {
 	appLabels := statefulSetBuilder.buildAppLabels()
 	sts.ObjectMeta.Labels = appLabels.BuildLabels()
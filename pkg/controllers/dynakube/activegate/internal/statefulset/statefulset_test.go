@@ -176,6 +176,16 @@ func TestGetBaseSpec(t *testing.T) {
 		assert.Equal(t, testConfigHash, stsSpec.Template.Annotations[consts.AnnotationActiveGateConfigurationHash])
 		assert.Equal(t, testTokenHash, stsSpec.Template.Annotations[consts.AnnotationActiveGateTenantTokenHash])
 	})
+	t.Run("replicas are not set when autoscaling is enabled", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.ActiveGate.Autoscaling = &activegate.AutoscalingSpec{MaxReplicas: 3}
+		multiCapability := capability.NewMultiCapability(&dk)
+		builder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dk, multiCapability)
+
+		stsSpec := builder.getBaseSpec()
+
+		assert.Nil(t, stsSpec.Replicas)
+	})
 }
 
 func TestAddLabels(t *testing.T) {
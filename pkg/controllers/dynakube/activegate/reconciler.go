@@ -9,6 +9,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/authtoken"
 	capabilityInternal "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/customproperties"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/horizontalpodautoscaler"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
 	agconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/activegate"
@@ -187,6 +188,14 @@ func (r *Reconciler) createCapability(ctx context.Context, agCapability capabili
 }
 
 func (r *Reconciler) deleteCapability(ctx context.Context, agCapability capability.Capability) error {
+	if err := statefulset.NewPodDisruptionBudgetReconciler(r.client, r.apiReader, r.dk, agCapability, nil).Reconcile(ctx); err != nil {
+		return err
+	}
+
+	if err := horizontalpodautoscaler.NewReconciler(r.client, r.apiReader, r.dk, agCapability).Reconcile(ctx); err != nil {
+		return err
+	}
+
 	if err := r.deleteStatefulset(ctx, agCapability); err != nil {
 		return err
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/apimonitoring"
 	oaconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/deploymentmetadata"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/drift"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceapi"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceclient"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension"
@@ -21,6 +22,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/kspm"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/logmonitoring"
 	logmondaemonset "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/logmonitoring/daemonset"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/proxy"
@@ -32,10 +34,14 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -58,7 +64,10 @@ func Add(mgr manager.Manager, _ string) error {
 }
 
 func NewController(mgr manager.Manager, clusterID string) *Controller {
-	return NewDynaKubeController(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetConfig(), clusterID)
+	controller := NewDynaKubeController(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetConfig(), clusterID)
+	controller.eventRecorder = mgr.GetEventRecorderFor("dynakube-controller")
+
+	return controller
 }
 
 func NewDynaKubeController(kubeClient client.Client, apiReader client.Reader, config *rest.Config, clusterID string) *Controller {
@@ -83,15 +92,30 @@ func NewDynaKubeController(kubeClient client.Client, apiReader client.Reader, co
 		logMonitoringReconcilerBuilder:      logmonitoring.NewReconciler,
 		proxyReconcilerBuilder:              proxy.NewReconciler,
 		kspmReconcilerBuilder:               kspm.NewReconciler,
+		networkPolicyReconcilerBuilder:      networkpolicy.NewReconciler,
 	}
 }
 
+// NewRenderController returns a Controller that only works against the given client and uses dtClient instead of
+// connecting to a Dynatrace environment, so the resulting objects can be inspected without touching a cluster.
+func NewRenderController(kubeClient client.Client, dtClient dtclient.Client, operatorNamespace, clusterID string) *Controller {
+	controller := NewDynaKubeController(kubeClient, kubeClient, nil, clusterID)
+	controller.fs = afero.Afero{Fs: afero.NewMemMapFs()}
+	controller.operatorNamespace = operatorNamespace
+	controller.dynatraceClientBuilder = dynatraceclient.NewStaticBuilder(dtClient)
+
+	return controller
+}
+
 func (controller *Controller) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&dynakube.DynaKube{}).
 		Named("dynakube-controller").
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&appsv1.DaemonSet{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&networkingv1.NetworkPolicy{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
 		Complete(controller)
@@ -108,6 +132,7 @@ type Controller struct {
 	dynatraceClientBuilder dynatraceclient.Builder
 	config                 *rest.Config
 	istioClientBuilder     istio.ClientBuilder
+	eventRecorder          record.EventRecorder
 
 	deploymentMetadataReconcilerBuilder deploymentmetadata.ReconcilerBuilder
 	activeGateReconcilerBuilder         activegate.ReconcilerBuilder
@@ -120,6 +145,7 @@ type Controller struct {
 	logMonitoringReconcilerBuilder      logmonitoring.ReconcilerBuilder
 	proxyReconcilerBuilder              proxy.ReconcilerBuilder
 	kspmReconcilerBuilder               kspm.ReconcilerBuilder
+	networkPolicyReconcilerBuilder      networkpolicy.ReconcilerBuilder
 
 	tokens            token.Tokens
 	operatorNamespace string
@@ -171,6 +197,8 @@ func (controller *Controller) getDynakubeOrCleanup(ctx context.Context, dkName,
 			return nil, errors.WithMessagef(err, "failed to list namespaces for dynakube %s", dkName)
 		}
 
+		deleteMetrics(dkName)
+
 		return nil, controller.createDynakubeMapper(ctx, dk).UnmapFromDynaKube(namespaces)
 	} else if err != nil {
 		return nil, errors.WithStack(err)
@@ -189,6 +217,7 @@ func (controller *Controller) handleError(
 	case dynatraceapi.IsUnreachable(err):
 		log.Info("the Dynatrace API server is unavailable or request limit reached! trying again in one minute",
 			"errorCode", dynatraceapi.StatusCode(err), "errorMessage", dynatraceapi.Message(err))
+		recordApiUnreachable(dk)
 		// should we set the phase to error ?
 		return reconcile.Result{RequeueAfter: fastUpdateInterval}, nil
 
@@ -201,6 +230,8 @@ func (controller *Controller) handleError(
 		dk.Status.SetPhase(controller.determineDynaKubePhase(dk))
 	}
 
+	recordPhase(dk)
+
 	if isStatusDifferent, err := hasher.IsDifferent(oldStatus, dk.Status); err != nil {
 		log.Error(err, "failed to generate hash for the status section")
 	} else if isStatusDifferent {
@@ -240,7 +271,9 @@ func (controller *Controller) reconcileDynaKube(ctx context.Context, dk *dynakub
 	if istioClient != nil {
 		istioReconciler := controller.istioReconcilerBuilder(istioClient)
 
-		err := istioReconciler.ReconcileAPIUrl(ctx, dk)
+		err := observeComponent(dk, istioComponent, func() error {
+			return istioReconciler.ReconcileAPIUrl(ctx, dk)
+		})
 		if err != nil {
 			return errors.WithMessage(err, "failed to reconcile istio objects for API url")
 		}
@@ -262,12 +295,26 @@ func (controller *Controller) reconcileDynaKube(ctx context.Context, dk *dynakub
 
 	proxyReconciler := controller.proxyReconcilerBuilder(controller.client, controller.apiReader, dk)
 
-	err = proxyReconciler.Reconcile(ctx)
+	err = observeComponent(dk, proxyComponent, func() error {
+		return proxyReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		return err
 	}
 
-	return controller.reconcileComponents(ctx, dynatraceClient, istioClient, dk)
+	return controller.reconcileComponentsWithDrift(ctx, dynatraceClient, istioClient, dk)
+}
+
+// reconcileComponentsWithDrift collects the drift of all components and sets the Drift condition once at the end, so unchanged
+// drift doesn't change the status.
+func (controller *Controller) reconcileComponentsWithDrift(ctx context.Context, dynatraceClient dtclient.Client, istioClient *istio.Client, dk *dynakube.DynaKube) error {
+	driftCollector := drift.Collect(dk)
+
+	err := controller.reconcileComponents(ctx, dynatraceClient, istioClient, dk)
+
+	driftCollector.Apply(controller.eventRecorder, dk)
+
+	return err
 }
 
 func (controller *Controller) setupIstioClient(dk *dynakube.DynaKube) (*istio.Client, error) {
@@ -320,7 +367,9 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	log.Info("start reconciling ActiveGate")
 
-	err := controller.reconcileActiveGate(ctx, dk, dynatraceClient, istioClient)
+	err := observeComponent(dk, activeGateComponent, func() error {
+		return controller.reconcileActiveGate(ctx, dk, dynatraceClient, istioClient)
+	})
 	if err != nil {
 		log.Info("could not reconcile ActiveGate")
 
@@ -329,7 +378,9 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	extensionReconciler := controller.extensionReconcilerBuilder(controller.client, controller.apiReader, dk)
 
-	err = extensionReconciler.Reconcile(ctx)
+	err = observeComponent(dk, extensionComponent, func() error {
+		return extensionReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		log.Info("could not reconcile Extensions")
 
@@ -340,7 +391,9 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	otelcReconciler := controller.otelcReconcilerBuilder(controller.client, controller.apiReader, dk)
 
-	err = otelcReconciler.Reconcile(ctx)
+	err = observeComponent(dk, otelcComponent, func() error {
+		return otelcReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		log.Info("could not reconcile otelc")
 
@@ -351,7 +404,9 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	logMonitoringReconciler := controller.logMonitoringReconcilerBuilder(controller.client, controller.apiReader, dynatraceClient, dk)
 
-	err = logMonitoringReconciler.Reconcile(ctx)
+	err = observeComponent(dk, logMonitoringComponent, func() error {
+		return logMonitoringReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		if errors.Is(err, oaconnectioninfo.NoOneAgentCommunicationHostsError) || errors.Is(err, logmondaemonset.KubernetesSettingsNotAvailableError) {
 			controller.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
@@ -366,12 +421,15 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	log.Info("start reconciling app injection")
 
-	err = controller.injectionReconcilerBuilder(controller.client,
+	injectionReconciler := controller.injectionReconcilerBuilder(controller.client,
 		controller.apiReader,
 		dynatraceClient,
 		istioClient,
-		dk).
-		Reconcile(ctx)
+		dk)
+
+	err = observeComponent(dk, injectionComponent, func() error {
+		return injectionReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		if errors.Is(err, oaconnectioninfo.NoOneAgentCommunicationHostsError) {
 			// missing communication hosts is not an error per se, just make sure next the reconciliation is happening ASAP
@@ -388,15 +446,18 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	log.Info("start reconciling OneAgent")
 
-	err = controller.oneAgentReconcilerBuilder(
+	oneAgentReconciler := controller.oneAgentReconcilerBuilder(
 		controller.client,
 		controller.apiReader,
 		dynatraceClient,
 		dk,
 		controller.tokens,
 		controller.clusterID,
-	).
-		Reconcile(ctx)
+	)
+
+	err = observeComponent(dk, oneAgentComponent, func() error {
+		return oneAgentReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		if errors.Is(err, oaconnectioninfo.NoOneAgentCommunicationHostsError) {
 			// missing communication hosts is not an error per se, just make sure next the reconciliation is happening ASAP
@@ -413,13 +474,26 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 
 	kspmReconciler := controller.kspmReconcilerBuilder(controller.client, controller.apiReader, dk)
 
-	err = kspmReconciler.Reconcile(ctx)
+	err = observeComponent(dk, kspmComponent, func() error {
+		return kspmReconciler.Reconcile(ctx)
+	})
 	if err != nil {
 		log.Info("could not reconcile kspm")
 
 		componentErrors = append(componentErrors, err)
 	}
 
+	networkPolicyReconciler := controller.networkPolicyReconcilerBuilder(controller.client, controller.apiReader, dk)
+
+	err = observeComponent(dk, networkPolicyComponent, func() error {
+		return networkPolicyReconciler.Reconcile(ctx)
+	})
+	if err != nil {
+		log.Info("could not reconcile network policies")
+
+		componentErrors = append(componentErrors, err)
+	}
+
 	return goerrors.Join(componentErrors...)
 }
 
@@ -21,6 +21,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/injection"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/kspm"
 	logmon "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/logmonitoring"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
 	oneagentcontroller "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/proxy"
@@ -440,6 +441,7 @@ func createFakeClientAndReconciler(t *testing.T, mockClient dtclient.Client, dk
 		extensionReconcilerBuilder:          extension.NewReconciler,
 		otelcReconcilerBuilder:              otelc.NewReconciler,
 		kspmReconcilerBuilder:               kspm.NewReconciler,
+		networkPolicyReconcilerBuilder:      networkpolicy.NewReconciler,
 		clusterID:                           testUID,
 	}
 
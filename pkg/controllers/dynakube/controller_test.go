@@ -18,14 +18,17 @@ import (
 	ag "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
 	oaconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/drift"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/extension"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/injection"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/kspm"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/logmonitoring"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/networkpolicy"
 	oneagentcontroller "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/oneagent"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	controllermock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/controllers"
@@ -37,6 +40,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	fakeistio "istio.io/client-go/pkg/clientset/versioned/fake"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,6 +48,7 @@ import (
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -358,6 +363,9 @@ func TestReconcileComponents(t *testing.T) {
 		mockKSPMReconciler := controllermock.NewReconciler(t)
 		mockKSPMReconciler.On("Reconcile", mock.Anything).Return(errors.New("BOOM"))
 
+		mockNetworkPolicyReconciler := controllermock.NewReconciler(t)
+		mockNetworkPolicyReconciler.On("Reconcile", mock.Anything).Return(errors.New("BOOM"))
+
 		controller := &Controller{
 			client:    fakeClient,
 			apiReader: fakeClient,
@@ -370,6 +378,7 @@ func TestReconcileComponents(t *testing.T) {
 			extensionReconcilerBuilder:     createExtensionReconcilerBuilder(mockExtensionReconciler),
 			otelcReconcilerBuilder:         createOtelcReconcilerBuilder(mockOtelcReconciler),
 			kspmReconcilerBuilder:          createKSPMReconcilerBuilder(mockKSPMReconciler),
+			networkPolicyReconcilerBuilder: createNetworkPolicyReconcilerBuilder(mockNetworkPolicyReconciler),
 		}
 		mockedDtc := dtclientmock.NewClient(t)
 
@@ -377,7 +386,7 @@ func TestReconcileComponents(t *testing.T) {
 
 		require.Error(t, err)
 		// goerrors.Join concats errors with \n
-		assert.Len(t, strings.Split(err.Error(), "\n"), 7) // ActiveGate, Extension, OtelC, OneAgent LogMonitoring, and Injection reconcilers
+		assert.Len(t, strings.Split(err.Error(), "\n"), 8) // ActiveGate, Extension, OtelC, OneAgent LogMonitoring, Injection, KSPM and NetworkPolicy reconcilers
 	})
 
 	t.Run("exit early in case of no oneagent conncection info", func(t *testing.T) {
@@ -415,6 +424,80 @@ func TestReconcileComponents(t *testing.T) {
 	})
 }
 
+func TestReconcileComponentsWithDrift(t *testing.T) {
+	ctx := context.Background()
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "this-is-a-name",
+			Namespace: "dynatrace",
+		},
+		Spec: dynakube.DynaKubeSpec{APIURL: "this-is-an-api-url"},
+	}
+
+	statusUpdates := 0
+	fakeClient := interceptor.NewClient(fake.NewClientWithIndex(dk).(client.WithWatch), interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, clt client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			statusUpdates++
+
+			return clt.SubResource(subResourceName).Update(ctx, obj, opts...)
+		},
+	})
+
+	mockOneAgentReconciler := controllermock.NewReconciler(t)
+	mockOneAgentReconciler.On("Reconcile", mock.Anything).Run(func(mock.Arguments) {
+		drift.NewHandler(dk)(&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "oneagent"}}, []string{"spec.template.spec.nodeName"})
+	}).Return(nil)
+
+	newSucceedingReconciler := func() *controllermock.Reconciler {
+		reconciler := controllermock.NewReconciler(t)
+		reconciler.On("Reconcile", mock.Anything).Return(nil)
+
+		return reconciler
+	}
+
+	mockInjectionReconciler := injectionmock.NewReconciler(t)
+	mockInjectionReconciler.On("Reconcile", mock.Anything).Return(nil)
+
+	controller := &Controller{
+		client:       fakeClient,
+		apiReader:    fakeClient,
+		fs:           afero.Afero{Fs: afero.NewMemMapFs()},
+		requeueAfter: defaultUpdateInterval,
+
+		activeGateReconcilerBuilder:    createActivegateReconcilerBuilder(newSucceedingReconciler()),
+		injectionReconcilerBuilder:     createInjectionReconcilerBuilder(mockInjectionReconciler),
+		oneAgentReconcilerBuilder:      createOneAgentReconcilerBuilder(mockOneAgentReconciler),
+		logMonitoringReconcilerBuilder: createLogMonitoringReconcilerBuilder(newSucceedingReconciler()),
+		extensionReconcilerBuilder:     createExtensionReconcilerBuilder(newSucceedingReconciler()),
+		otelcReconcilerBuilder:         createOtelcReconcilerBuilder(newSucceedingReconciler()),
+		kspmReconcilerBuilder:          createKSPMReconcilerBuilder(newSucceedingReconciler()),
+		networkPolicyReconcilerBuilder: createNetworkPolicyReconcilerBuilder(newSucceedingReconciler()),
+	}
+
+	reconcileOnce := func() {
+		oldStatus := *dk.Status.DeepCopy()
+
+		err := controller.reconcileComponentsWithDrift(ctx, dtclientmock.NewClient(t), nil, dk)
+		require.NoError(t, err)
+
+		_, err = controller.handleError(ctx, dk, err, oldStatus)
+		require.NoError(t, err)
+	}
+
+	reconcileOnce()
+	require.Equal(t, 1, statusUpdates)
+
+	condition := meta.FindStatusCondition(dk.Status.Conditions, conditions.DriftConditionType)
+	require.NotNil(t, condition)
+	assert.Equal(t, "DaemonSet oneagent: spec.template.spec.nodeName", condition.Message)
+
+	// the drift was reported a while ago, a new transition time would change the status
+	condition.LastTransitionTime = metav1.NewTime(condition.LastTransitionTime.Add(-time.Hour))
+
+	reconcileOnce()
+	assert.Equal(t, 1, statusUpdates, "unchanged drift must not update the status")
+}
+
 func createActivegateReconcilerBuilder(reconciler controllers.Reconciler) ag.ReconcilerBuilder {
 	return func(_ client.Client, _ client.Reader, _ *dynakube.DynaKube, _ dtclient.Client, _ *istio.Client, _ token.Tokens) controllers.Reconciler {
 		return reconciler
@@ -457,6 +540,12 @@ func createKSPMReconcilerBuilder(reconciler controllers.Reconciler) kspm.Reconci
 	}
 }
 
+func createNetworkPolicyReconcilerBuilder(reconciler controllers.Reconciler) networkpolicy.ReconcilerBuilder {
+	return func(_ client.Client, _ client.Reader, _ *dynakube.DynaKube) controllers.Reconciler {
+		return reconciler
+	}
+}
+
 type errorClient struct {
 	client.Client
 }
@@ -0,0 +1,114 @@
+package drift
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Collector gathers the drift detected during a reconciliation of a DynaKube, so the Drift condition is only set once at its end.
+type Collector struct {
+	mutex  sync.Mutex
+	drifts []conditions.Drift
+}
+
+var (
+	collectorsMutex sync.Mutex
+	collectors      = map[*dynakube.DynaKube]*Collector{}
+)
+
+// Collect starts collecting the drift reported by the handlers of the given DynaKube, until Apply is called.
+func Collect(dk *dynakube.DynaKube) *Collector {
+	collector := &Collector{}
+
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+
+	collectors[dk] = collector
+
+	return collector
+}
+
+func collectorOf(dk *dynakube.DynaKube) *Collector {
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+
+	return collectors[dk]
+}
+
+// NewHandler returns the drift handler for the queries of objects owned by the DynaKube.
+// It reports the drift to the Collector of the DynaKube and decides, based on the drift-policy feature flag, if it gets reverted.
+func NewHandler(dk *dynakube.DynaKube) func(object client.Object, fields []string) bool {
+	return func(object client.Object, fields []string) bool {
+		revert := dk.FeatureRevertDrift()
+
+		if collector := collectorOf(dk); collector != nil {
+			collector.add(conditions.Drift{Kind: kindOf(object), Name: object.GetName(), Fields: fields, Reverted: revert})
+		}
+
+		return revert
+	}
+}
+
+func (collector *Collector) add(drift conditions.Drift) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	collector.drifts = append(collector.drifts, drift)
+}
+
+// Apply stops the collection, sets the Drift condition of the DynaKube to the collected drift and emits a Warning event if it changed.
+func (collector *Collector) Apply(recorder record.EventRecorder, dk *dynakube.DynaKube) {
+	collectorsMutex.Lock()
+	if collectors[dk] == collector {
+		delete(collectors, dk)
+	}
+	collectorsMutex.Unlock()
+
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	var previous *metav1.Condition
+	if existing := meta.FindStatusCondition(*dk.Conditions(), conditions.DriftConditionType); existing != nil {
+		previous = existing.DeepCopy()
+	}
+
+	conditions.SetDrift(dk.Conditions(), collector.drifts)
+
+	recordEvent(recorder, dk, previous)
+}
+
+// recordEvent emits a Warning event for newly detected drift, drift that is still present with the same fields is not
+// reported again.
+func recordEvent(recorder record.EventRecorder, dk *dynakube.DynaKube, previous *metav1.Condition) {
+	current := meta.FindStatusCondition(*dk.Conditions(), conditions.DriftConditionType)
+	if recorder == nil || current == nil {
+		return
+	}
+
+	if previous != nil && previous.Reason == current.Reason && previous.Message == current.Message {
+		return
+	}
+
+	recorder.Event(dk, corev1.EventTypeWarning, current.Reason, current.Message)
+}
+
+func kindOf(object client.Object) string {
+	if kind := object.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+
+	objectType := reflect.TypeOf(object)
+	if objectType.Kind() == reflect.Ptr {
+		objectType = objectType.Elem()
+	}
+
+	return objectType.Name()
+}
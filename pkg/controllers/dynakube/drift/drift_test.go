@@ -0,0 +1,111 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func createDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "ds"}}
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Run("report only by default", func(t *testing.T) {
+		dk := &dynakube.DynaKube{}
+		collector := Collect(dk)
+
+		reverted := NewHandler(dk)(createDaemonSet(), []string{"spec.template.spec.nodeName"})
+
+		assert.False(t, reverted)
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), conditions.DriftConditionType))
+
+		collector.Apply(nil, dk)
+		assert.Nil(t, collectorOf(dk))
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), conditions.DriftConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.DriftDetectedReason, condition.Reason)
+		assert.Equal(t, "DaemonSet ds: spec.template.spec.nodeName", condition.Message)
+	})
+	t.Run("revert if configured", func(t *testing.T) {
+		dk := &dynakube.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{dynakube.AnnotationFeatureDriftPolicy: "revert"},
+			},
+		}
+		collector := Collect(dk)
+
+		reverted := NewHandler(dk)(createDaemonSet(), []string{"spec.template.spec.nodeName"})
+
+		assert.True(t, reverted)
+
+		collector.Apply(nil, dk)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), conditions.DriftConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, conditions.DriftRevertedReason, condition.Reason)
+	})
+	t.Run("without collector the drift is only decided", func(t *testing.T) {
+		dk := &dynakube.DynaKube{}
+
+		reverted := NewHandler(dk)(createDaemonSet(), []string{"field"})
+
+		assert.False(t, reverted)
+		assert.Empty(t, *dk.Conditions())
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("no event without drift", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(1)
+		dk := &dynakube.DynaKube{}
+
+		Collect(dk).Apply(recorder, dk)
+
+		assert.Empty(t, recorder.Events)
+	})
+	t.Run("event for new drift", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(1)
+		dk := &dynakube.DynaKube{}
+		collector := Collect(dk)
+		NewHandler(dk)(createDaemonSet(), []string{"field"})
+
+		collector.Apply(recorder, dk)
+
+		require.Len(t, recorder.Events, 1)
+		assert.Equal(t, "Warning DriftDetected DaemonSet ds: field", <-recorder.Events)
+	})
+	t.Run("no event and same condition for unchanged drift", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(1)
+		dk := &dynakube.DynaKube{}
+		collector := Collect(dk)
+		NewHandler(dk)(createDaemonSet(), []string{"field"})
+		collector.Apply(nil, dk)
+		previous := meta.FindStatusCondition(*dk.Conditions(), conditions.DriftConditionType).DeepCopy()
+
+		collector = Collect(dk)
+		NewHandler(dk)(createDaemonSet(), []string{"field"})
+		collector.Apply(recorder, dk)
+
+		assert.Empty(t, recorder.Events)
+		assert.Equal(t, *previous, *meta.FindStatusCondition(*dk.Conditions(), conditions.DriftConditionType))
+	})
+	t.Run("resolved drift removes the condition", func(t *testing.T) {
+		dk := &dynakube.DynaKube{}
+		collector := Collect(dk)
+		NewHandler(dk)(createDaemonSet(), []string{"field"})
+		collector.Apply(nil, dk)
+
+		Collect(dk).Apply(nil, dk)
+
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), conditions.DriftConditionType))
+	})
+}
@@ -77,6 +77,7 @@ func (dynatraceClientBuilder builder) Build() (dtclient.Client, error) {
 	opts.appendCertCheck(dynatraceClientBuilder.dk.Spec.SkipCertCheck)
 	opts.appendNetworkZone(dynatraceClientBuilder.dk.Spec.NetworkZone)
 	opts.appendHostGroup(dynatraceClientBuilder.dk.OneAgent().GetHostGroup())
+	opts.appendDynakubeName(dynatraceClientBuilder.dk.Name)
 
 	err := opts.appendProxySettings(apiReader, &dynatraceClientBuilder.dk)
 	if err != nil {
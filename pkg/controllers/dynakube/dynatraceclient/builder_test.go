@@ -1,6 +1,7 @@
 package dynatraceclient
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
@@ -8,6 +9,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -131,3 +133,20 @@ func TestBuildDynatraceClient(t *testing.T) {
 		assert.Nil(t, dtc)
 	})
 }
+
+func TestStaticBuilder(t *testing.T) {
+	dtClient := dtclientmock.NewClient(t)
+
+	builder := NewStaticBuilder(dtClient).
+		SetContext(context.Background()).
+		SetDynakube(dynakube.DynaKube{}).
+		SetTokens(token.Tokens{})
+
+	built, err := builder.Build()
+	require.NoError(t, err)
+	assert.Same(t, dtClient, built)
+
+	built, err = builder.BuildWithTokenVerification(&dynakube.DynaKubeStatus{})
+	require.NoError(t, err)
+	assert.Same(t, dtClient, built)
+}
@@ -34,6 +34,12 @@ func (opts *options) appendHostGroup(hostGroup string) {
 	}
 }
 
+func (opts *options) appendDynakubeName(dynakubeName string) {
+	if dynakubeName != "" {
+		opts.Opts = append(opts.Opts, dtclient.DynakubeName(dynakubeName))
+	}
+}
+
 func (opts *options) appendCertCheck(skipCertCheck bool) {
 	opts.Opts = append(opts.Opts, dtclient.SkipCertificateValidation(skipCertCheck))
 }
@@ -0,0 +1,40 @@
+package dynatraceclient
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+)
+
+type staticBuilder struct {
+	dtClient dtclient.Client
+}
+
+// NewStaticBuilder returns a Builder that always hands out the given client, without reading any secrets or verifying tokens.
+func NewStaticBuilder(dtClient dtclient.Client) Builder {
+	return staticBuilder{
+		dtClient: dtClient,
+	}
+}
+
+func (staticBuilder staticBuilder) SetContext(_ context.Context) Builder {
+	return staticBuilder
+}
+
+func (staticBuilder staticBuilder) SetDynakube(_ dynakube.DynaKube) Builder {
+	return staticBuilder
+}
+
+func (staticBuilder staticBuilder) SetTokens(_ token.Tokens) Builder {
+	return staticBuilder
+}
+
+func (staticBuilder staticBuilder) Build() (dtclient.Client, error) {
+	return staticBuilder.dtClient, nil
+}
+
+func (staticBuilder staticBuilder) BuildWithTokenVerification(_ *dynakube.DynaKubeStatus) (dtclient.Client, error) {
+	return staticBuilder.dtClient, nil
+}
@@ -1,3 +1,6 @@
 package eec
 
-const extensionsControllerStatefulSetConditionType string = "ExtensionsControllerStatefulSet"
+const (
+	extensionsControllerStatefulSetConditionType         string = "ExtensionsControllerStatefulSet"
+	extensionsControllerPodDisruptionBudgetConditionType string = "ExtensionsControllerPodDisruptionBudget"
+)
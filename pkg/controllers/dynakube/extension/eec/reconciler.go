@@ -1,8 +1,10 @@
 package eec
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/poddisruptionbudget"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/statefulset"
 	"github.com/pkg/errors"
@@ -33,6 +35,10 @@ func NewReconciler(clt client.Client, apiReader client.Reader, dk *dynakube.Dyna
 
 func (r *reconciler) Reconcile(ctx context.Context) error {
 	if !r.dk.IsExtensionsEnabled() {
+		if err := r.podDisruptionBudgetReconciler(nil).Reconcile(ctx); err != nil {
+			return err
+		}
+
 		if meta.FindStatusCondition(*r.dk.Conditions(), extensionsControllerStatefulSetConditionType) == nil {
 			return nil
 		}
@@ -68,5 +74,18 @@ func (r *reconciler) Reconcile(ctx context.Context) error {
 		return errors.New("kubeSystemUUID unknown")
 	}
 
-	return r.createOrUpdateStatefulset(ctx)
+	err := r.createOrUpdateStatefulset(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.podDisruptionBudgetReconciler(r.dk.Spec.Templates.ExtensionExecutionController.PodDisruptionBudget).Reconcile(ctx)
+}
+
+func (r *reconciler) podDisruptionBudgetReconciler(spec *pdb.Spec) *poddisruptionbudget.Reconciler {
+	return poddisruptionbudget.NewReconciler(r.client, r.apiReader, r.dk, spec,
+		r.dk.ExtensionsExecutionControllerStatefulsetName(),
+		extensionsControllerPodDisruptionBudgetConditionType,
+		buildAppLabels(r.dk.Name).BuildMatchLabels(),
+	)
 }
@@ -5,6 +5,7 @@ import (
 	"maps"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/drift"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/daemonset"
@@ -60,7 +61,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 		return err
 	}
 
-	updated, err := daemonset.Query(r.client, r.apiReader, log).WithOwner(r.dk).CreateOrUpdate(ctx, ds)
+	updated, err := daemonset.Query(r.client, r.apiReader, log).WithOwner(r.dk).WithDriftHandler(drift.NewHandler(r.dk)).CreateOrUpdate(ctx, ds)
 	if err != nil {
 		conditions.SetKubeApiError(r.dk.Conditions(), conditionType, err)
 
@@ -0,0 +1,106 @@
+package dynakube
+
+import (
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "dynatrace"
+	metricsSubsystem = "dynakube"
+
+	dynakubeLabel  = "dynakube"
+	componentLabel = "component"
+	phaseLabel     = "phase"
+
+	istioComponent         = "istio"
+	proxyComponent         = "proxy"
+	activeGateComponent    = "activegate"
+	extensionComponent     = "extension"
+	otelcComponent         = "otelc"
+	logMonitoringComponent = "logmonitoring"
+	injectionComponent     = "injection"
+	oneAgentComponent      = "oneagent"
+	kspmComponent          = "kspm"
+	networkPolicyComponent = "networkpolicy"
+)
+
+var (
+	reconcileDurationMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of the reconciliation of a DynaKube component in seconds",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{dynakubeLabel, componentLabel})
+
+	reconcileErrorsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of failed reconciliations of a DynaKube component",
+	}, []string{dynakubeLabel, componentLabel})
+
+	phaseMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "phase",
+		Help:      "Current phase of a DynaKube, the active phase is set to 1, all others to 0",
+	}, []string{dynakubeLabel, phaseLabel})
+
+	apiUnreachableMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "api_unreachable_total",
+		Help:      "Number of reconciliations that were requeued early because the Dynatrace API was unreachable",
+	}, []string{dynakubeLabel})
+
+	knownPhases = []status.DeploymentPhase{status.Running, status.Deploying, status.Error}
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileDurationMetric, reconcileErrorsMetric, phaseMetric, apiUnreachableMetric)
+}
+
+// observeComponent runs the reconcile func of a DynaKube component and records its duration and whether it failed.
+func observeComponent(dk *dynakube.DynaKube, component string, reconcile func() error) error {
+	start := time.Now()
+	err := reconcile()
+
+	reconcileDurationMetric.WithLabelValues(dk.Name, component).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		reconcileErrorsMetric.WithLabelValues(dk.Name, component).Inc()
+	}
+
+	return err
+}
+
+func recordPhase(dk *dynakube.DynaKube) {
+	for _, phase := range knownPhases {
+		value := 0.0
+		if dk.Status.Phase == phase {
+			value = 1
+		}
+
+		phaseMetric.WithLabelValues(dk.Name, string(phase)).Set(value)
+	}
+}
+
+func recordApiUnreachable(dk *dynakube.DynaKube) {
+	apiUnreachableMetric.WithLabelValues(dk.Name).Inc()
+}
+
+// deleteMetrics removes all series of a DynaKube, so removed DynaKubes do not keep reporting stale values.
+func deleteMetrics(dkName string) {
+	labels := prometheus.Labels{dynakubeLabel: dkName}
+
+	reconcileDurationMetric.DeletePartialMatch(labels)
+	reconcileErrorsMetric.DeletePartialMatch(labels)
+	phaseMetric.DeletePartialMatch(labels)
+	apiUnreachableMetric.DeletePartialMatch(labels)
+}
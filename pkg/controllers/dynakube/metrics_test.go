@@ -0,0 +1,73 @@
+package dynakube
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObserveComponent(t *testing.T) {
+	t.Run("records duration without error", func(t *testing.T) {
+		dk := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "observe-success"}}
+		t.Cleanup(func() { deleteMetrics(dk.Name) })
+
+		err := observeComponent(dk, activeGateComponent, func() error { return nil })
+
+		require.NoError(t, err)
+		histogram := &dto.Metric{}
+		require.NoError(t, reconcileDurationMetric.WithLabelValues(dk.Name, activeGateComponent).(prometheus.Histogram).Write(histogram))
+		assert.Equal(t, uint64(1), histogram.GetHistogram().GetSampleCount())
+		assert.InDelta(t, 0, testutil.ToFloat64(reconcileErrorsMetric.WithLabelValues(dk.Name, activeGateComponent)), 0)
+	})
+	t.Run("counts errors per component", func(t *testing.T) {
+		dk := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "observe-error"}}
+		t.Cleanup(func() { deleteMetrics(dk.Name) })
+
+		expectedErr := errors.New("BOOM")
+
+		err := observeComponent(dk, oneAgentComponent, func() error { return expectedErr })
+		require.ErrorIs(t, err, expectedErr)
+
+		_ = observeComponent(dk, oneAgentComponent, func() error { return expectedErr })
+
+		assert.InDelta(t, 2, testutil.ToFloat64(reconcileErrorsMetric.WithLabelValues(dk.Name, oneAgentComponent)), 0)
+		assert.InDelta(t, 0, testutil.ToFloat64(reconcileErrorsMetric.WithLabelValues(dk.Name, kspmComponent)), 0)
+	})
+}
+
+func TestRecordPhase(t *testing.T) {
+	dk := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "record-phase"}}
+	t.Cleanup(func() { deleteMetrics(dk.Name) })
+
+	dk.Status.SetPhase(status.Deploying)
+	recordPhase(dk)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(phaseMetric.WithLabelValues(dk.Name, string(status.Deploying))), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(phaseMetric.WithLabelValues(dk.Name, string(status.Running))), 0)
+
+	dk.Status.SetPhase(status.Running)
+	recordPhase(dk)
+
+	assert.InDelta(t, 0, testutil.ToFloat64(phaseMetric.WithLabelValues(dk.Name, string(status.Deploying))), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(phaseMetric.WithLabelValues(dk.Name, string(status.Running))), 0)
+}
+
+func TestDeleteMetrics(t *testing.T) {
+	dk := &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: "delete-metrics"}}
+
+	recordApiUnreachable(dk)
+	recordPhase(dk)
+	require.Positive(t, testutil.CollectAndCount(apiUnreachableMetric))
+
+	deleteMetrics(dk.Name)
+
+	assert.InDelta(t, 0, testutil.ToFloat64(apiUnreachableMetric.WithLabelValues(dk.Name)), 0)
+}
@@ -0,0 +1,13 @@
+package networkpolicy
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+const (
+	conditionType = "NetworkPolicies"
+)
+
+var (
+	log = logd.Get().WithName("network-policy")
+)
@@ -0,0 +1,221 @@
+package networkpolicy
+
+import (
+	"context"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	agconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/activegate"
+	oaconnectioninfo "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo/oneagent"
+	otelcservice "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/service"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// policy describes the NetworkPolicy of a single component.
+type policy struct {
+	name           string
+	component      string
+	selectorLabels map[string]string
+	ingress        []networkingv1.NetworkPolicyIngressRule
+	egress         []networkingv1.NetworkPolicyEgressRule
+
+	// unrestrictedEgress is set for components that connect to targets not known to the operator
+	unrestrictedEgress bool
+}
+
+func (r *Reconciler) activeGateName() string {
+	return r.dk.Name + "-" + agconsts.MultiActiveGateName
+}
+
+// allPolicyNames returns the names of all policies that can be created for the DynaKube, used for the cleanup.
+func (r *Reconciler) allPolicyNames() []string {
+	return []string{
+		r.activeGateName(),
+		r.dk.ExtensionsExecutionControllerStatefulsetName(),
+		r.dk.ExtensionsCollectorStatefulsetName(),
+		r.dk.LogMonitoring().GetDaemonSetName(),
+		r.dk.KSPM().GetDaemonSetName(),
+	}
+}
+
+// desiredPolicies returns the policies of all components that are currently deployed for the DynaKube.
+func (r *Reconciler) desiredPolicies(ctx context.Context) ([]policy, error) {
+	var policies []policy
+
+	if r.dk.ActiveGate().IsEnabled() {
+		activeGatePolicy, err := r.activeGatePolicy(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, activeGatePolicy)
+	}
+
+	if r.dk.IsExtensionsEnabled() {
+		policies = append(policies, r.extensionsControllerPolicy(), r.extensionsCollectorPolicy())
+	}
+
+	if r.dk.LogMonitoring().IsStandalone() {
+		logMonitoringPolicy, err := r.logMonitoringPolicy(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		policies = append(policies, logMonitoringPolicy)
+	}
+
+	if r.dk.KSPM().IsEnabled() {
+		policies = append(policies, r.kspmPolicy())
+	}
+
+	return policies, nil
+}
+
+func (r *Reconciler) activeGatePolicy(ctx context.Context) (policy, error) {
+	hosts, err := r.apiUrlHosts()
+	if err != nil {
+		return policy{}, err
+	}
+
+	hosts = append(hosts, agconnectioninfo.GetEndpointsAsCommunicationHosts(r.dk)...)
+
+	if r.dk.NeedsActiveGateProxy() {
+		proxyHost, err := r.proxyHost(ctx)
+		if err != nil {
+			return policy{}, err
+		}
+
+		hosts = append(hosts, proxyHost)
+	}
+
+	egress := []networkingv1.NetworkPolicyEgressRule{dnsEgressRule()}
+	if r.dk.ActiveGate().IsKubernetesMonitoringEnabled() {
+		egress = append(egress, kubernetesApiEgressRule())
+	}
+
+	if r.dk.IsExtensionsEnabled() {
+		// the ActiveGate forwards the extension configurations to the extensions controller
+		egress = append(egress, podEgressRule(r.extensionsControllerSelectorLabels(), consts.ExtensionsCollectorTargetPortName))
+	}
+
+	egress = append(egress, hostEgressRules(hosts)...)
+
+	return policy{
+		name:           r.activeGateName(),
+		component:      labels.ActiveGateComponentLabel,
+		selectorLabels: r.activeGateSelectorLabels(),
+		ingress:        ingressFromAnywhere(portsOf(corev1.ProtocolTCP, namedPorts(agconsts.HttpsServicePortName, agconsts.HttpServicePortName)...)),
+		egress:         egress,
+	}, nil
+}
+
+func (r *Reconciler) extensionsControllerPolicy() policy {
+	return policy{
+		name:           r.dk.ExtensionsExecutionControllerStatefulsetName(),
+		component:      labels.ExtensionComponentLabel,
+		selectorLabels: r.extensionsControllerSelectorLabels(),
+		ingress:        ingressFromAnywhere(portsOf(corev1.ProtocolTCP, namedPorts(consts.ExtensionsCollectorTargetPortName)...)),
+		egress: []networkingv1.NetworkPolicyEgressRule{
+			dnsEgressRule(),
+			podEgressRule(r.activeGateSelectorLabels(), agconsts.HttpsServicePortName),
+		},
+	}
+}
+
+// extensionsCollectorPolicy only restricts ingress traffic, as the collector scrapes the endpoints configured by the extensions.
+func (r *Reconciler) extensionsCollectorPolicy() policy {
+	var ports []networkingv1.NetworkPolicyPort
+
+	if r.dk.TelemetryService().IsEnabled() {
+		for _, servicePort := range otelcservice.BuildServicePortList(r.dk.TelemetryService().GetProtocols()) {
+			ports = append(ports, portsOf(servicePort.Protocol, servicePort.TargetPort)...)
+		}
+	}
+
+	return policy{
+		name:               r.dk.ExtensionsCollectorStatefulsetName(),
+		component:          labels.CollectorComponentLabel,
+		selectorLabels:     labels.NewAppLabels(labels.CollectorComponentLabel, r.dk.Name, labels.CollectorComponentLabel, "").BuildMatchLabels(),
+		ingress:            ingressFromAnywhere(ports),
+		unrestrictedEgress: true,
+	}
+}
+
+func (r *Reconciler) logMonitoringPolicy(ctx context.Context) (policy, error) {
+	hosts, err := r.apiUrlHosts()
+	if err != nil {
+		return policy{}, err
+	}
+
+	hosts = append(hosts, oaconnectioninfo.GetCommunicationHosts(r.dk)...)
+
+	if r.dk.NeedsOneAgentProxy() {
+		proxyHost, err := r.proxyHost(ctx)
+		if err != nil {
+			return policy{}, err
+		}
+
+		hosts = append(hosts, proxyHost)
+	}
+
+	egress := []networkingv1.NetworkPolicyEgressRule{dnsEgressRule()}
+	if r.dk.ActiveGate().IsEnabled() {
+		egress = append(egress, podEgressRule(r.activeGateSelectorLabels(), agconsts.HttpsServicePortName))
+	}
+
+	egress = append(egress, hostEgressRules(hosts)...)
+
+	return policy{
+		name:           r.dk.LogMonitoring().GetDaemonSetName(),
+		component:      labels.LogMonitoringComponentLabel,
+		selectorLabels: labels.NewCoreLabels(r.dk.Name, labels.LogMonitoringComponentLabel).BuildMatchLabels(),
+		egress:         egress,
+	}, nil
+}
+
+func (r *Reconciler) kspmPolicy() policy {
+	return policy{
+		name:           r.dk.KSPM().GetDaemonSetName(),
+		component:      labels.KSPMComponentLabel,
+		selectorLabels: labels.NewCoreLabels(r.dk.Name, labels.KSPMComponentLabel).BuildMatchLabels(),
+		egress: []networkingv1.NetworkPolicyEgressRule{
+			dnsEgressRule(),
+			podEgressRule(r.activeGateSelectorLabels(), agconsts.HttpsServicePortName),
+		},
+	}
+}
+
+func (r *Reconciler) activeGateSelectorLabels() map[string]string {
+	return labels.NewAppLabels(labels.ActiveGateComponentLabel, r.dk.Name, "", "").BuildMatchLabels()
+}
+
+func (r *Reconciler) extensionsControllerSelectorLabels() map[string]string {
+	return labels.NewAppLabels(labels.ExtensionComponentLabel, r.dk.Name, labels.ExtensionComponentLabel, "").BuildMatchLabels()
+}
+
+func (r *Reconciler) apiUrlHosts() ([]dtclient.CommunicationHost, error) {
+	apiHost, err := dtclient.ParseEndpoint(r.dk.ApiUrl())
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse api url")
+	}
+
+	return []dtclient.CommunicationHost{apiHost}, nil
+}
+
+func (r *Reconciler) proxyHost(ctx context.Context) (dtclient.CommunicationHost, error) {
+	proxyUrl, err := r.dk.Proxy(ctx, r.apiReader)
+	if err != nil {
+		return dtclient.CommunicationHost{}, err
+	}
+
+	proxyHost, err := dtclient.ParseEndpoint(proxyUrl)
+	if err != nil {
+		return dtclient.CommunicationHost{}, errors.WithMessage(err, "failed to parse proxy url")
+	}
+
+	return proxyHost, nil
+}
@@ -0,0 +1,147 @@
+package networkpolicy
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	k8snetworkpolicy "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/networkpolicy"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ controllers.Reconciler = &Reconciler{}
+
+// Reconciler manages the NetworkPolicies of the components deployed for a DynaKube, if they are enabled via `.spec.networkPolicies`.
+// The policy of the webhook is shared by all DynaKubes, see reconcileWebhookPolicy.
+type Reconciler struct {
+	client    client.Client
+	apiReader client.Reader
+	dk        *dynakube.DynaKube
+}
+
+type ReconcilerBuilder func(client client.Client, apiReader client.Reader, dk *dynakube.DynaKube) controllers.Reconciler
+
+func NewReconciler(client client.Client, apiReader client.Reader, dk *dynakube.DynaKube) controllers.Reconciler {
+	return &Reconciler{
+		client:    client,
+		apiReader: apiReader,
+		dk:        dk,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	if !r.dk.IsNetworkPoliciesEnabled() {
+		err := r.removeOnce(ctx)
+		if err != nil {
+			return err
+		}
+
+		return r.reconcileWebhookPolicy(ctx)
+	}
+
+	return r.createOrUpdate(ctx)
+}
+
+func (r *Reconciler) removeOnce(ctx context.Context) error {
+	if meta.FindStatusCondition(*r.dk.Conditions(), conditionType) == nil {
+		return nil
+	}
+	defer meta.RemoveStatusCondition(r.dk.Conditions(), conditionType)
+
+	r.deletePolicies(ctx, r.allPolicyNames())
+
+	return nil
+}
+
+func (r *Reconciler) createOrUpdate(ctx context.Context) error {
+	policies, err := r.desiredPolicies(ctx)
+	if err != nil {
+		conditions.SetNetworkPoliciesGenFailed(r.dk.Conditions(), conditionType, err)
+
+		return err
+	}
+
+	names := make([]string, 0, len(policies))
+	query := k8snetworkpolicy.Query(r.client, r.apiReader, log)
+
+	for _, policy := range policies {
+		desired, err := r.build(policy)
+		if err != nil {
+			conditions.SetNetworkPoliciesGenFailed(r.dk.Conditions(), conditionType, err)
+
+			return err
+		}
+
+		_, err = query.CreateOrUpdate(ctx, desired)
+		if err != nil {
+			log.Info("failed to create/update network policy", "name", policy.name)
+			conditions.SetKubeApiError(r.dk.Conditions(), conditionType, err)
+
+			return err
+		}
+
+		names = append(names, policy.name)
+	}
+
+	var obsolete []string
+
+	for _, name := range r.allPolicyNames() {
+		if !slices.Contains(names, name) {
+			obsolete = append(obsolete, name)
+		}
+	}
+
+	r.deletePolicies(ctx, obsolete)
+
+	err = r.reconcileWebhookPolicy(ctx)
+	if err != nil {
+		conditions.SetKubeApiError(r.dk.Conditions(), conditionType, err)
+
+		return err
+	}
+
+	conditions.SetNetworkPoliciesCreated(r.dk.Conditions(), conditionType, names)
+
+	return nil
+}
+
+func (r *Reconciler) build(policy policy) (*networkingv1.NetworkPolicy, error) {
+	coreLabels := labels.NewCoreLabels(r.dk.Name, policy.component)
+
+	if policy.unrestrictedEgress {
+		return k8snetworkpolicy.Build(r.dk, policy.name, policy.selectorLabels,
+			k8snetworkpolicy.SetLabels(coreLabels.BuildLabels()),
+			k8snetworkpolicy.SetIngress(policy.ingress),
+		)
+	}
+
+	return k8snetworkpolicy.Build(r.dk, policy.name, policy.selectorLabels,
+		k8snetworkpolicy.SetLabels(coreLabels.BuildLabels()),
+		k8snetworkpolicy.SetIngress(policy.ingress),
+		k8snetworkpolicy.SetEgress(policy.egress),
+	)
+}
+
+func (r *Reconciler) deletePolicies(ctx context.Context, names []string) {
+	query := k8snetworkpolicy.Query(r.client, r.apiReader, log)
+
+	for _, name := range names {
+		toDelete := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: r.dk.Namespace,
+			},
+		}
+
+		err := query.Delete(ctx, toDelete)
+		if err != nil {
+			log.Error(err, "failed to clean up network policy", "name", name)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testName      = "test-name"
+	testNamespace = "test-namespace"
+	testApiUrl    = "https://tenant.dynatrace.com/api"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no network policies if not enabled", func(t *testing.T) {
+		dk := createDynakube(false)
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		var policies networkingv1.NetworkPolicyList
+		require.NoError(t, clt.List(ctx, &policies))
+		assert.Empty(t, policies.Items)
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), conditionType))
+	})
+	t.Run("create network policy for the ActiveGate", func(t *testing.T) {
+		dk := createDynakube(true)
+		dk.Status.ActiveGate.ConnectionInfo.Endpoints = "https://10.0.0.1:9999/communication"
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		policy := getNetworkPolicy(t, clt, testName+"-activegate")
+		assert.Equal(t, testName, policy.OwnerReferences[0].Name)
+		assert.Equal(t, dk.Name, policy.Spec.PodSelector.MatchLabels["app.kubernetes.io/created-by"])
+		assert.ElementsMatch(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, policy.Spec.PolicyTypes)
+
+		require.Len(t, policy.Spec.Ingress, 1)
+		assert.Equal(t, intstr.FromString("https"), *policy.Spec.Ingress[0].Ports[0].Port)
+
+		// dns, kubernetes api, api url (hostname) and endpoint (ip)
+		require.Len(t, policy.Spec.Egress, 4)
+		assert.Equal(t, intstr.FromInt32(443), *policy.Spec.Egress[2].Ports[0].Port)
+		assert.Equal(t, "10.0.0.1/32", policy.Spec.Egress[3].To[0].IPBlock.CIDR)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	})
+	t.Run("ActiveGate can reach the extensions controller, if extensions are enabled", func(t *testing.T) {
+		dk := createDynakube(true)
+		dk.Spec.Extensions = &dynakube.ExtensionsSpec{}
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		activeGatePolicy := getNetworkPolicy(t, clt, testName+"-activegate")
+		controllerPolicy := getNetworkPolicy(t, clt, dk.ExtensionsExecutionControllerStatefulsetName())
+
+		// dns, kubernetes api, extensions controller and api url (hostname)
+		require.Len(t, activeGatePolicy.Spec.Egress, 4)
+		controllerEgress := activeGatePolicy.Spec.Egress[2]
+		require.Len(t, controllerEgress.To, 1)
+		assert.Equal(t, controllerPolicy.Spec.PodSelector.MatchLabels, controllerEgress.To[0].PodSelector.MatchLabels)
+		assert.Equal(t, *controllerPolicy.Spec.Ingress[0].Ports[0].Port, *controllerEgress.Ports[0].Port)
+	})
+	t.Run("create network policy for the webhook, if deployed next to the DynaKube", func(t *testing.T) {
+		dk := createDynakube(true)
+		dk.Spec.ActiveGate = activegate.Spec{}
+		clt := fake.NewClient(createWebhookDeployment())
+
+		err := NewReconciler(clt, clt, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		policy := getNetworkPolicy(t, clt, webhook.DeploymentName)
+		require.Len(t, policy.OwnerReferences, 1)
+		assert.Equal(t, "Deployment", policy.OwnerReferences[0].Kind)
+		assert.Equal(t, webhook.DeploymentName, policy.OwnerReferences[0].Name)
+		assert.Equal(t, map[string]string{"internal.dynatrace.com/app": "webhook"}, policy.Spec.PodSelector.MatchLabels)
+		require.Len(t, policy.Spec.Ingress, 1)
+		require.Len(t, policy.Spec.Ingress[0].Ports, 2)
+		assert.Equal(t, intstr.FromInt32(8443), *policy.Spec.Ingress[0].Ports[0].Port)
+		assert.Equal(t, corev1.ProtocolTCP, *policy.Spec.Ingress[0].Ports[0].Protocol)
+
+		assertNetworkPolicyNotFound(t, clt, testName+"-activegate")
+	})
+	t.Run("network policy of the webhook is shared by the DynaKubes", func(t *testing.T) {
+		dk := createDynakube(true)
+		otherDk := createDynakube(true)
+		otherDk.Name = "other"
+		clt := fake.NewClient(createWebhookDeployment(), dk, otherDk)
+
+		require.NoError(t, NewReconciler(clt, clt, dk).Reconcile(ctx))
+		require.NoError(t, NewReconciler(clt, clt, otherDk).Reconcile(ctx))
+
+		getNetworkPolicy(t, clt, webhook.DeploymentName)
+		assertNetworkPolicyNotFound(t, clt, dk.Name+"-"+webhook.DeploymentName)
+		assertNetworkPolicyNotFound(t, clt, otherDk.Name+"-"+webhook.DeploymentName)
+	})
+	t.Run("network policy of the webhook is kept while another DynaKube enables network policies", func(t *testing.T) {
+		otherDk := createDynakube(true)
+		otherDk.Name = "other"
+		clt := fake.NewClient(createWebhookDeployment(), otherDk)
+
+		require.NoError(t, NewReconciler(clt, clt, otherDk).Reconcile(ctx))
+		getNetworkPolicy(t, clt, webhook.DeploymentName)
+
+		require.NoError(t, NewReconciler(clt, clt, createDynakube(false)).Reconcile(ctx))
+		getNetworkPolicy(t, clt, webhook.DeploymentName)
+	})
+	t.Run("network policy of the webhook is removed once no DynaKube enables network policies", func(t *testing.T) {
+		dk := createDynakube(true)
+		clt := fake.NewClient(createWebhookDeployment())
+
+		require.NoError(t, NewReconciler(clt, clt, dk).Reconcile(ctx))
+		getNetworkPolicy(t, clt, webhook.DeploymentName)
+
+		dk.Spec.NetworkPolicies = nil
+
+		require.NoError(t, NewReconciler(clt, clt, dk).Reconcile(ctx))
+		assertNetworkPolicyNotFound(t, clt, webhook.DeploymentName)
+	})
+	t.Run("remove obsolete network policies", func(t *testing.T) {
+		dk := createDynakube(true)
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk).Reconcile(ctx)
+		require.NoError(t, err)
+		getNetworkPolicy(t, clt, testName+"-activegate")
+
+		dk.Spec.ActiveGate = activegate.Spec{}
+
+		err = NewReconciler(clt, clt, dk).Reconcile(ctx)
+		require.NoError(t, err)
+		assertNetworkPolicyNotFound(t, clt, testName+"-activegate")
+	})
+	t.Run("remove network policies if disabled", func(t *testing.T) {
+		dk := createDynakube(true)
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		dk.Spec.NetworkPolicies = nil
+
+		err = NewReconciler(clt, clt, dk).Reconcile(ctx)
+		require.NoError(t, err)
+
+		assertNetworkPolicyNotFound(t, clt, testName+"-activegate")
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), conditionType))
+	})
+	t.Run("invalid api url sets condition", func(t *testing.T) {
+		dk := createDynakube(true)
+		dk.Spec.APIURL = "://invalid"
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk).Reconcile(ctx)
+		require.Error(t, err)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), conditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	})
+}
+
+func createDynakube(enabled bool) *dynakube.DynaKube {
+	dk := &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: dynakube.DynaKubeSpec{
+			APIURL: testApiUrl,
+			ActiveGate: activegate.Spec{
+				Capabilities: []activegate.CapabilityDisplayName{activegate.KubeMonCapability.DisplayName},
+			},
+		},
+	}
+
+	if enabled {
+		dk.Spec.NetworkPolicies = &dynakube.NetworkPoliciesSpec{}
+	}
+
+	return dk
+}
+
+func createWebhookDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      webhook.DeploymentName,
+			Namespace: testNamespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"internal.dynatrace.com/app": "webhook"},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "webhook",
+							Ports: []corev1.ContainerPort{
+								{Name: "server-port", ContainerPort: 8443},
+								{Name: "metrics", ContainerPort: 8383, Protocol: corev1.ProtocolTCP},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getNetworkPolicy(t *testing.T, clt client.Client, name string) *networkingv1.NetworkPolicy {
+	var policy networkingv1.NetworkPolicy
+
+	err := clt.Get(context.Background(), client.ObjectKey{Name: name, Namespace: testNamespace}, &policy)
+	require.NoError(t, err)
+
+	return &policy
+}
+
+func assertNetworkPolicyNotFound(t *testing.T, clt client.Client, name string) {
+	var policy networkingv1.NetworkPolicy
+
+	err := clt.Get(context.Background(), client.ObjectKey{Name: name, Namespace: testNamespace}, &policy)
+	require.Error(t, err)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
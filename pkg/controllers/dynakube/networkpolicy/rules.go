@@ -0,0 +1,138 @@
+package networkpolicy
+
+import (
+	"net"
+	"slices"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	// dnsPorts also contains 5353, because the DNS pods on OpenShift listen on it
+	dnsPorts = []int32{53, 5353}
+
+	kubernetesApiPorts = []int32{443, 6443}
+)
+
+func portsOf(protocol corev1.Protocol, ports ...intstr.IntOrString) []networkingv1.NetworkPolicyPort {
+	policyPorts := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		})
+	}
+
+	return policyPorts
+}
+
+func numberedPorts(ports ...int32) []intstr.IntOrString {
+	out := make([]intstr.IntOrString, 0, len(ports))
+	for _, port := range ports {
+		out = append(out, intstr.FromInt32(port))
+	}
+
+	return out
+}
+
+func namedPorts(names ...string) []intstr.IntOrString {
+	out := make([]intstr.IntOrString, 0, len(names))
+	for _, name := range names {
+		out = append(out, intstr.FromString(name))
+	}
+
+	return out
+}
+
+// ingressFromAnywhere allows traffic to the given ports from any source, as clients can also be pods using the host network.
+func ingressFromAnywhere(ports []networkingv1.NetworkPolicyPort) []networkingv1.NetworkPolicyIngressRule {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	return []networkingv1.NetworkPolicyIngressRule{{Ports: ports}}
+}
+
+func dnsEgressRule() networkingv1.NetworkPolicyEgressRule {
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: append(
+			portsOf(corev1.ProtocolUDP, numberedPorts(dnsPorts...)...),
+			portsOf(corev1.ProtocolTCP, numberedPorts(dnsPorts...)...)...,
+		),
+	}
+}
+
+// kubernetesApiEgressRule allows traffic to the Kubernetes API, its endpoints are not known, so only the ports are restricted.
+func kubernetesApiEgressRule() networkingv1.NetworkPolicyEgressRule {
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: portsOf(corev1.ProtocolTCP, numberedPorts(kubernetesApiPorts...)...),
+	}
+}
+
+// podEgressRule allows traffic to the named ports of the pods matching the selector labels in the namespace of the policy.
+func podEgressRule(selectorLabels map[string]string, portNames ...string) networkingv1.NetworkPolicyEgressRule {
+	return networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{
+			{PodSelector: &metav1.LabelSelector{MatchLabels: selectorLabels}},
+		},
+		Ports: portsOf(corev1.ProtocolTCP, namedPorts(portNames...)...),
+	}
+}
+
+// hostEgressRules allows traffic to the given hosts.
+// NetworkPolicies can't select hosts by their name, so for those only the ports are restricted, while IP addresses are used as peers.
+func hostEgressRules(hosts []dtclient.CommunicationHost) []networkingv1.NetworkPolicyEgressRule {
+	hostnamePorts := sets.New[int32]()
+	ipPorts := map[string]sets.Set[int32]{}
+
+	for _, host := range hosts {
+		port := int32(host.Port) //nolint:gosec
+
+		ip := net.ParseIP(host.Host)
+		if ip == nil {
+			hostnamePorts.Insert(port)
+
+			continue
+		}
+
+		cidr := ip.String() + "/32"
+		if ip.To4() == nil {
+			cidr = ip.String() + "/128"
+		}
+
+		if _, ok := ipPorts[cidr]; !ok {
+			ipPorts[cidr] = sets.New[int32]()
+		}
+
+		ipPorts[cidr].Insert(port)
+	}
+
+	var rules []networkingv1.NetworkPolicyEgressRule
+
+	if hostnamePorts.Len() > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			Ports: portsOf(corev1.ProtocolTCP, numberedPorts(sets.List(hostnamePorts)...)...),
+		})
+	}
+
+	cidrs := make([]string, 0, len(ipPorts))
+	for cidr := range ipPorts {
+		cidrs = append(cidrs, cidr)
+	}
+
+	slices.Sort(cidrs)
+
+	for _, cidr := range cidrs {
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: cidr}}},
+			Ports: portsOf(corev1.ProtocolTCP, numberedPorts(sets.List(ipPorts[cidr])...)...),
+		})
+	}
+
+	return rules
+}
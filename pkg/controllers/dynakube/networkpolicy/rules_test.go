@@ -0,0 +1,44 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestHostEgressRules(t *testing.T) {
+	t.Run("no hosts, no rules", func(t *testing.T) {
+		assert.Empty(t, hostEgressRules(nil))
+	})
+	t.Run("hostnames are merged into a single rule restricting only the ports", func(t *testing.T) {
+		rules := hostEgressRules([]dtclient.CommunicationHost{
+			{Protocol: "https", Host: "tenant.live.dynatrace.com", Port: 443},
+			{Protocol: "https", Host: "other.dynatrace.com", Port: 443},
+			{Protocol: "http", Host: "proxy", Port: 8080},
+		})
+
+		require.Len(t, rules, 1)
+		assert.Empty(t, rules[0].To)
+		require.Len(t, rules[0].Ports, 2)
+		assert.Equal(t, intstr.FromInt32(443), *rules[0].Ports[0].Port)
+		assert.Equal(t, intstr.FromInt32(8080), *rules[0].Ports[1].Port)
+	})
+	t.Run("ip addresses are used as peers", func(t *testing.T) {
+		rules := hostEgressRules([]dtclient.CommunicationHost{
+			{Protocol: "https", Host: "10.0.0.2", Port: 9999},
+			{Protocol: "https", Host: "10.0.0.1", Port: 443},
+			{Protocol: "https", Host: "10.0.0.1", Port: 8443},
+			{Protocol: "https", Host: "fd00::1", Port: 443},
+		})
+
+		require.Len(t, rules, 3)
+		assert.Equal(t, "10.0.0.1/32", rules[0].To[0].IPBlock.CIDR)
+		assert.Len(t, rules[0].Ports, 2)
+		assert.Equal(t, "10.0.0.2/32", rules[1].To[0].IPBlock.CIDR)
+		assert.Len(t, rules[1].Ports, 1)
+		assert.Equal(t, "fd00::1/128", rules[2].To[0].IPBlock.CIDR)
+	})
+}
@@ -0,0 +1,132 @@
+package networkpolicy
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	k8snetworkpolicy "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/networkpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// webhookPolicyName is the name of the policy of the webhook. The webhook serves all DynaKubes, so there is only one policy for it,
+// which is owned by the webhook Deployment instead of a DynaKube.
+const webhookPolicyName = webhook.DeploymentName
+
+// reconcileWebhookPolicy creates the policy of the webhook as long as any DynaKube next to the webhook enables network policies,
+// and removes it otherwise.
+func (r *Reconciler) reconcileWebhookPolicy(ctx context.Context) error {
+	needed, err := r.isWebhookPolicyNeeded(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !needed {
+		return r.removeWebhookPolicy(ctx)
+	}
+
+	var webhookDeployment appsv1.Deployment
+
+	err = r.apiReader.Get(ctx, client.ObjectKey{Name: webhook.DeploymentName, Namespace: r.dk.Namespace}, &webhookDeployment)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	webhookPolicy := newWebhookPolicy(&webhookDeployment)
+
+	desired, err := k8snetworkpolicy.Build(&webhookDeployment, webhookPolicy.name, webhookPolicy.selectorLabels,
+		k8snetworkpolicy.SetLabels(labels.NewCoreLabels(webhookDeployment.Name, webhookPolicy.component).BuildLabels()),
+		k8snetworkpolicy.SetIngress(webhookPolicy.ingress),
+		k8snetworkpolicy.SetEgress(webhookPolicy.egress),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = k8snetworkpolicy.Query(r.client, r.apiReader, log).CreateOrUpdate(ctx, desired)
+	if err != nil {
+		log.Info("failed to create/update network policy", "name", webhookPolicyName)
+
+		return err
+	}
+
+	return nil
+}
+
+// isWebhookPolicyNeeded checks if the reconciled or any other DynaKube in the namespace of the webhook enables network policies.
+func (r *Reconciler) isWebhookPolicyNeeded(ctx context.Context) (bool, error) {
+	if r.dk.IsNetworkPoliciesEnabled() {
+		return true, nil
+	}
+
+	var dynakubes dynakube.DynaKubeList
+
+	err := r.client.List(ctx, &dynakubes, client.InNamespace(r.dk.Namespace))
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	for _, dk := range dynakubes.Items {
+		// the reconciled DynaKube may be outdated in the cache
+		if dk.Name != r.dk.Name && dk.IsNetworkPoliciesEnabled() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// removeWebhookPolicy checks the cache first, so the DynaKubes without network policies don't cause a request on each reconcile.
+func (r *Reconciler) removeWebhookPolicy(ctx context.Context) error {
+	webhookPolicy := &networkingv1.NetworkPolicy{}
+
+	err := r.client.Get(ctx, client.ObjectKey{Name: webhookPolicyName, Namespace: r.dk.Namespace}, webhookPolicy)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return k8snetworkpolicy.Query(r.client, r.apiReader, log).Delete(ctx, webhookPolicy)
+}
+
+// newWebhookPolicy derives the policy from the webhook Deployment, as it is deployed by the Helm chart and not by the operator.
+func newWebhookPolicy(webhookDeployment *appsv1.Deployment) policy {
+	var ports []networkingv1.NetworkPolicyPort
+
+	for _, container := range webhookDeployment.Spec.Template.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			protocol := containerPort.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+
+			ports = append(ports, portsOf(protocol, intstr.FromInt32(containerPort.ContainerPort))...)
+		}
+	}
+
+	var selectorLabels map[string]string
+	if webhookDeployment.Spec.Selector != nil {
+		selectorLabels = webhookDeployment.Spec.Selector.MatchLabels
+	}
+
+	return policy{
+		name:           webhookPolicyName,
+		component:      labels.WebhookComponentLabel,
+		selectorLabels: selectorLabels,
+		ingress:        ingressFromAnywhere(ports),
+		egress: []networkingv1.NetworkPolicyEgressRule{
+			dnsEgressRule(),
+			kubernetesApiEgressRule(),
+		},
+	}
+}
@@ -103,7 +103,7 @@ func (r *Reconciler) buildService() (*corev1.Service, error) {
 
 	var svcPorts []corev1.ServicePort
 	if r.dk.TelemetryService().IsEnabled() && r.dk.Spec.TelemetryService.ServiceName == "" {
-		svcPorts = buildServicePortList(r.dk.TelemetryService().GetProtocols())
+		svcPorts = BuildServicePortList(r.dk.TelemetryService().GetProtocols())
 	}
 
 	return service.Build(r.dk,
@@ -115,7 +115,8 @@ func (r *Reconciler) buildService() (*corev1.Service, error) {
 	)
 }
 
-func buildServicePortList(protocols []telemetryservice.Protocol) []corev1.ServicePort {
+// BuildServicePortList returns the ports the collector listens on for the given telemetry protocols.
+func BuildServicePortList(protocols []telemetryservice.Protocol) []corev1.ServicePort {
 	if len(protocols) == 0 {
 		return nil
 	}
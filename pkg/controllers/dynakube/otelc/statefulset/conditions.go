@@ -1,3 +1,6 @@
 package statefulset
 
-const conditionType string = "OtelStatefulSet"
+const (
+	conditionType                    string = "OtelStatefulSet"
+	podDisruptionBudgetConditionType string = "OtelPodDisruptionBudget"
+)
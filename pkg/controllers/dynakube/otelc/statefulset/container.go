@@ -17,7 +17,8 @@ const (
 	otelcSecretTokenFilePath = secretsTokensPath + "/" + consts.OtelcTokenSecretKey
 )
 
-func getContainer(dk *dynakube.DynaKube) corev1.Container {
+// Image returns the image of the OpenTelemetry collector configured in the DynaKube, or the default one.
+func Image(dk *dynakube.DynaKube) string {
 	imageRepo := dk.Spec.Templates.OpenTelemetryCollector.ImageRef.Repository
 	imageTag := dk.Spec.Templates.OpenTelemetryCollector.ImageRef.Tag
 
@@ -29,9 +30,13 @@ func getContainer(dk *dynakube.DynaKube) corev1.Container {
 		imageTag = defaultImageTag
 	}
 
+	return imageRepo + ":" + imageTag
+}
+
+func getContainer(dk *dynakube.DynaKube) corev1.Container {
 	return corev1.Container{
 		Name:            containerName,
-		Image:           imageRepo + ":" + imageTag,
+		Image:           Image(dk),
 		ImagePullPolicy: corev1.PullAlways,
 		SecurityContext: buildSecurityContext(),
 		Env:             getEnvs(dk),
@@ -4,7 +4,10 @@ import (
 	"context"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/drift"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/poddisruptionbudget"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
@@ -42,6 +45,10 @@ func NewReconciler(clt client.Client,
 
 func (r *Reconciler) Reconcile(ctx context.Context) error {
 	if !r.dk.IsExtensionsEnabled() {
+		if err := r.podDisruptionBudgetReconciler(nil).Reconcile(ctx); err != nil {
+			return err
+		}
+
 		if meta.FindStatusCondition(*r.dk.Conditions(), conditionType) == nil {
 			return nil
 		}
@@ -65,7 +72,20 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 		return nil
 	}
 
-	return r.createOrUpdateStatefulset(ctx)
+	err := r.createOrUpdateStatefulset(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.podDisruptionBudgetReconciler(r.dk.Spec.Templates.OpenTelemetryCollector.PodDisruptionBudget).Reconcile(ctx)
+}
+
+func (r *Reconciler) podDisruptionBudgetReconciler(spec *pdb.Spec) *poddisruptionbudget.Reconciler {
+	return poddisruptionbudget.NewReconciler(r.client, r.apiReader, r.dk, spec,
+		r.dk.ExtensionsCollectorStatefulsetName(),
+		podDisruptionBudgetConditionType,
+		buildAppLabels(r.dk.Name).BuildMatchLabels(),
+	)
 }
 
 func (r *Reconciler) createOrUpdateStatefulset(ctx context.Context) error {
@@ -108,7 +128,7 @@ func (r *Reconciler) createOrUpdateStatefulset(ctx context.Context) error {
 		return err
 	}
 
-	_, err = statefulset.Query(r.client, r.apiReader, log).WithOwner(r.dk).CreateOrUpdate(ctx, sts)
+	_, err = statefulset.Query(r.client, r.apiReader, log).WithOwner(r.dk).WithDriftHandler(drift.NewHandler(r.dk)).CreateOrUpdate(ctx, sts)
 	if err != nil {
 		log.Info("failed to create/update " + r.dk.ExtensionsCollectorStatefulsetName() + " statefulset")
 		conditions.SetKubeApiError(r.dk.Conditions(), conditionType, err)
@@ -0,0 +1,7 @@
+package poddisruptionbudget
+
+import "github.com/Dynatrace/dynatrace-operator/pkg/logd"
+
+var (
+	log = logd.Get().WithName("pod-disruption-budget")
+)
@@ -0,0 +1,92 @@
+package poddisruptionbudget
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/conditions"
+	k8spdb "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/poddisruptionbudget"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reconciler manages the PodDisruptionBudget of a single component, it is created if the component's spec configures one and removed otherwise.
+type Reconciler struct {
+	client         client.Client
+	apiReader      client.Reader
+	dk             *dynakube.DynaKube
+	spec           *pdb.Spec
+	name           string
+	conditionType  string
+	selectorLabels map[string]string
+}
+
+func NewReconciler(clt client.Client, apiReader client.Reader, dk *dynakube.DynaKube, spec *pdb.Spec, name, conditionType string, selectorLabels map[string]string) *Reconciler {
+	return &Reconciler{
+		client:         clt,
+		apiReader:      apiReader,
+		dk:             dk,
+		spec:           spec,
+		name:           name,
+		conditionType:  conditionType,
+		selectorLabels: selectorLabels,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	if r.spec == nil {
+		return r.removeOnce(ctx)
+	}
+
+	return r.createOrUpdate(ctx)
+}
+
+func (r *Reconciler) removeOnce(ctx context.Context) error {
+	if meta.FindStatusCondition(*r.dk.Conditions(), r.conditionType) == nil {
+		return nil
+	}
+	defer meta.RemoveStatusCondition(r.dk.Conditions(), r.conditionType)
+
+	toDelete := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.name,
+			Namespace: r.dk.Namespace,
+		},
+	}
+
+	err := k8spdb.Query(r.client, r.apiReader, log).Delete(ctx, toDelete)
+	if err != nil {
+		log.Error(err, "failed to clean up pod disruption budget", "name", r.name)
+
+		return nil
+	}
+
+	return nil
+}
+
+func (r *Reconciler) createOrUpdate(ctx context.Context) error {
+	desired, err := k8spdb.Build(r.dk, r.name, r.selectorLabels,
+		k8spdb.SetMinAvailable(r.spec.MinAvailable),
+		k8spdb.SetMaxUnavailable(r.spec.MaxUnavailable),
+	)
+	if err != nil {
+		conditions.SetPodDisruptionBudgetGenFailed(r.dk.Conditions(), r.conditionType, err)
+
+		return err
+	}
+
+	_, err = k8spdb.Query(r.client, r.apiReader, log).CreateOrUpdate(ctx, desired)
+	if err != nil {
+		log.Info("failed to create/update pod disruption budget", "name", r.name)
+		conditions.SetKubeApiError(r.dk.Conditions(), r.conditionType, err)
+
+		return err
+	}
+
+	conditions.SetPodDisruptionBudgetCreated(r.dk.Conditions(), r.conditionType, r.name)
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package poddisruptionbudget
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/shared/pdb"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testName          = "test-pdb"
+	testNamespace     = "test-namespace"
+	testConditionType = "TestPodDisruptionBudget"
+)
+
+var testSelectorLabels = map[string]string{"app": "test"}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("create pod disruption budget", func(t *testing.T) {
+		dk := createDynakube()
+		minAvailable := intstr.FromInt32(1)
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk, &pdb.Spec{MinAvailable: &minAvailable}, testName, testConditionType, testSelectorLabels).Reconcile(ctx)
+		require.NoError(t, err)
+
+		actual := getPodDisruptionBudget(t, clt)
+		assert.Equal(t, minAvailable, *actual.Spec.MinAvailable)
+		assert.Nil(t, actual.Spec.MaxUnavailable)
+		assert.Equal(t, testSelectorLabels, actual.Spec.Selector.MatchLabels)
+
+		condition := meta.FindStatusCondition(*dk.Conditions(), testConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	})
+	t.Run("update pod disruption budget", func(t *testing.T) {
+		dk := createDynakube()
+		minAvailable := intstr.FromInt32(1)
+		maxUnavailable := intstr.FromString("50%")
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk, &pdb.Spec{MinAvailable: &minAvailable}, testName, testConditionType, testSelectorLabels).Reconcile(ctx)
+		require.NoError(t, err)
+
+		err = NewReconciler(clt, clt, dk, &pdb.Spec{MaxUnavailable: &maxUnavailable}, testName, testConditionType, testSelectorLabels).Reconcile(ctx)
+		require.NoError(t, err)
+
+		actual := getPodDisruptionBudget(t, clt)
+		assert.Nil(t, actual.Spec.MinAvailable)
+		assert.Equal(t, maxUnavailable, *actual.Spec.MaxUnavailable)
+	})
+	t.Run("remove pod disruption budget if spec is removed", func(t *testing.T) {
+		dk := createDynakube()
+		minAvailable := intstr.FromInt32(1)
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, clt, dk, &pdb.Spec{MinAvailable: &minAvailable}, testName, testConditionType, testSelectorLabels).Reconcile(ctx)
+		require.NoError(t, err)
+
+		err = NewReconciler(clt, clt, dk, nil, testName, testConditionType, testSelectorLabels).Reconcile(ctx)
+		require.NoError(t, err)
+
+		err = clt.Get(ctx, client.ObjectKey{Name: testName, Namespace: testNamespace}, &policyv1.PodDisruptionBudget{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		assert.Nil(t, meta.FindStatusCondition(*dk.Conditions(), testConditionType))
+	})
+	t.Run("do nothing without spec and condition", func(t *testing.T) {
+		dk := createDynakube()
+		existing := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		}
+		clt := fake.NewClient(existing)
+
+		err := NewReconciler(clt, clt, dk, nil, testName, testConditionType, testSelectorLabels).Reconcile(ctx)
+		require.NoError(t, err)
+
+		getPodDisruptionBudget(t, clt)
+	})
+}
+
+func createDynakube() *dynakube.DynaKube {
+	return &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dynakube",
+			Namespace: testNamespace,
+		},
+	}
+}
+
+func getPodDisruptionBudget(t *testing.T, clt client.Client) *policyv1.PodDisruptionBudget {
+	actual := &policyv1.PodDisruptionBudget{}
+	err := clt.Get(context.Background(), client.ObjectKey{Name: testName, Namespace: testNamespace}, actual)
+	require.NoError(t, err)
+
+	return actual
+}
@@ -0,0 +1,117 @@
+package injectionpolicy
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/injectionpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/injection/policy"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// the policies are applied by the webhook when pods are created, pods are not watched to avoid caching all pods of the cluster
+const defaultUpdateInterval = 5 * time.Minute
+
+// Controller reports the pods an InjectionPolicy was applied to in its status.
+type Controller struct {
+	client    client.Client
+	apiReader client.Reader
+}
+
+func Add(mgr manager.Manager, _ string) error {
+	return NewController(mgr).SetupWithManager(mgr)
+}
+
+func NewController(mgr manager.Manager) *Controller {
+	return &Controller{
+		client:    mgr.GetClient(),
+		apiReader: mgr.GetAPIReader(),
+	}
+}
+
+func (controller *Controller) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&injectionpolicy.InjectionPolicy{}).
+		Named("injectionpolicy-controller").
+		Complete(controller)
+}
+
+func (controller *Controller) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log.Info("reconciling InjectionPolicy", "namespace", request.Namespace, "name", request.Name)
+
+	var injectionPolicy injectionpolicy.InjectionPolicy
+
+	err := controller.apiReader.Get(ctx, request.NamespacedName, &injectionPolicy)
+	if k8serrors.IsNotFound(err) {
+		return reconcile.Result{}, nil
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	matchedPods, err := controller.getMatchedPods(ctx, injectionPolicy)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	newStatus := injectionpolicy.InjectionPolicyStatus{
+		MatchedPods:        matchedPods,
+		MatchedPodCount:    len(matchedPods),
+		ObservedGeneration: injectionPolicy.Generation,
+	}
+
+	if len(matchedPods) > policy.MaxReportedPods {
+		newStatus.MatchedPods = matchedPods[:policy.MaxReportedPods]
+	}
+
+	if isStatusEqual(injectionPolicy.Status, newStatus) {
+		return reconcile.Result{RequeueAfter: defaultUpdateInterval}, nil
+	}
+
+	injectionPolicy.Status = newStatus
+
+	err = controller.client.Status().Update(ctx, &injectionPolicy)
+	if k8serrors.IsConflict(err) {
+		log.Info("could not update InjectionPolicy status due to conflict", "namespace", request.Namespace, "name", request.Name)
+
+		return reconcile.Result{Requeue: true}, nil
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	log.Info("InjectionPolicy status updated", "namespace", request.Namespace, "name", request.Name, "matchedPods", newStatus.MatchedPodCount)
+
+	return reconcile.Result{RequeueAfter: defaultUpdateInterval}, nil
+}
+
+// getMatchedPods returns the sorted names of the pods of the namespace the webhook applied the policy to.
+func (controller *Controller) getMatchedPods(ctx context.Context, injectionPolicy injectionpolicy.InjectionPolicy) ([]string, error) {
+	var podList corev1.PodList
+	if err := controller.apiReader.List(ctx, &podList, client.InNamespace(injectionPolicy.Namespace)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	matchedPods := []string{}
+
+	for _, pod := range podList.Items {
+		if policy.AppliedPolicy(pod) == injectionPolicy.Name {
+			matchedPods = append(matchedPods, pod.Name)
+		}
+	}
+
+	slices.Sort(matchedPods)
+
+	return matchedPods, nil
+}
+
+func isStatusEqual(current, desired injectionpolicy.InjectionPolicyStatus) bool {
+	return current.MatchedPodCount == desired.MatchedPodCount &&
+		current.ObservedGeneration == desired.ObservedGeneration &&
+		slices.Equal(current.MatchedPods, desired.MatchedPods)
+}
@@ -0,0 +1,112 @@
+package injectionpolicy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/injectionpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/injection/policy"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	testNamespace  = "test-namespace"
+	testPolicyName = "test-policy"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: testPolicyName, Namespace: testNamespace}}
+
+	t.Run("matched pods are reported", func(t *testing.T) {
+		controller := createTestController(
+			createTestPolicy(),
+			createTestPod("pod-b", testNamespace, testPolicyName),
+			createTestPod("pod-a", testNamespace, testPolicyName),
+			createTestPod("other-policy", testNamespace, "other"),
+			createTestPod("no-policy", testNamespace, ""),
+			createTestPod("other-namespace", "other", testPolicyName),
+		)
+
+		result, err := controller.Reconcile(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, defaultUpdateInterval, result.RequeueAfter)
+
+		reconciled := getTestPolicy(t, controller.client)
+		assert.Equal(t, []string{"pod-a", "pod-b"}, reconciled.Status.MatchedPods)
+		assert.Equal(t, 2, reconciled.Status.MatchedPodCount)
+		assert.Equal(t, int64(3), reconciled.Status.ObservedGeneration)
+	})
+	t.Run("reported pod names are limited", func(t *testing.T) {
+		objects := []client.Object{createTestPolicy()}
+		for i := range policy.MaxReportedPods + 1 {
+			objects = append(objects, createTestPod(fmt.Sprintf("pod-%03d", i), testNamespace, testPolicyName))
+		}
+
+		controller := createTestController(objects...)
+
+		_, err := controller.Reconcile(ctx, request)
+		require.NoError(t, err)
+
+		reconciled := getTestPolicy(t, controller.client)
+		assert.Len(t, reconciled.Status.MatchedPods, policy.MaxReportedPods)
+		assert.Equal(t, policy.MaxReportedPods+1, reconciled.Status.MatchedPodCount)
+	})
+	t.Run("deleted policy is ignored", func(t *testing.T) {
+		controller := createTestController()
+
+		result, err := controller.Reconcile(ctx, request)
+		require.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+	})
+}
+
+func createTestController(objects ...client.Object) *Controller {
+	fakeClient := fake.NewClientWithIndex(objects...)
+
+	return &Controller{
+		client:    fakeClient,
+		apiReader: fakeClient,
+	}
+}
+
+func createTestPolicy() *injectionpolicy.InjectionPolicy {
+	return &injectionpolicy.InjectionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testPolicyName,
+			Namespace:  testNamespace,
+			Generation: 3,
+		},
+	}
+}
+
+func createTestPod(name, namespace, policyName string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	if policyName != "" {
+		pod.Annotations = map[string]string{dtwebhook.AnnotationInjectionPolicy: policyName}
+	}
+
+	return pod
+}
+
+func getTestPolicy(t *testing.T, clt client.Client) injectionpolicy.InjectionPolicy {
+	var injectionPolicy injectionpolicy.InjectionPolicy
+	require.NoError(t, clt.Get(context.Background(), types.NamespacedName{Name: testPolicyName, Namespace: testNamespace}, &injectionPolicy))
+
+	return injectionPolicy
+}
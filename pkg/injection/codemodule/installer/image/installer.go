@@ -26,6 +26,8 @@ type Properties struct {
 	Dynakube     *dynakube.DynaKube
 	PathResolver metadata.PathResolver
 	ImageDigest  string
+	// Arch is the architecture to pull the image for, in the format of the Image Registry, defaults to the architecture of this binary
+	Arch string
 }
 
 func NewImageInstaller(ctx context.Context, fs afero.Fs, props *Properties) (installer.Installer, error) {
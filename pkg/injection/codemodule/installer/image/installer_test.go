@@ -10,6 +10,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/zip"
@@ -37,7 +38,7 @@ func TestIsAlreadyPresent(t *testing.T) {
 				PathResolver: pathResolver,
 			},
 		}
-		isDownloaded := installer.isAlreadyPresent(pathResolver.AgentSharedBinaryDirForAgent(imageDigest))
+		isDownloaded := installer.isAlreadyPresent(pathResolver.AgentSharedBinaryDirForAgent(imageDigest, arch.ImageArch))
 		assert.False(t, isDownloaded)
 	})
 	t.Run("returns true if path present", func(t *testing.T) {
@@ -47,14 +48,14 @@ func TestIsAlreadyPresent(t *testing.T) {
 				PathResolver: pathResolver,
 			},
 		}
-		isDownloaded := installer.isAlreadyPresent(pathResolver.AgentSharedBinaryDirForAgent(imageDigest))
+		isDownloaded := installer.isAlreadyPresent(pathResolver.AgentSharedBinaryDirForAgent(imageDigest, arch.ImageArch))
 		assert.True(t, isDownloaded)
 	})
 }
 
 func testFileSystemWithSharedDirPresent(pathResolver metadata.PathResolver, imageDigest string) afero.Fs {
 	fs := afero.NewMemMapFs()
-	_ = fs.MkdirAll(pathResolver.AgentSharedBinaryDirForAgent(imageDigest), 0777)
+	_ = fs.MkdirAll(pathResolver.AgentSharedBinaryDirForAgent(imageDigest, arch.ImageArch), 0777)
 
 	return fs
 }
@@ -50,7 +50,7 @@ func (installer *Installer) pullImageInfo(imageName string) (*containerv1.Image,
 	image, err := remote.Image(ref, remote.WithContext(context.TODO()),
 		remote.WithAuthFromKeychain(installer.keychain),
 		remote.WithTransport(installer.transport),
-		remote.WithPlatform(arch.ImagePlatform),
+		remote.WithPlatform(arch.PlatformFor(installer.props.Arch)),
 	)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "getting image %q", imageName)
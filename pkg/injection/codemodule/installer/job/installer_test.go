@@ -7,6 +7,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -30,7 +31,7 @@ func TestIsAlreadyPresent(t *testing.T) {
 				ImageUri:     testImageURL,
 			},
 		}
-		isDownloaded := installer.isAlreadyPresent(pathResolver.AgentSharedBinaryDirForAgent(testVersion))
+		isDownloaded := installer.isAlreadyPresent(pathResolver.AgentSharedBinaryDirForAgent(testVersion, arch.ImageArch))
 		assert.False(t, isDownloaded)
 	})
 	t.Run("returns true if path present", func(t *testing.T) {
@@ -40,14 +41,14 @@ func TestIsAlreadyPresent(t *testing.T) {
 				PathResolver: pathResolver,
 			},
 		}
-		isDownloaded := installer.isAlreadyPresent(pathResolver.AgentSharedBinaryDirForAgent(testVersion))
+		isDownloaded := installer.isAlreadyPresent(pathResolver.AgentSharedBinaryDirForAgent(testVersion, arch.ImageArch))
 		assert.True(t, isDownloaded)
 	})
 }
 
 func testFileSystemWithSharedDirPresent(pathResolver metadata.PathResolver, imageDigest string) afero.Fs {
 	fs := afero.NewMemMapFs()
-	_ = fs.MkdirAll(pathResolver.AgentSharedBinaryDirForAgent(imageDigest), 0777)
+	_ = fs.MkdirAll(pathResolver.AgentSharedBinaryDirForAgent(imageDigest, arch.ImageArch), 0777)
 
 	return fs
 }
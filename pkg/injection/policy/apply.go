@@ -0,0 +1,57 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/injectionpolicy"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Apply sets the annotations of the pod that correspond to the settings of the policy.
+// Annotations that are already set on the pod are not changed, so the pod can still override the policy.
+func Apply(policy *injectionpolicy.InjectionPolicy, pod *corev1.Pod) {
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+
+	spec := policy.Spec
+
+	if spec.OneAgent != nil {
+		setDefault(pod.Annotations, dtwebhook.AnnotationOneAgentInject, strconv.FormatBool(*spec.OneAgent))
+	}
+
+	if spec.MetadataEnrichment != nil {
+		setDefault(pod.Annotations, dtwebhook.AnnotationMetadataEnrichmentInject, strconv.FormatBool(*spec.MetadataEnrichment))
+	}
+
+	if len(spec.Technologies) > 0 {
+		setDefault(pod.Annotations, dtwebhook.AnnotationTechnologies, strings.Join(spec.Technologies, ","))
+	}
+
+	if spec.Flavor != "" {
+		setDefault(pod.Annotations, dtwebhook.AnnotationFlavor, spec.Flavor)
+	}
+
+	if spec.FailurePolicy != "" {
+		setDefault(pod.Annotations, dtwebhook.AnnotationFailurePolicy, spec.FailurePolicy)
+	}
+
+	for _, containerName := range spec.ExcludedContainers {
+		setDefault(pod.Annotations, dtwebhook.AnnotationContainerInjection+"/"+containerName, "false")
+	}
+
+	pod.Annotations[dtwebhook.AnnotationInjectionPolicy] = policy.Name
+}
+
+// AppliedPolicy returns the name of the InjectionPolicy that was applied to the pod, or an empty string.
+func AppliedPolicy(pod corev1.Pod) string {
+	return pod.Annotations[dtwebhook.AnnotationInjectionPolicy]
+}
+
+func setDefault(annotations map[string]string, key, value string) {
+	if _, ok := annotations[key]; !ok {
+		annotations[key] = value
+	}
+}
@@ -0,0 +1,67 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/injectionpolicy"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestApply(t *testing.T) {
+	policy := &injectionpolicy.InjectionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Namespace: testNamespace},
+		Spec: injectionpolicy.InjectionPolicySpec{
+			OneAgent:           ptr.To(true),
+			MetadataEnrichment: ptr.To(false),
+			Technologies:       []string{"java", "nginx"},
+			Flavor:             "musl",
+			ExcludedContainers: []string{"sidecar"},
+			FailurePolicy:      "fail",
+		},
+	}
+
+	t.Run("sets annotations", func(t *testing.T) {
+		pod := &corev1.Pod{}
+
+		Apply(policy, pod)
+
+		assert.Equal(t, map[string]string{
+			dtwebhook.AnnotationOneAgentInject:                  "true",
+			dtwebhook.AnnotationMetadataEnrichmentInject:        "false",
+			dtwebhook.AnnotationTechnologies:                    "java,nginx",
+			dtwebhook.AnnotationFlavor:                          "musl",
+			dtwebhook.AnnotationContainerInjection + "/sidecar": "false",
+			dtwebhook.AnnotationFailurePolicy:                   "fail",
+			dtwebhook.AnnotationInjectionPolicy:                 "test-policy",
+		}, pod.Annotations)
+		assert.True(t, dtwebhook.IsContainerExcludedFromInjection(nil, pod.Annotations, "sidecar"))
+		assert.Equal(t, "test-policy", AppliedPolicy(*pod))
+	})
+	t.Run("annotations of the pod take precedence", func(t *testing.T) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					dtwebhook.AnnotationOneAgentInject: "false",
+					dtwebhook.AnnotationFlavor:         "default",
+				},
+			},
+		}
+
+		Apply(policy, pod)
+
+		assert.Equal(t, "false", pod.Annotations[dtwebhook.AnnotationOneAgentInject])
+		assert.Equal(t, "default", pod.Annotations[dtwebhook.AnnotationFlavor])
+		assert.Equal(t, "java,nginx", pod.Annotations[dtwebhook.AnnotationTechnologies])
+	})
+	t.Run("empty policy only records its name", func(t *testing.T) {
+		pod := &corev1.Pod{}
+
+		Apply(&injectionpolicy.InjectionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "empty"}}, pod)
+
+		assert.Equal(t, map[string]string{dtwebhook.AnnotationInjectionPolicy: "empty"}, pod.Annotations)
+	})
+}
@@ -0,0 +1,17 @@
+package policy
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+const (
+	// PodKind is used as owner kind of pods that are not owned by a workload.
+	PodKind = "Pod"
+
+	// MaxReportedPods limits the number of pod names reported in the status of an InjectionPolicy.
+	MaxReportedPods = 100
+)
+
+var (
+	log = logd.Get().WithName("injection-policy")
+)
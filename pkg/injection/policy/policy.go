@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/injectionpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/metadata"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Resolve returns the InjectionPolicy of the namespace that applies to the given pod, or nil if none selects it.
+// If several policies select the pod, the one whose name sorts first is returned.
+func Resolve(ctx context.Context, reader client.Reader, pod *corev1.Pod, namespace string) (*injectionpolicy.InjectionPolicy, error) {
+	var policyList injectionpolicy.InjectionPolicyList
+	if err := reader.List(ctx, &policyList, client.InNamespace(namespace)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if len(policyList.Items) == 0 {
+		return nil, nil
+	}
+
+	slices.SortFunc(policyList.Items, func(a, b injectionpolicy.InjectionPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	resolver := ownerResolver{reader: reader, pod: pod, namespace: namespace}
+
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+
+		matches, err := matches(ctx, policy, pod, &resolver)
+		if err != nil {
+			return nil, err
+		}
+
+		if matches {
+			return policy, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func matches(ctx context.Context, policy *injectionpolicy.InjectionPolicy, pod *corev1.Pod, resolver *ownerResolver) (bool, error) {
+	if policy.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector)
+		if err != nil {
+			log.Info("ignoring injection policy with invalid selector", "policy", policy.Name, "namespace", policy.Namespace, "error", err.Error())
+
+			return false, nil
+		}
+
+		if !selector.Matches(labels.Set(pod.Labels)) {
+			return false, nil
+		}
+	}
+
+	if len(policy.Spec.OwnerKinds) == 0 {
+		return true, nil
+	}
+
+	kind, err := resolver.kind(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(policy.Spec.OwnerKinds, func(ownerKind string) bool {
+		return strings.EqualFold(ownerKind, kind)
+	}), nil
+}
+
+// ownerResolver looks up the kind of the workload owning the pod once and only if a policy needs it.
+type ownerResolver struct {
+	reader    client.Reader
+	pod       *corev1.Pod
+	namespace string
+	ownerKind string
+}
+
+func (resolver *ownerResolver) kind(ctx context.Context) (string, error) {
+	if resolver.ownerKind != "" {
+		return resolver.ownerKind, nil
+	}
+
+	kind, err := rootOwnerKind(ctx, resolver.reader, resolver.pod, resolver.namespace)
+	if err != nil {
+		return "", err
+	}
+
+	resolver.ownerKind = kind
+
+	return kind, nil
+}
+
+// rootOwnerKind returns the kind of the workload owning the pod in lower case, e.g. "deployment", or "pod" if it isn't owned by a workload.
+func rootOwnerKind(ctx context.Context, reader client.Reader, pod *corev1.Pod, namespace string) (string, error) {
+	kind, err := metadata.FindWorkloadKind(ctx, reader, pod, namespace)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to find the workload of the pod")
+	}
+
+	// the kind of the pod itself is only set if its TypeMeta is, which is not the case for listed pods
+	if kind == "" {
+		kind = strings.ToLower(PodKind)
+	}
+
+	return kind, nil
+}
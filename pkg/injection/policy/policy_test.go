@@ -0,0 +1,193 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/injectionpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testNamespace  = "test-namespace"
+	testPodName    = "test-pod"
+	testDeployment = "test-deployment"
+	testReplicaSet = "test-deployment-abc"
+)
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no policy", func(t *testing.T) {
+		clt := fake.NewClient()
+
+		resolved, err := Resolve(ctx, clt, createTestPod(nil), testNamespace)
+		require.NoError(t, err)
+		assert.Nil(t, resolved)
+	})
+	t.Run("policy without selector selects all pods", func(t *testing.T) {
+		clt := fake.NewClient(createTestPolicy("all", nil, nil))
+
+		resolved, err := Resolve(ctx, clt, createTestPod(nil), testNamespace)
+		require.NoError(t, err)
+		require.NotNil(t, resolved)
+		assert.Equal(t, "all", resolved.Name)
+	})
+	t.Run("policies of other namespaces are ignored", func(t *testing.T) {
+		otherPolicy := createTestPolicy("all", nil, nil)
+		otherPolicy.Namespace = "other"
+		clt := fake.NewClient(otherPolicy)
+
+		resolved, err := Resolve(ctx, clt, createTestPod(nil), testNamespace)
+		require.NoError(t, err)
+		assert.Nil(t, resolved)
+	})
+	t.Run("label selector", func(t *testing.T) {
+		clt := fake.NewClient(
+			createTestPolicy("a-frontend", map[string]string{"app": "frontend"}, nil),
+			createTestPolicy("b-backend", map[string]string{"app": "backend"}, nil),
+		)
+
+		resolved, err := Resolve(ctx, clt, createTestPod(map[string]string{"app": "backend"}), testNamespace)
+		require.NoError(t, err)
+		require.NotNil(t, resolved)
+		assert.Equal(t, "b-backend", resolved.Name)
+
+		resolved, err = Resolve(ctx, clt, createTestPod(map[string]string{"app": "other"}), testNamespace)
+		require.NoError(t, err)
+		assert.Nil(t, resolved)
+	})
+	t.Run("first policy by name wins", func(t *testing.T) {
+		clt := fake.NewClient(
+			createTestPolicy("b", nil, nil),
+			createTestPolicy("a", nil, nil),
+		)
+
+		resolved, err := Resolve(ctx, clt, createTestPod(nil), testNamespace)
+		require.NoError(t, err)
+		require.NotNil(t, resolved)
+		assert.Equal(t, "a", resolved.Name)
+	})
+	t.Run("owner kind of pod without owner", func(t *testing.T) {
+		clt := fake.NewClient(
+			createTestPolicy("deployments", nil, []string{"Deployment"}),
+			createTestPolicy("pods", nil, []string{PodKind}),
+		)
+
+		resolved, err := Resolve(ctx, clt, createTestPod(nil), testNamespace)
+		require.NoError(t, err)
+		require.NotNil(t, resolved)
+		assert.Equal(t, "pods", resolved.Name)
+	})
+	t.Run("owner kind is resolved through the replicaset", func(t *testing.T) {
+		pod := createTestPod(nil)
+		pod.OwnerReferences = []metav1.OwnerReference{createControllerReference("apps/v1", "ReplicaSet", testReplicaSet)}
+
+		replicaSet := &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            testReplicaSet,
+				Namespace:       testNamespace,
+				OwnerReferences: []metav1.OwnerReference{createControllerReference("apps/v1", "Deployment", testDeployment)},
+			},
+		}
+
+		clt := fake.NewClient(
+			createTestPolicy("a-statefulsets", nil, []string{"StatefulSet"}),
+			createTestPolicy("b-deployments", nil, []string{"Deployment"}),
+			replicaSet,
+			createTestDeployment(),
+		)
+
+		resolved, err := Resolve(ctx, clt, pod, testNamespace)
+		require.NoError(t, err)
+		require.NotNil(t, resolved)
+		assert.Equal(t, "b-deployments", resolved.Name)
+	})
+	t.Run("owner kinds are matched case-insensitively", func(t *testing.T) {
+		pod := createTestPod(nil)
+		pod.OwnerReferences = []metav1.OwnerReference{createControllerReference("apps/v1", "Deployment", testDeployment)}
+
+		clt := fake.NewClient(
+			createTestPolicy("deployments", nil, []string{"deployment"}),
+			createTestDeployment(),
+		)
+
+		resolved, err := Resolve(ctx, clt, pod, testNamespace)
+		require.NoError(t, err)
+		require.NotNil(t, resolved)
+		assert.Equal(t, "deployments", resolved.Name)
+	})
+	t.Run("missing owner fails", func(t *testing.T) {
+		pod := createTestPod(nil)
+		pod.OwnerReferences = []metav1.OwnerReference{createControllerReference("apps/v1", "ReplicaSet", testReplicaSet)}
+
+		clt := fake.NewClient(createTestPolicy("deployments", nil, []string{"Deployment"}))
+
+		_, err := Resolve(ctx, clt, pod, testNamespace)
+		require.Error(t, err)
+	})
+	t.Run("invalid selector is ignored", func(t *testing.T) {
+		invalidPolicy := createTestPolicy("a-invalid", nil, nil)
+		invalidPolicy.Spec.Selector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "invalid"}},
+		}
+
+		clt := fake.NewClient(invalidPolicy, createTestPolicy("b-valid", nil, nil))
+
+		resolved, err := Resolve(ctx, clt, createTestPod(nil), testNamespace)
+		require.NoError(t, err)
+		require.NotNil(t, resolved)
+		assert.Equal(t, "b-valid", resolved.Name)
+	})
+}
+
+func createTestPolicy(name string, matchLabels map[string]string, ownerKinds []string) *injectionpolicy.InjectionPolicy {
+	policy := &injectionpolicy.InjectionPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Spec: injectionpolicy.InjectionPolicySpec{
+			OwnerKinds: ownerKinds,
+		},
+	}
+
+	if matchLabels != nil {
+		policy.Spec.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+
+	return policy
+}
+
+func createTestPod(labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   testPodName,
+			Labels: labels,
+		},
+	}
+}
+
+func createTestDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDeployment,
+			Namespace: testNamespace,
+		},
+	}
+}
+
+func createControllerReference(apiVersion, kind, name string) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+		Controller: ptr.To(true),
+	}
+}
@@ -26,6 +26,7 @@ type environment struct {
 	InstallerUrl  string `json:"installerUrl"`
 
 	InstallerFlavor string `json:"installerFlavor"`
+	InstallerArch   string `json:"installerArch"`
 	InstallVersion  string `json:"installVersion"`
 	InstallPath     string `json:"installPath"`
 
@@ -123,6 +124,7 @@ func (env *environment) getMetadataEnrichmentFieldSetters() []func() error {
 
 func (env *environment) setOptionalFields() {
 	env.addInstallerUrl()
+	env.addInstallerArch()
 	env.addInstallerFlavor()
 	env.addInstallVersion()
 	env.addClusterName()
@@ -150,10 +152,20 @@ func (env *environment) addFailurePolicy() error {
 	return nil
 }
 
+// addInstallerArch uses the architecture of the node if the webhook could determine it, otherwise the architecture of this binary.
+func (env *environment) addInstallerArch() {
+	imageArch, _ := checkEnvVar(consts.AgentInstallerArchEnv)
+	if arch.IsSupported(imageArch) {
+		env.InstallerArch = imageArch
+	} else {
+		env.InstallerArch = arch.ImageArch
+	}
+}
+
 func (env *environment) addInstallerFlavor() {
 	flavor, _ := checkEnvVar(consts.AgentInstallerFlavorEnv)
 	if flavor == "" {
-		env.InstallerFlavor = arch.Flavor
+		env.InstallerFlavor = arch.FlavorFor(env.InstallerArch)
 	} else {
 		env.InstallerFlavor = flavor
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,6 +51,7 @@ func TestNewEnv(t *testing.T) {
 
 		assert.Equal(t, failPhrase, env.FailurePolicy)
 		assert.NotEmpty(t, env.InstallerFlavor) // set to what is defined in arch.Flavor
+		assert.Equal(t, arch.ImageArch, env.InstallerArch)
 		assert.Empty(t, env.InstallerTech)
 		assert.Empty(t, env.InstallVersion)
 		assert.Empty(t, env.InstallPath)
@@ -124,6 +126,46 @@ func TestFailurePolicyModes(t *testing.T) {
 	}
 }
 
+func TestAddInstallerArch(t *testing.T) {
+	t.Run("architecture from the webhook is used", func(t *testing.T) {
+		t.Setenv(consts.AgentInstallerArchEnv, arch.S390Image)
+
+		env := &environment{}
+		env.addInstallerArch()
+		env.addInstallerFlavor()
+
+		assert.Equal(t, arch.S390Image, env.InstallerArch)
+		assert.Equal(t, arch.FlavorDefault, env.InstallerFlavor)
+	})
+	t.Run("multidistro flavor for amd64", func(t *testing.T) {
+		t.Setenv(consts.AgentInstallerArchEnv, arch.AMDImage)
+
+		env := &environment{}
+		env.addInstallerArch()
+		env.addInstallerFlavor()
+
+		assert.Equal(t, arch.FlavorMultidistro, env.InstallerFlavor)
+	})
+	t.Run("flavor from the webhook takes precedence", func(t *testing.T) {
+		t.Setenv(consts.AgentInstallerArchEnv, arch.AMDImage)
+		t.Setenv(consts.AgentInstallerFlavorEnv, arch.FlavorDefault)
+
+		env := &environment{}
+		env.addInstallerArch()
+		env.addInstallerFlavor()
+
+		assert.Equal(t, arch.FlavorDefault, env.InstallerFlavor)
+	})
+	t.Run("unknown or unsupported architecture => architecture of the binary", func(t *testing.T) {
+		t.Setenv(consts.AgentInstallerArchEnv, "riscv64")
+
+		env := &environment{}
+		env.addInstallerArch()
+
+		assert.Equal(t, arch.ImageArch, env.InstallerArch)
+	})
+}
+
 func prepCombinedTestEnv(t *testing.T) {
 	prepMetadataEnrichmentTestEnv(t, false)
 	prepOneAgentTestEnv(t)
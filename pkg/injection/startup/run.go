@@ -79,7 +79,7 @@ func NewRunner(fs afero.Fs) (*Runner, error) {
 				Os:            dtclient.OsUnix,
 				Type:          dtclient.InstallerTypePaaS,
 				Flavor:        env.InstallerFlavor,
-				Arch:          arch.Arch,
+				Arch:          arch.DynatraceArch(env.InstallerArch),
 				Technologies:  env.InstallerTech,
 				TargetVersion: targetVersion,
 				Url:           env.InstallerUrl,
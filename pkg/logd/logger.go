@@ -18,6 +18,7 @@ const (
 
 var (
 	baseLogger     Logger
+	baseWriter     *prettyLogWriter
 	baseLoggerOnce sync.Once
 )
 
@@ -26,12 +27,21 @@ var (
 func Get() Logger {
 	baseLoggerOnce.Do(func() {
 		logLevel := readLogLevelFromEnv()
-		baseLogger = createLogger(NewPrettyLogWriter(), logLevel)
+		baseWriter = &prettyLogWriter{out: os.Stdout}
+		baseLogger = createLogger(baseWriter, logLevel)
 	})
 
 	return baseLogger
 }
 
+// SetOutput redirects the output of the base logger and therefore of every logger derived from it, which is needed
+// by commands that use stdout for their actual output.
+func SetOutput(out io.Writer) {
+	Get()
+
+	baseWriter.out = out
+}
+
 func LogBaseLoggerSettings() {
 	logLevel := readLogLevelFromEnv()
 	baseLogger.Info("logging level", "logLevel", logLevel.String())
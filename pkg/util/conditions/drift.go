@@ -0,0 +1,63 @@
+package conditions
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DriftConditionType  = "Drift"
+	DriftDetectedReason = "DriftDetected"
+	DriftRevertedReason = "DriftReverted"
+
+	maxDriftedFields = 10
+	driftSeparator   = "; "
+)
+
+// Drift describes the fields of an object that were changed outside the operator.
+type Drift struct {
+	Kind     string
+	Name     string
+	Fields   []string
+	Reverted bool
+}
+
+// SetDrift sets the Drift condition to the given drift, or removes it if there is none.
+// An existing condition keeps its LastTransitionTime, so unchanged drift doesn't change the status.
+func SetDrift(conditions *[]metav1.Condition, drifts []Drift) {
+	if len(drifts) == 0 {
+		meta.RemoveStatusCondition(conditions, DriftConditionType)
+
+		return
+	}
+
+	messages := make([]string, 0, len(drifts))
+	reason := DriftRevertedReason
+
+	for _, drift := range drifts {
+		messages = append(messages, fmt.Sprintf("%s %s: %s", drift.Kind, drift.Name, formatDriftedFields(drift.Fields)))
+
+		if !drift.Reverted {
+			reason = DriftDetectedReason
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:    DriftConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: strings.Join(messages, driftSeparator),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
+
+func formatDriftedFields(fields []string) string {
+	if len(fields) <= maxDriftedFields {
+		return strings.Join(fields, ", ")
+	}
+
+	return fmt.Sprintf("%s and %d more", strings.Join(fields[:maxDriftedFields], ", "), len(fields)-maxDriftedFields)
+}
@@ -0,0 +1,83 @@
+package conditions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetDrift(t *testing.T) {
+	t.Run("single object", func(t *testing.T) {
+		conditions := []metav1.Condition{}
+
+		SetDrift(&conditions, []Drift{{Kind: "DaemonSet", Name: "ds", Fields: []string{"a", "b"}}})
+
+		condition := meta.FindStatusCondition(conditions, DriftConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, DriftDetectedReason, condition.Reason)
+		assert.Equal(t, "DaemonSet ds: a, b", condition.Message)
+	})
+	t.Run("multiple objects are merged", func(t *testing.T) {
+		conditions := []metav1.Condition{}
+
+		SetDrift(&conditions, []Drift{
+			{Kind: "DaemonSet", Name: "ds", Fields: []string{"a"}, Reverted: true},
+			{Kind: "StatefulSet", Name: "sts", Fields: []string{"b"}, Reverted: true},
+		})
+
+		condition := meta.FindStatusCondition(conditions, DriftConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, DriftRevertedReason, condition.Reason)
+		assert.Equal(t, "DaemonSet ds: a; StatefulSet sts: b", condition.Message)
+	})
+	t.Run("detected drift takes precedence over reverted drift", func(t *testing.T) {
+		conditions := []metav1.Condition{}
+
+		SetDrift(&conditions, []Drift{
+			{Kind: "DaemonSet", Name: "ds", Fields: []string{"a"}, Reverted: true},
+			{Kind: "StatefulSet", Name: "sts", Fields: []string{"b"}},
+		})
+
+		condition := meta.FindStatusCondition(conditions, DriftConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, DriftDetectedReason, condition.Reason)
+	})
+	t.Run("too many fields are cut", func(t *testing.T) {
+		conditions := []metav1.Condition{}
+		fields := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}
+
+		SetDrift(&conditions, []Drift{{Kind: "DaemonSet", Name: "ds", Fields: fields}})
+
+		condition := meta.FindStatusCondition(conditions, DriftConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, "DaemonSet ds: 1, 2, 3, 4, 5, 6, 7, 8, 9, 10 and 2 more", condition.Message)
+	})
+	t.Run("unchanged drift keeps the transition time", func(t *testing.T) {
+		transitionTime := metav1.NewTime(time.Now().Add(-time.Hour))
+		conditions := []metav1.Condition{{
+			Type:               DriftConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             DriftDetectedReason,
+			Message:            "DaemonSet ds: a",
+			LastTransitionTime: transitionTime,
+		}}
+
+		SetDrift(&conditions, []Drift{{Kind: "DaemonSet", Name: "ds", Fields: []string{"a"}}})
+
+		require.Len(t, conditions, 1)
+		assert.Equal(t, transitionTime, conditions[0].LastTransitionTime)
+	})
+	t.Run("no drift removes the condition", func(t *testing.T) {
+		conditions := []metav1.Condition{}
+		SetDrift(&conditions, []Drift{{Kind: "DaemonSet", Name: "ds", Fields: []string{"a"}}})
+
+		SetDrift(&conditions, nil)
+
+		assert.Empty(t, conditions)
+	})
+}
@@ -0,0 +1,31 @@
+package conditions
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	HorizontalPodAutoscalerCreatedReason    = "HorizontalPodAutoscalerCreated"
+	HorizontalPodAutoscalerGenerationFailed = "HorizontalPodAutoscalerGenerationFailed"
+)
+
+func SetHorizontalPodAutoscalerCreated(conditions *[]metav1.Condition, conditionType, name string) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  HorizontalPodAutoscalerCreatedReason,
+		Message: appendCreatedSuffix(name),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
+
+func SetHorizontalPodAutoscalerGenFailed(conditions *[]metav1.Condition, conditionType string, err error) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  HorizontalPodAutoscalerGenerationFailed,
+		Message: "Failed to generate horizontal pod autoscaler: " + err.Error(),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
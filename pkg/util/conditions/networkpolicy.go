@@ -0,0 +1,33 @@
+package conditions
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	NetworkPoliciesCreatedReason    = "NetworkPoliciesCreated"
+	NetworkPoliciesGenerationFailed = "NetworkPoliciesGenerationFailed"
+)
+
+func SetNetworkPoliciesCreated(conditions *[]metav1.Condition, conditionType string, names []string) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  NetworkPoliciesCreatedReason,
+		Message: appendCreatedSuffix(strings.Join(names, ", ")),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
+
+func SetNetworkPoliciesGenFailed(conditions *[]metav1.Condition, conditionType string, err error) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  NetworkPoliciesGenerationFailed,
+		Message: "Failed to generate network policies: " + err.Error(),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
@@ -0,0 +1,31 @@
+package conditions
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	PodDisruptionBudgetCreatedReason    = "PodDisruptionBudgetCreated"
+	PodDisruptionBudgetGenerationFailed = "PodDisruptionBudgetGenerationFailed"
+)
+
+func SetPodDisruptionBudgetCreated(conditions *[]metav1.Condition, conditionType, name string) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  PodDisruptionBudgetCreatedReason,
+		Message: appendCreatedSuffix(name),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
+
+func SetPodDisruptionBudgetGenFailed(conditions *[]metav1.Condition, conditionType string, err error) {
+	condition := metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  PodDisruptionBudgetGenerationFailed,
+		Message: "Failed to generate pod disruption budget: " + err.Error(),
+	}
+	_ = meta.SetStatusCondition(conditions, condition)
+}
@@ -84,6 +84,51 @@ func TestCreateOrUpdateDaemonSet(t *testing.T) {
 		assert.Equal(t, newDaemonSet, *ds)
 		assert.Equal(t, newMatchLabels, ds.Spec.Selector.MatchLabels)
 	})
+	t.Run("report drift when hash matches but live object changed", func(t *testing.T) {
+		annotations := map[string]string{hasher.AnnotationHash: "same"}
+		liveDaemonSet := createTestDaemonSetWithMatchLabels(daemonsetName, namespaceName, annotations, nil)
+		liveDaemonSet.Spec.Template.Spec.NodeName = "edited"
+		desiredDaemonSet := createTestDaemonSetWithMatchLabels(daemonsetName, namespaceName, annotations, nil)
+		desiredDaemonSet.Spec.Template.Spec.NodeName = "desired"
+		fakeClient := fake.NewClient(&liveDaemonSet)
+
+		var reportedFields []string
+
+		updated, err := Query(fakeClient, fakeClient, daemonSetLog).
+			WithDriftHandler(func(_ client.Object, fields []string) bool {
+				reportedFields = fields
+
+				return false
+			}).
+			CreateOrUpdate(ctx, &desiredDaemonSet)
+		require.NoError(t, err)
+		require.False(t, updated)
+		assert.Equal(t, []string{"spec.template.spec.nodeName"}, reportedFields)
+
+		ds, err := Query(fakeClient, fakeClient, daemonSetLog).Get(ctx, client.ObjectKeyFromObject(&liveDaemonSet))
+		require.NoError(t, err)
+		assert.Equal(t, "edited", ds.Spec.Template.Spec.NodeName)
+	})
+	t.Run("revert drift when hash matches but live object changed", func(t *testing.T) {
+		annotations := map[string]string{hasher.AnnotationHash: "same"}
+		liveDaemonSet := createTestDaemonSetWithMatchLabels(daemonsetName, namespaceName, annotations, nil)
+		liveDaemonSet.Spec.Template.Spec.NodeName = "edited"
+		desiredDaemonSet := createTestDaemonSetWithMatchLabels(daemonsetName, namespaceName, annotations, nil)
+		desiredDaemonSet.Spec.Template.Spec.NodeName = "desired"
+		fakeClient := fake.NewClient(&liveDaemonSet)
+
+		updated, err := Query(fakeClient, fakeClient, daemonSetLog).
+			WithDriftHandler(func(_ client.Object, _ []string) bool {
+				return true
+			}).
+			CreateOrUpdate(ctx, &desiredDaemonSet)
+		require.NoError(t, err)
+		require.True(t, updated)
+
+		ds, err := Query(fakeClient, fakeClient, daemonSetLog).Get(ctx, client.ObjectKeyFromObject(&liveDaemonSet))
+		require.NoError(t, err)
+		assert.Equal(t, "desired", ds.Spec.Template.Spec.NodeName)
+	})
 }
 
 func createTestDaemonSetWithMatchLabels(name, namespace string, annotations, matchLabels map[string]string) appsv1.DaemonSet {
@@ -0,0 +1,92 @@
+package drift
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// comparedPath is the part of the objects that is compared, everything outside of it (e.g. the replicas managed by an HPA)
+// is allowed to be changed by others.
+var comparedPath = []string{"spec", "template"}
+
+// Fields returns the paths of the fields below spec.template that are set on desired but have a different value on current.
+// Fields that are only present on current are ignored, as the api-server fills in defaults for most of them,
+// but lists have to match in length, so added or removed entries (e.g. env vars) are detected.
+func Fields(current, desired client.Object) ([]string, error) {
+	currentContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(current)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	desiredContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var currentValue, desiredValue any = currentContent, desiredContent
+
+	for _, key := range comparedPath {
+		currentValue = childOf(currentValue, key)
+		desiredValue = childOf(desiredValue, key)
+	}
+
+	var fields []string
+
+	compare(strings.Join(comparedPath, "."), currentValue, desiredValue, &fields)
+
+	return fields, nil
+}
+
+func compare(path string, current, desired any, fields *[]string) {
+	switch typedDesired := desired.(type) {
+	case nil:
+		return
+	case map[string]any:
+		typedCurrent, ok := current.(map[string]any)
+		if !ok {
+			*fields = append(*fields, path)
+
+			return
+		}
+
+		keys := make([]string, 0, len(typedDesired))
+		for key := range typedDesired {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			compare(path+"."+key, typedCurrent[key], typedDesired[key], fields)
+		}
+	case []any:
+		typedCurrent, ok := current.([]any)
+		if !ok || len(typedCurrent) != len(typedDesired) {
+			*fields = append(*fields, path)
+
+			return
+		}
+
+		for i := range typedDesired {
+			compare(path+"["+strconv.Itoa(i)+"]", typedCurrent[i], typedDesired[i], fields)
+		}
+	default:
+		if !reflect.DeepEqual(current, desired) {
+			*fields = append(*fields, path)
+		}
+	}
+}
+
+func childOf(value any, key string) any {
+	if typed, ok := value.(map[string]any); ok {
+		return typed[key]
+	}
+
+	return nil
+}
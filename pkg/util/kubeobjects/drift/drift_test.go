@@ -0,0 +1,77 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func createDaemonSet(image string, env ...corev1.EnvVar) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "test",
+							Image: image,
+							Env:   env,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestFields(t *testing.T) {
+	t.Run("no drift", func(t *testing.T) {
+		fields, err := Fields(createDaemonSet("image:1"), createDaemonSet("image:1"))
+		require.NoError(t, err)
+		assert.Empty(t, fields)
+	})
+	t.Run("defaults set by the api-server are ignored", func(t *testing.T) {
+		current := createDaemonSet("image:1")
+		current.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+		current.Spec.Template.Spec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+		current.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+
+		fields, err := Fields(current, createDaemonSet("image:1"))
+		require.NoError(t, err)
+		assert.Empty(t, fields)
+	})
+	t.Run("changes outside of the template are ignored", func(t *testing.T) {
+		current := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: ptr.To(int32(3))}}
+		desired := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))}}
+
+		fields, err := Fields(current, desired)
+		require.NoError(t, err)
+		assert.Empty(t, fields)
+	})
+	t.Run("changed value", func(t *testing.T) {
+		fields, err := Fields(createDaemonSet("image:2"), createDaemonSet("image:1"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"spec.template.spec.containers[0].image"}, fields)
+	})
+	t.Run("added list entry", func(t *testing.T) {
+		current := createDaemonSet("image:1", corev1.EnvVar{Name: "A", Value: "a"}, corev1.EnvVar{Name: "B", Value: "b"})
+		desired := createDaemonSet("image:1", corev1.EnvVar{Name: "A", Value: "a"})
+
+		fields, err := Fields(current, desired)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"spec.template.spec.containers[0].env"}, fields)
+	})
+	t.Run("removed field", func(t *testing.T) {
+		current := createDaemonSet("image:1")
+		desired := createDaemonSet("image:1")
+		desired.Spec.Template.Spec.NodeSelector = map[string]string{"key": "value"}
+
+		fields, err := Fields(current, desired)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"spec.template.spec.nodeSelector"}, fields)
+	})
+}
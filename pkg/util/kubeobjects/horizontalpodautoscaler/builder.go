@@ -0,0 +1,62 @@
+package horizontalpodautoscaler
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/internal/builder"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// Mandatory fields, provided in constructor as named params
+	setName      = builder.SetName[*autoscalingv2.HorizontalPodAutoscaler]
+	setNamespace = builder.SetNamespace[*autoscalingv2.HorizontalPodAutoscaler]
+
+	// Optional fields, provided in constructor as list of options
+	SetLabels = builder.SetLabels[*autoscalingv2.HorizontalPodAutoscaler]
+)
+
+// Build creates a HorizontalPodAutoscaler scaling the given target, the target has to be in the namespace of the owner.
+func Build(owner metav1.Object, name string, target autoscalingv2.CrossVersionObjectReference, options ...builder.Option[*autoscalingv2.HorizontalPodAutoscaler]) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	neededOpts := []builder.Option[*autoscalingv2.HorizontalPodAutoscaler]{
+		setName(name),
+		setScaleTargetRef(target),
+		setNamespace(owner.GetNamespace()),
+	}
+	neededOpts = append(neededOpts, options...)
+
+	return builder.Build(owner, &autoscalingv2.HorizontalPodAutoscaler{}, neededOpts...)
+}
+
+func setScaleTargetRef(target autoscalingv2.CrossVersionObjectReference) builder.Option[*autoscalingv2.HorizontalPodAutoscaler] {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.ScaleTargetRef = target
+	}
+}
+
+func SetReplicas(minReplicas, maxReplicas int32) builder.Option[*autoscalingv2.HorizontalPodAutoscaler] {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.MinReplicas = &minReplicas
+		h.Spec.MaxReplicas = maxReplicas
+	}
+}
+
+// SetResourceUtilization adds a metric targeting the average utilization of the given resource, a nil target is ignored.
+func SetResourceUtilization(resource corev1.ResourceName, targetUtilization *int32) builder.Option[*autoscalingv2.HorizontalPodAutoscaler] {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		if targetUtilization == nil {
+			return
+		}
+
+		h.Spec.Metrics = append(h.Spec.Metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: resource,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: targetUtilization,
+				},
+			},
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package horizontalpodautoscaler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testOwnerName = "statefulset-as-owner-of-hpa"
+	testHPAName   = "test-hpa-name"
+	testNamespace = "test-namespace"
+)
+
+func createStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testOwnerName,
+			Namespace: testNamespace,
+		},
+	}
+}
+
+func TestHorizontalPodAutoscalerBuilder(t *testing.T) {
+	target := autoscalingv2.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Name:       testOwnerName,
+	}
+
+	t.Run("create horizontal pod autoscaler", func(t *testing.T) {
+		hpa, err := Build(createStatefulSet(), testHPAName, target, SetReplicas(2, 5))
+		require.NoError(t, err)
+		require.Len(t, hpa.OwnerReferences, 1)
+		assert.Equal(t, testOwnerName, hpa.OwnerReferences[0].Name)
+		assert.Equal(t, testHPAName, hpa.Name)
+		assert.Equal(t, testNamespace, hpa.Namespace)
+		assert.Equal(t, target, hpa.Spec.ScaleTargetRef)
+		assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		assert.Empty(t, hpa.Spec.Metrics)
+	})
+	t.Run("create horizontal pod autoscaler with resource metrics", func(t *testing.T) {
+		hpa, err := Build(createStatefulSet(), testHPAName, target,
+			SetResourceUtilization(corev1.ResourceCPU, ptr.To(int32(80))),
+			SetResourceUtilization(corev1.ResourceMemory, nil),
+		)
+		require.NoError(t, err)
+		require.Len(t, hpa.Spec.Metrics, 1)
+		assert.Equal(t, autoscalingv2.ResourceMetricSourceType, hpa.Spec.Metrics[0].Type)
+		assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+		assert.Equal(t, int32(80), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/drift"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -23,6 +24,10 @@ type Generic[T client.Object, L client.ObjectList] struct {
 	IsEqual      func(T, T) bool
 	MustRecreate func(T, T) bool
 
+	// OnDrift is called with the drifted fields, if the live object differs from the desired one although its hash matches.
+	// Its result decides if the drift gets reverted.
+	OnDrift func(object client.Object, fields []string) bool
+
 	Owner      client.Object
 	KubeClient client.Client
 	KubeReader client.Reader
@@ -35,6 +40,12 @@ func (c Generic[T, L]) WithOwner(owner client.Object) Generic[T, L] {
 	return c
 }
 
+func (c Generic[T, L]) WithDriftHandler(onDrift func(object client.Object, fields []string) bool) Generic[T, L] {
+	c.OnDrift = onDrift
+
+	return c
+}
+
 func (c Generic[T, L]) Get(ctx context.Context, objectKey client.ObjectKey) (T, error) {
 	err := c.KubeReader.Get(ctx, objectKey, c.Target)
 
@@ -105,9 +116,13 @@ func (c Generic[T, L]) CreateOrUpdate(ctx context.Context, newObject T) (bool, e
 	}
 
 	if c.IsEqual(currentObject, newObject) {
-		c.log(newObject).Info("update not needed, no changes detected")
+		if !c.mustRevertDrift(currentObject, newObject) {
+			c.log(newObject).Info("update not needed, no changes detected")
+
+			return false, nil
+		}
 
-		return false, nil
+		c.log(newObject).Info("reverting drift, changes to the live object detected")
 	}
 
 	if c.MustRecreate(currentObject, newObject) {
@@ -132,6 +147,25 @@ func (c Generic[T, L]) CreateOrUpdate(ctx context.Context, newObject T) (bool, e
 	return true, nil
 }
 
+func (c Generic[T, L]) mustRevertDrift(currentObject, newObject T) bool {
+	if c.OnDrift == nil {
+		return false
+	}
+
+	fields, err := drift.Fields(currentObject, newObject)
+	if err != nil {
+		c.log(newObject).Error(err, "failed to detect drift")
+
+		return false
+	} else if len(fields) == 0 {
+		return false
+	}
+
+	c.log(newObject).Info("drift detected", "fields", fields)
+
+	return c.OnDrift(currentObject, fields)
+}
+
 func (c Generic[T, L]) Recreate(ctx context.Context, object T) error {
 	err := c.Delete(ctx, object)
 	if err != nil {
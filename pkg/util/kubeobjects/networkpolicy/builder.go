@@ -0,0 +1,50 @@
+package networkpolicy
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/internal/builder"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	// Mandatory fields, provided in constructor as named params
+	setName      = builder.SetName[*networkingv1.NetworkPolicy]
+	setNamespace = builder.SetNamespace[*networkingv1.NetworkPolicy]
+
+	// Optional fields, provided in constructor as list of options
+	SetLabels = builder.SetLabels[*networkingv1.NetworkPolicy]
+)
+
+// Build creates a NetworkPolicy for the pods matching the selector labels, that denies all traffic not allowed by the given rules.
+func Build(owner metav1.Object, name string, selectorLabels map[string]string, options ...builder.Option[*networkingv1.NetworkPolicy]) (*networkingv1.NetworkPolicy, error) {
+	neededOpts := []builder.Option[*networkingv1.NetworkPolicy]{
+		setName(name),
+		setPodSelector(selectorLabels),
+		setNamespace(owner.GetNamespace()),
+	}
+	neededOpts = append(neededOpts, options...)
+
+	return builder.Build(owner, &networkingv1.NetworkPolicy{}, neededOpts...)
+}
+
+func setPodSelector(labels map[string]string) builder.Option[*networkingv1.NetworkPolicy] {
+	return func(n *networkingv1.NetworkPolicy) {
+		n.Spec.PodSelector = metav1.LabelSelector{MatchLabels: labels}
+	}
+}
+
+// SetIngress restricts the ingress traffic to the given rules, no rules deny all ingress traffic.
+func SetIngress(rules []networkingv1.NetworkPolicyIngressRule) builder.Option[*networkingv1.NetworkPolicy] {
+	return func(n *networkingv1.NetworkPolicy) {
+		n.Spec.Ingress = rules
+		n.Spec.PolicyTypes = append(n.Spec.PolicyTypes, networkingv1.PolicyTypeIngress)
+	}
+}
+
+// SetEgress restricts the egress traffic to the given rules, no rules deny all egress traffic.
+func SetEgress(rules []networkingv1.NetworkPolicyEgressRule) builder.Option[*networkingv1.NetworkPolicy] {
+	return func(n *networkingv1.NetworkPolicy) {
+		n.Spec.Egress = rules
+		n.Spec.PolicyTypes = append(n.Spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+	}
+}
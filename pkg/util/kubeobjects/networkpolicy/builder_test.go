@@ -0,0 +1,56 @@
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	testOwnerName         = "statefulset-as-owner-of-network-policy"
+	testNetworkPolicyName = "test-network-policy-name"
+	testNamespace         = "test-namespace"
+)
+
+func createStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testOwnerName,
+			Namespace: testNamespace,
+		},
+	}
+}
+
+func TestNetworkPolicyBuilder(t *testing.T) {
+	selectorLabels := map[string]string{
+		"name": "value",
+	}
+
+	t.Run("create network policy", func(t *testing.T) {
+		networkPolicy, err := Build(createStatefulSet(), testNetworkPolicyName, selectorLabels)
+		require.NoError(t, err)
+		require.Len(t, networkPolicy.OwnerReferences, 1)
+		assert.Equal(t, testOwnerName, networkPolicy.OwnerReferences[0].Name)
+		assert.Equal(t, testNetworkPolicyName, networkPolicy.Name)
+		assert.Equal(t, testNamespace, networkPolicy.Namespace)
+		assert.Equal(t, selectorLabels, networkPolicy.Spec.PodSelector.MatchLabels)
+		assert.Empty(t, networkPolicy.Spec.PolicyTypes)
+	})
+	t.Run("create network policy with ingress and egress", func(t *testing.T) {
+		port := intstr.FromString("https")
+		ingress := []networkingv1.NetworkPolicyIngressRule{
+			{Ports: []networkingv1.NetworkPolicyPort{{Port: &port}}},
+		}
+
+		networkPolicy, err := Build(createStatefulSet(), testNetworkPolicyName, selectorLabels, SetIngress(ingress), SetEgress(nil))
+		require.NoError(t, err)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, networkPolicy.Spec.PolicyTypes)
+		assert.Equal(t, ingress, networkPolicy.Spec.Ingress)
+		assert.Empty(t, networkPolicy.Spec.Egress)
+	})
+}
@@ -0,0 +1,46 @@
+package poddisruptionbudget
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/internal/builder"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var (
+	// Mandatory fields, provided in constructor as named params
+	setName      = builder.SetName[*policyv1.PodDisruptionBudget]
+	setNamespace = builder.SetNamespace[*policyv1.PodDisruptionBudget]
+
+	// Optional fields, provided in constructor as list of options
+	SetLabels = builder.SetLabels[*policyv1.PodDisruptionBudget]
+)
+
+func Build(owner metav1.Object, name string, selectorLabels map[string]string, options ...builder.Option[*policyv1.PodDisruptionBudget]) (*policyv1.PodDisruptionBudget, error) {
+	neededOpts := []builder.Option[*policyv1.PodDisruptionBudget]{
+		setName(name),
+		setSelectorLabels(selectorLabels),
+		setNamespace(owner.GetNamespace()),
+	}
+	neededOpts = append(neededOpts, options...)
+
+	return builder.Build(owner, &policyv1.PodDisruptionBudget{}, neededOpts...)
+}
+
+func setSelectorLabels(labels map[string]string) builder.Option[*policyv1.PodDisruptionBudget] {
+	return func(p *policyv1.PodDisruptionBudget) {
+		p.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	}
+}
+
+func SetMinAvailable(minAvailable *intstr.IntOrString) builder.Option[*policyv1.PodDisruptionBudget] {
+	return func(p *policyv1.PodDisruptionBudget) {
+		p.Spec.MinAvailable = minAvailable
+	}
+}
+
+func SetMaxUnavailable(maxUnavailable *intstr.IntOrString) builder.Option[*policyv1.PodDisruptionBudget] {
+	return func(p *policyv1.PodDisruptionBudget) {
+		p.Spec.MaxUnavailable = maxUnavailable
+	}
+}
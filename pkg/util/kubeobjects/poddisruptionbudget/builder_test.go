@@ -0,0 +1,60 @@
+package poddisruptionbudget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	testOwnerName = "statefulset-as-owner-of-pdb"
+	testPDBName   = "test-pdb-name"
+	testNamespace = "test-namespace"
+)
+
+func createStatefulSet() *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testOwnerName,
+			Namespace: testNamespace,
+		},
+	}
+}
+
+func TestPodDisruptionBudgetBuilder(t *testing.T) {
+	selectorLabels := map[string]string{
+		"name": "value",
+	}
+
+	t.Run("create pod disruption budget", func(t *testing.T) {
+		pdb, err := Build(createStatefulSet(), testPDBName, selectorLabels)
+		require.NoError(t, err)
+		require.Len(t, pdb.OwnerReferences, 1)
+		assert.Equal(t, testOwnerName, pdb.OwnerReferences[0].Name)
+		assert.Equal(t, testPDBName, pdb.Name)
+		assert.Equal(t, testNamespace, pdb.Namespace)
+		assert.Equal(t, selectorLabels, pdb.Spec.Selector.MatchLabels)
+		assert.Nil(t, pdb.Spec.MinAvailable)
+		assert.Nil(t, pdb.Spec.MaxUnavailable)
+	})
+	t.Run("create pod disruption budget with minAvailable", func(t *testing.T) {
+		minAvailable := intstr.FromInt32(1)
+
+		pdb, err := Build(createStatefulSet(), testPDBName, selectorLabels, SetMinAvailable(&minAvailable))
+		require.NoError(t, err)
+		assert.Equal(t, &minAvailable, pdb.Spec.MinAvailable)
+		assert.Nil(t, pdb.Spec.MaxUnavailable)
+	})
+	t.Run("create pod disruption budget with maxUnavailable", func(t *testing.T) {
+		maxUnavailable := intstr.FromString("50%")
+
+		pdb, err := Build(createStatefulSet(), testPDBName, selectorLabels, SetMaxUnavailable(&maxUnavailable))
+		require.NoError(t, err)
+		assert.Nil(t, pdb.Spec.MinAvailable)
+		assert.Equal(t, &maxUnavailable, pdb.Spec.MaxUnavailable)
+	})
+}
@@ -0,0 +1,38 @@
+package kubesystem
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// nativeSidecarsMinorVersion is the first minor version of Kubernetes 1.x that enables native sidecars (init containers with restartPolicy Always) by default.
+const nativeSidecarsMinorVersion = 29
+
+// SupportsNativeSidecars checks if the Kubernetes version of the cluster runs native sidecars.
+// On older versions the restartPolicy of init containers is dropped, so a sidecar would block the start of the pod.
+func SupportsNativeSidecars(cfg *rest.Config) (bool, error) {
+	client, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	serverVersion, err := client.ServerVersion()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return supportsNativeSidecars(serverVersion), nil
+}
+
+func supportsNativeSidecars(serverVersion *version.Info) bool {
+	// managed clusters report versions like "29+"
+	major, _ := strconv.Atoi(strings.TrimSuffix(serverVersion.Major, "+"))
+	minor, _ := strconv.Atoi(strings.TrimSuffix(serverVersion.Minor, "+"))
+
+	return major > 1 || (major == 1 && minor >= nativeSidecarsMinorVersion)
+}
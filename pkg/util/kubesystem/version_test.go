@@ -0,0 +1,28 @@
+package kubesystem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+func TestSupportsNativeSidecars(t *testing.T) {
+	tests := []struct {
+		major, minor string
+		expected     bool
+	}{
+		{major: "1", minor: "28", expected: false},
+		{major: "1", minor: "29", expected: true},
+		{major: "1", minor: "31+", expected: true},
+		{major: "1", minor: "27+", expected: false},
+		{major: "2", minor: "0", expected: true},
+		{major: "", minor: "", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.major+"."+test.minor, func(t *testing.T) {
+			assert.Equal(t, test.expected, supportsNativeSidecars(&version.Info{Major: test.major, Minor: test.minor}))
+		})
+	}
+}
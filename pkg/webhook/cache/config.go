@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// ReadyzCheckName is the name of the readiness check that fails until the cache is synced.
+	ReadyzCheckName = "webhook-cache"
+
+	// DefaultMaxStaleness is how long the cache is still used after the last successful contact with the Kubernetes API.
+	DefaultMaxStaleness = 5 * time.Minute
+
+	heartbeatPeriod = 10 * time.Second
+
+	sourceLabel     = "source"
+	cacheSource     = "cache"
+	apiServerSource = "apiserver"
+)
+
+var (
+	log = logd.Get().WithName("webhook-cache")
+
+	cacheAgeMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "webhook_cache",
+		Name:      "age_seconds",
+		Help:      "Seconds since the webhook cache was last confirmed to be up to date with the Kubernetes API",
+	})
+
+	cacheReadsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "webhook_cache",
+		Name:      "reads_total",
+		Help:      "Number of DynaKube and Namespace reads of the webhook, by whether they were served from the cache or the Kubernetes API",
+	}, []string{sourceLabel})
+
+	watchErrorsMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "webhook_cache",
+		Name:      "watch_errors_total",
+		Help:      "Number of errors of the watches backing the webhook cache",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(cacheAgeMetric, cacheReadsMetric, watchErrorsMetric)
+}
@@ -0,0 +1,224 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// informerCache is the part of cache.Cache the Reader needs.
+type informerCache interface {
+	client.Reader
+	GetInformer(ctx context.Context, obj client.Object, opts ...ctrlcache.InformerGetOption) (ctrlcache.Informer, error)
+	Start(ctx context.Context) error
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// Reader serves the DynaKubes of the webhook namespace and the Namespaces with the injection label from an informer cache,
+// so the webhook doesn't depend on the Kubernetes API for every admission request.
+// Everything else, and objects not found in the cache, is read from the Kubernetes API directly.
+// The cache is only used if the Kubernetes API was reachable during the last maxStaleness, otherwise the reads go to the Kubernetes API again.
+type Reader struct {
+	cache     informerCache
+	apiReader client.Reader
+
+	namespace    string
+	maxStaleness time.Duration
+
+	synced      atomic.Bool
+	lastContact atomic.Int64
+	now         func() time.Time
+}
+
+var _ client.Reader = &Reader{}
+
+func New(config *rest.Config, apiReader client.Reader, namespace string, maxStaleness time.Duration) (*Reader, error) {
+	injectionLabelSelector, err := labels.NewRequirement(dtwebhook.InjectionInstanceLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	informers, err := ctrlcache.New(config, ctrlcache.Options{
+		Scheme:                      scheme.Scheme,
+		ReaderFailOnMissingInformer: true,
+		DefaultWatchErrorHandler:    watchErrorHandler,
+		ByObject: map[client.Object]ctrlcache.ByObject{
+			&dynakube.DynaKube{}: {
+				Namespaces: map[string]ctrlcache.Config{namespace: {}},
+			},
+			&corev1.Namespace{}: {
+				Label: labels.NewSelector().Add(*injectionLabelSelector),
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return newReader(informers, apiReader, namespace, maxStaleness), nil
+}
+
+func newReader(informers informerCache, apiReader client.Reader, namespace string, maxStaleness time.Duration) *Reader {
+	return &Reader{
+		cache:        informers,
+		apiReader:    apiReader,
+		namespace:    namespace,
+		maxStaleness: maxStaleness,
+		now:          time.Now,
+	}
+}
+
+// Start implements manager.Runnable, it warms up the cache and keeps track of its age until the context is done.
+func (reader *Reader) Start(ctx context.Context) error {
+	for _, obj := range []client.Object{&dynakube.DynaKube{}, &corev1.Namespace{}} {
+		if _, err := reader.cache.GetInformer(ctx, obj); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	go func() {
+		if err := reader.cache.Start(ctx); err != nil {
+			log.Error(err, "webhook cache stopped")
+		}
+	}()
+
+	if !reader.cache.WaitForCacheSync(ctx) {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		return errors.New("failed to sync the webhook cache")
+	}
+
+	reader.markContact()
+	reader.synced.Store(true)
+	log.Info("webhook cache synced")
+
+	ticker := time.NewTicker(heartbeatPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			reader.heartbeat(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, every replica needs its own cache.
+func (reader *Reader) NeedLeaderElection() bool {
+	return false
+}
+
+// ReadyzCheck implements healthz.Checker, the webhook is not ready until the cache is synced.
+func (reader *Reader) ReadyzCheck(_ *http.Request) error {
+	if !reader.synced.Load() {
+		return errors.New("webhook cache is not synced yet")
+	}
+
+	return nil
+}
+
+func (reader *Reader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if !reader.isCached(key, obj) || reader.isStale() {
+		return reader.getFromAPIServer(ctx, key, obj, opts...)
+	}
+
+	err := reader.cache.Get(ctx, key, obj, opts...)
+	if k8serrors.IsNotFound(err) {
+		// the object might be too new for the cache, or the Namespace might not have the injection label
+		return reader.getFromAPIServer(ctx, key, obj, opts...)
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	cacheReadsMetric.WithLabelValues(cacheSource).Inc()
+
+	return nil
+}
+
+// List always reads from the Kubernetes API, the cache only serves single objects.
+func (reader *Reader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return reader.apiReader.List(ctx, list, opts...)
+}
+
+func (reader *Reader) getFromAPIServer(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := reader.apiReader.Get(ctx, key, obj, opts...)
+	if err == nil || k8serrors.IsNotFound(err) {
+		reader.markContact()
+	}
+
+	cacheReadsMetric.WithLabelValues(apiServerSource).Inc()
+
+	return err
+}
+
+func (reader *Reader) isCached(key client.ObjectKey, obj client.Object) bool {
+	if !reader.synced.Load() {
+		return false
+	}
+
+	switch obj.(type) {
+	case *dynakube.DynaKube:
+		return key.Namespace == reader.namespace
+	case *corev1.Namespace:
+		return true
+	default:
+		return false
+	}
+}
+
+// isStale returns true if the Kubernetes API wasn't reachable for longer than maxStaleness, so the cache might be outdated.
+func (reader *Reader) isStale() bool {
+	age := reader.age()
+	cacheAgeMetric.Set(age.Seconds())
+
+	return age > reader.maxStaleness
+}
+
+func (reader *Reader) age() time.Duration {
+	return reader.now().Sub(time.Unix(0, reader.lastContact.Load()))
+}
+
+func (reader *Reader) markContact() {
+	reader.lastContact.Store(reader.now().UnixNano())
+	cacheAgeMetric.Set(0)
+}
+
+// heartbeat checks if the Kubernetes API is reachable, the watches of the cache can't be used for that as they don't report when they recover.
+func (reader *Reader) heartbeat(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, heartbeatPeriod)
+	defer cancel()
+
+	var namespace corev1.Namespace
+
+	if err := reader.apiReader.Get(ctx, client.ObjectKey{Name: reader.namespace}, &namespace); err != nil {
+		log.Info("Kubernetes API not reachable, serving from cache", "age", reader.age().String(), "error", err.Error())
+		cacheAgeMetric.Set(reader.age().Seconds())
+
+		return
+	}
+
+	reader.markContact()
+}
+
+func watchErrorHandler(r *toolscache.Reflector, err error) {
+	watchErrorsMetric.Inc()
+	toolscache.DefaultWatchErrorHandler(r, err)
+}
@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testNamespace    = "dynatrace"
+	testAppNamespace = "app"
+	testDynakubeName = "dynakube"
+)
+
+type fakeInformerCache struct {
+	client.Reader
+	synced bool
+}
+
+func (fakeInformerCache) GetInformer(context.Context, client.Object, ...ctrlcache.InformerGetOption) (ctrlcache.Informer, error) {
+	return nil, nil //nolint:nilnil
+}
+
+func (fakeInformerCache) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	return nil
+}
+
+func (cache fakeInformerCache) WaitForCacheSync(context.Context) bool {
+	return cache.synced
+}
+
+// failingReader simulates an unreachable Kubernetes API.
+type failingReader struct {
+	client.Reader
+}
+
+func (failingReader) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return errors.New("connection refused")
+}
+
+func TestReaderGet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads from the Kubernetes API until synced", func(t *testing.T) {
+		reader := newReader(fakeInformerCache{Reader: fake.NewClient()}, fake.NewClient(getTestDynakube()), testNamespace, DefaultMaxStaleness)
+
+		before := testutil.ToFloat64(cacheReadsMetric.WithLabelValues(apiServerSource))
+
+		var dk dynakube.DynaKube
+		require.NoError(t, reader.Get(ctx, client.ObjectKey{Name: testDynakubeName, Namespace: testNamespace}, &dk))
+		assert.InDelta(t, before+1, testutil.ToFloat64(cacheReadsMetric.WithLabelValues(apiServerSource)), 0)
+	})
+	t.Run("reads from the cache if synced, even if the Kubernetes API is not reachable", func(t *testing.T) {
+		reader := createSyncedReader(fakeInformerCache{Reader: fake.NewClient(getTestDynakube(), getTestNamespace())}, failingReader{})
+
+		before := testutil.ToFloat64(cacheReadsMetric.WithLabelValues(cacheSource))
+
+		var dk dynakube.DynaKube
+		require.NoError(t, reader.Get(ctx, client.ObjectKey{Name: testDynakubeName, Namespace: testNamespace}, &dk))
+
+		var namespace corev1.Namespace
+		require.NoError(t, reader.Get(ctx, client.ObjectKey{Name: testAppNamespace}, &namespace))
+		assert.Equal(t, testDynakubeName, namespace.Labels[dtwebhook.InjectionInstanceLabel])
+
+		assert.InDelta(t, before+2, testutil.ToFloat64(cacheReadsMetric.WithLabelValues(cacheSource)), 0)
+	})
+	t.Run("falls back to the Kubernetes API if not in cache", func(t *testing.T) {
+		unlabelledNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled"}}
+		reader := createSyncedReader(fakeInformerCache{Reader: fake.NewClient()}, fake.NewClient(unlabelledNamespace))
+
+		var namespace corev1.Namespace
+		require.NoError(t, reader.Get(ctx, client.ObjectKey{Name: unlabelledNamespace.Name}, &namespace))
+
+		var dk dynakube.DynaKube
+		err := reader.Get(ctx, client.ObjectKey{Name: testDynakubeName, Namespace: testNamespace}, &dk)
+		require.True(t, k8serrors.IsNotFound(err))
+	})
+	t.Run("reads other objects from the Kubernetes API", func(t *testing.T) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: testNamespace}}
+		reader := createSyncedReader(fakeInformerCache{Reader: fake.NewClient()}, fake.NewClient(secret))
+
+		require.NoError(t, reader.Get(ctx, client.ObjectKeyFromObject(secret), &corev1.Secret{}))
+	})
+	t.Run("stops using the cache once it is too stale", func(t *testing.T) {
+		reader := createSyncedReader(fakeInformerCache{Reader: fake.NewClient(getTestDynakube())}, failingReader{})
+		reader.now = func() time.Time { return time.Now().Add(DefaultMaxStaleness + time.Minute) }
+
+		var dk dynakube.DynaKube
+		require.Error(t, reader.Get(ctx, client.ObjectKey{Name: testDynakubeName, Namespace: testNamespace}, &dk))
+		assert.Greater(t, testutil.ToFloat64(cacheAgeMetric), DefaultMaxStaleness.Seconds())
+	})
+}
+
+func TestReaderStart(t *testing.T) {
+	t.Run("becomes ready once synced", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		reader := newReader(fakeInformerCache{Reader: fake.NewClient(), synced: true}, fake.NewClient(), testNamespace, DefaultMaxStaleness)
+
+		require.Error(t, reader.ReadyzCheck(nil))
+
+		done := make(chan error)
+
+		go func() { done <- reader.Start(ctx) }()
+
+		require.Eventually(t, func() bool { return reader.ReadyzCheck(nil) == nil }, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+	t.Run("fails if not synced", func(t *testing.T) {
+		reader := newReader(fakeInformerCache{Reader: fake.NewClient()}, fake.NewClient(), testNamespace, DefaultMaxStaleness)
+
+		require.Error(t, reader.Start(context.Background()))
+		require.Error(t, reader.ReadyzCheck(nil))
+	})
+}
+
+func TestHeartbeat(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("keeps the cache fresh if the Kubernetes API is reachable", func(t *testing.T) {
+		reader := createSyncedReader(fakeInformerCache{Reader: fake.NewClient()}, fake.NewClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}))
+		reader.lastContact.Store(0)
+
+		reader.heartbeat(ctx)
+
+		assert.False(t, reader.isStale())
+	})
+	t.Run("cache ages if the Kubernetes API is not reachable", func(t *testing.T) {
+		reader := createSyncedReader(fakeInformerCache{Reader: fake.NewClient()}, failingReader{})
+		reader.lastContact.Store(0)
+
+		reader.heartbeat(ctx)
+
+		assert.True(t, reader.isStale())
+	})
+}
+
+func createSyncedReader(informers informerCache, apiReader client.Reader) *Reader {
+	reader := newReader(informers, apiReader, testNamespace, DefaultMaxStaleness)
+	reader.markContact()
+	reader.synced.Store(true)
+
+	return reader
+}
+
+func getTestDynakube() *dynakube.DynaKube {
+	return &dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Name: testDynakubeName, Namespace: testNamespace}}
+}
+
+func getTestNamespace() *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   testAppNamespace,
+			Labels: map[string]string{dtwebhook.InjectionInstanceLabel: testDynakubeName},
+		},
+	}
+}
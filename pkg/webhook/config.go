@@ -16,6 +16,12 @@ const (
 	AnnotationOneAgentInjected = OneAgentPrefix + ".dynatrace.com/injected"
 	AnnotationOneAgentReason   = OneAgentPrefix + ".dynatrace.com/reason"
 
+	OtlpRelayPrefix = "otlp-relay"
+	// AnnotationOtlpRelayInject can be set at namespace or pod level to enable/disable the injection of the OTLP relay sidecar,
+	// the annotation of the pod takes precedence. The relay is not injected by default, and needs a telemetry service with the otlp protocol.
+	AnnotationOtlpRelayInject   = OtlpRelayPrefix + ".dynatrace.com/inject"
+	AnnotationOtlpRelayInjected = OtlpRelayPrefix + ".dynatrace.com/injected"
+
 	MetadataEnrichmentPrefix = "metadata-enrichment"
 	// AnnotationMetadataEnrichmentInject can be set at pod level to enable/disable metadata-enrichment injection.
 	AnnotationMetadataEnrichmentInject   = MetadataEnrichmentPrefix + ".dynatrace.com/inject"
@@ -41,8 +47,23 @@ const (
 	// "fail", the init container will exit with error code 1. Defaults to "silent".
 	AnnotationFailurePolicy = "oneagent.dynatrace.com/failure-policy"
 
+	// AnnotationInjectionProfile is set by the webhook to ShortLivedInjectionProfile on Pods of Jobs and CronJobs, if the DynaKube
+	// configures an environment for short-lived workloads. It can also be set on a Pod to choose the profile manually, any other
+	// value than ShortLivedInjectionProfile uses the default profile.
+	AnnotationInjectionProfile = "oneagent.dynatrace.com/injection-profile"
+
+	// ShortLivedInjectionProfile adds the environment for short-lived workloads of the DynaKube to the injected containers.
+	ShortLivedInjectionProfile = "short-lived"
+
 	AnnotationContainerInjection = "container.inject.dynatrace.com"
 
+	// AnnotationImageExcludedContainers is set by the webhook to the containers of the Pod that are excluded from injection by their image,
+	// as a JSON object mapping the container names to the reason of the exclusion.
+	AnnotationImageExcludedContainers = "dynatrace.com/image-excluded-containers"
+
+	// AnnotationInjectionPolicy is set by the webhook to the name of the InjectionPolicy that was applied to the Pod.
+	AnnotationInjectionPolicy = "dynatrace.com/injection-policy"
+
 	// DefaultInstallPath is the default directory to install the app-only OneAgent package.
 	DefaultInstallPath = "/opt/dynatrace/oneagent-paas"
 
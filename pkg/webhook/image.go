@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// IsContainerImageExcludedFromInjection checks the repository of the image against the image include and exclude patterns of the DynaKube.
+// If the container is excluded, the reason is returned as well.
+func IsContainerImageExcludedFromInjection(dk dynakube.DynaKube, image string) (bool, string) {
+	repository := imageRepository(image)
+
+	for _, pattern := range dk.FeatureInjectionImageExcludes() {
+		if matchesImagePattern(pattern, repository) {
+			return true, "image " + repository + " matches exclude pattern " + pattern
+		}
+	}
+
+	includes := dk.FeatureInjectionImageIncludes()
+	if len(includes) == 0 {
+		return false, ""
+	}
+
+	for _, pattern := range includes {
+		if matchesImagePattern(pattern, repository) {
+			return false, ""
+		}
+	}
+
+	return true, "image " + repository + " matches no include pattern"
+}
+
+// imageRepository removes the tag and digest from the image, e.g. "docker.io/istio/proxyv2:1.24@sha256:..." becomes "docker.io/istio/proxyv2".
+func imageRepository(image string) string {
+	repository, _, _ := strings.Cut(image, "@")
+
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+
+	return repository
+}
+
+// imagePatterns caches the compiled image patterns, they only come from the feature flags of the DynaKubes, so the cache stays small.
+var imagePatterns sync.Map
+
+// matchesImagePattern matches the repository against a glob pattern, "*" matches any sequence of characters (including "/") and "?" a single one.
+func matchesImagePattern(pattern, repository string) bool {
+	return compileImagePattern(pattern).MatchString(repository)
+}
+
+func compileImagePattern(pattern string) *regexp.Regexp {
+	if cached, ok := imagePatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+
+	var expression strings.Builder
+
+	expression.WriteString("^")
+
+	for _, char := range pattern {
+		switch char {
+		case '*':
+			expression.WriteString(".*")
+		case '?':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+
+	expression.WriteString("$")
+
+	// every character is either translated or quoted, so the expression is always valid
+	compiled := regexp.MustCompile(expression.String())
+	imagePatterns.Store(pattern, compiled)
+
+	return compiled
+}
+
+// reportImageExclusion adds the container and the reason of its exclusion to the AnnotationImageExcludedContainers annotation of the pod.
+func reportImageExclusion(pod *corev1.Pod, containerName, reason string) {
+	exclusions := map[string]string{}
+
+	if raw, ok := pod.Annotations[AnnotationImageExcludedContainers]; ok {
+		_ = json.Unmarshal([]byte(raw), &exclusions)
+	}
+
+	if exclusions[containerName] == reason {
+		return
+	}
+
+	exclusions[containerName] = reason
+
+	raw, err := json.Marshal(exclusions)
+	if err != nil {
+		return
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+
+	pod.Annotations[AnnotationImageExcludedContainers] = string(raw)
+}
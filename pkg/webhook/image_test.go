@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestImageRepository(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                        "nginx",
+		"nginx:1.27":                   "nginx",
+		"docker.io/istio/proxyv2:1.24": "docker.io/istio/proxyv2",
+		"registry:5000/team/app":       "registry:5000/team/app",
+		"registry:5000/team/app:v1@sha256:abcdef012": "registry:5000/team/app",
+		"quay.io/app@sha256:abcdef012":               "quay.io/app",
+	}
+
+	for image, expected := range tests {
+		t.Run(image, func(t *testing.T) {
+			assert.Equal(t, expected, imageRepository(image))
+		})
+	}
+}
+
+func TestMatchesImagePattern(t *testing.T) {
+	assert.True(t, matchesImagePattern("*istio/proxyv2", "docker.io/istio/proxyv2"))
+	assert.True(t, matchesImagePattern("*/my-team/*", "registry.example.com/my-team/backend/api"))
+	assert.True(t, matchesImagePattern("app-?", "app-1"))
+	assert.False(t, matchesImagePattern("app-?", "app-12"))
+	assert.False(t, matchesImagePattern("istio/proxyv2", "docker.io/istio/proxyv2"))
+	assert.False(t, matchesImagePattern("registry.io/*", "registryxio/app"))
+	assert.Same(t, compileImagePattern("app-?"), compileImagePattern("app-?"))
+}
+
+func TestIsContainerImageExcludedFromInjection(t *testing.T) {
+	createDynakube := func(include, exclude string) dynakube.DynaKube {
+		annotations := map[string]string{}
+		if include != "" {
+			annotations[dynakube.AnnotationFeatureInjectionImageInclude] = include
+		}
+
+		if exclude != "" {
+			annotations[dynakube.AnnotationFeatureInjectionImageExclude] = exclude
+		}
+
+		return dynakube.DynaKube{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+
+	t.Run("nothing is excluded without patterns", func(t *testing.T) {
+		excluded, reason := IsContainerImageExcludedFromInjection(createDynakube("", ""), "docker.io/istio/proxyv2:1.24")
+
+		assert.False(t, excluded)
+		assert.Empty(t, reason)
+	})
+	t.Run("exclude pattern excludes matching image", func(t *testing.T) {
+		excluded, reason := IsContainerImageExcludedFromInjection(createDynakube("", "*istio/proxyv2"), "docker.io/istio/proxyv2:1.24")
+
+		assert.True(t, excluded)
+		assert.Contains(t, reason, "*istio/proxyv2")
+	})
+	t.Run("include pattern excludes images not matching", func(t *testing.T) {
+		dk := createDynakube("*/my-team/*", "")
+
+		excluded, _ := IsContainerImageExcludedFromInjection(dk, "registry.io/my-team/app:v1")
+		assert.False(t, excluded)
+
+		excluded, reason := IsContainerImageExcludedFromInjection(dk, "registry.io/other-team/app:v1")
+		assert.True(t, excluded)
+		assert.Contains(t, reason, "no include pattern")
+	})
+	t.Run("exclude pattern wins over include pattern", func(t *testing.T) {
+		excluded, _ := IsContainerImageExcludedFromInjection(createDynakube("*/my-team/*", "*/my-team/sidecar"), "registry.io/my-team/sidecar:v1")
+
+		assert.True(t, excluded)
+	})
+}
+
+func TestNewContainersWithImagePatterns(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "app", Image: "registry.io/my-team/app:v1"},
+				{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.24"},
+			},
+		},
+	}
+	dk := dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				dynakube.AnnotationFeatureInjectionImageExclude: "*istio/proxyv2",
+			},
+		},
+	}
+	request := BaseRequest{Pod: pod, DynaKube: dk}
+
+	newContainers := request.NewContainers(func(corev1.Container) bool { return false })
+
+	require.Len(t, newContainers, 1)
+	assert.Equal(t, "app", newContainers[0].Name)
+	assert.NotContains(t, pod.Annotations, AnnotationImageExcludedContainers)
+
+	request.ReportImageExclusions()
+
+	assert.JSONEq(t,
+		`{"istio-proxy":"image docker.io/istio/proxyv2 matches exclude pattern *istio/proxyv2"}`,
+		pod.Annotations[AnnotationImageExcludedContainers])
+}
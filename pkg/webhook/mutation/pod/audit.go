@@ -0,0 +1,80 @@
+package pod
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+type injectionDecision string
+
+const (
+	decisionInjected injectionDecision = "injected"
+	decisionUpdated  injectionDecision = "updated"
+	decisionSkipped  injectionDecision = "skipped"
+	decisionFailed   injectionDecision = "failed"
+)
+
+const (
+	reasonMutated                     = "Mutated"
+	reasonNewContainersInjected       = "NewContainersInjected"
+	reasonEphemeralContainersInjected = "EphemeralContainersInjected"
+	reasonInvalidRequest              = "InvalidRequest"
+	reasonNamespaceNotMonitored       = "NamespaceNotMonitored"
+	reasonInjectionDisabled           = "InjectionDisabled"
+	reasonOcDebugPod                  = "OcDebugPod"
+	reasonAlreadyInjected             = "AlreadyInjected"
+	reasonAllContainersExcluded       = "AllContainersExcluded"
+	reasonNoMutatorEnabled            = "NoMutatorEnabled"
+	reasonMutationFailed              = "MutationFailed"
+	reasonPodNotInjected              = "PodNotInjected"
+	reasonInstallContainerNotDone     = "InstallContainerNotCompleted"
+	reasonNoNewEphemeralContainers    = "NoNewEphemeralContainers"
+
+	decisionLabel = "decision"
+	reasonLabel   = "reason"
+)
+
+var (
+	injectionDecisionsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "webhook",
+		Name:      "injection_decisions_total",
+		Help:      "Number of pod admission requests handled by the webhook, by the decision that was made and its reason",
+	}, []string{decisionLabel, reasonLabel})
+)
+
+func init() {
+	metrics.Registry.MustRegister(injectionDecisionsMetric)
+}
+
+// auditEntry is a single decision of the webhook about a pod.
+type auditEntry struct {
+	Pod      string            `json:"pod"`
+	Decision injectionDecision `json:"decision"`
+	Reason   string            `json:"reason"`
+	Message  string            `json:"message,omitempty"`
+}
+
+// audit records the decision made for a pod in the audit log, the injectionDecisionsMetric and, if enabled, the injection report of its namespace.
+func (wh *webhook) audit(namespace string, entry auditEntry) {
+	log.Info("injection decision", "podName", entry.Pod, "namespace", namespace, "decision", entry.Decision, "reason", entry.Reason, "message", entry.Message)
+
+	injectionDecisionsMetric.WithLabelValues(string(entry.Decision), entry.Reason).Inc()
+
+	wh.report.add(namespace, entry)
+}
+
+func newAuditEntry(podName string, decision injectionDecision, reason string) auditEntry {
+	return auditEntry{
+		Pod:      podName,
+		Decision: decision,
+		Reason:   reason,
+	}
+}
+
+func newFailedAuditEntry(podName string, reason string, err error) auditEntry {
+	entry := newAuditEntry(podName, decisionFailed, reason)
+	entry.Message = err.Error()
+
+	return entry
+}
@@ -0,0 +1,93 @@
+package pod
+
+import (
+	"context"
+	"testing"
+
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAudit(t *testing.T) {
+	tests := []struct {
+		name             string
+		mutators         []dtwebhook.PodMutator
+		pod              *corev1.Pod
+		objects          []client.Object
+		expectedDecision injectionDecision
+		expectedReason   string
+	}{
+		{
+			name:             "injected pod",
+			mutators:         []dtwebhook.PodMutator{createSimplePodMutatorMock(t)},
+			pod:              getTestPod(),
+			objects:          []client.Object{getTestDynakube(), getTestNamespace()},
+			expectedDecision: decisionInjected,
+			expectedReason:   reasonMutated,
+		},
+		{
+			name:             "injection disabled by annotation",
+			mutators:         []dtwebhook.PodMutator{createSimplePodMutatorMock(t)},
+			pod:              getTestPodWithInjectionDisabled(),
+			objects:          []client.Object{getTestDynakube(), getTestNamespace()},
+			expectedDecision: decisionSkipped,
+			expectedReason:   reasonInjectionDisabled,
+		},
+		{
+			name:             "oc debug pod",
+			mutators:         []dtwebhook.PodMutator{createSimplePodMutatorMock(t)},
+			pod:              getTestPodWithOcDebugPodAnnotations(),
+			objects:          []client.Object{getTestDynakube(), getTestNamespace()},
+			expectedDecision: decisionSkipped,
+			expectedReason:   reasonOcDebugPod,
+		},
+		{
+			name:             "already injected pod",
+			mutators:         []dtwebhook.PodMutator{createAlreadyInjectedPodMutatorMock(t)},
+			pod:              getTestPod(),
+			objects:          []client.Object{getTestDynakube(), getTestNamespace()},
+			expectedDecision: decisionSkipped,
+			expectedReason:   reasonAlreadyInjected,
+		},
+		{
+			name:             "failing mutator",
+			mutators:         []dtwebhook.PodMutator{createFailPodMutatorMock(t)},
+			pod:              getTestPod(),
+			objects:          []client.Object{getTestDynakube(), getTestNamespace()},
+			expectedDecision: decisionFailed,
+			expectedReason:   reasonMutationFailed,
+		},
+		{
+			name:             "missing DynaKube",
+			mutators:         []dtwebhook.PodMutator{createSimplePodMutatorMock(t)},
+			pod:              getTestPod(),
+			objects:          []client.Object{getTestNamespace()},
+			expectedDecision: decisionFailed,
+			expectedReason:   reasonInvalidRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			podWebhook := createTestWebhook(test.mutators, append(test.objects, test.pod))
+			podWebhook.report = newInjectionReport(nil, nil, testNamespaceName, testWebhookPodName)
+			metric := injectionDecisionsMetric.WithLabelValues(string(test.expectedDecision), test.expectedReason)
+			before := testutil.ToFloat64(metric)
+
+			podWebhook.Handle(context.Background(), *createTestAdmissionRequest(test.pod))
+
+			assert.InDelta(t, before+1, testutil.ToFloat64(metric), 0)
+
+			nsReport := podWebhook.report.namespaces[test.pod.Namespace]
+			require.NotNil(t, nsReport)
+			require.Len(t, nsReport.Recent, 1)
+			assert.Equal(t, test.expectedDecision, nsReport.Recent[0].Decision)
+			assert.Equal(t, test.expectedReason, nsReport.Recent[0].Reason)
+			assert.Equal(t, 1, nsReport.Reasons[test.expectedReason])
+		})
+	}
+}
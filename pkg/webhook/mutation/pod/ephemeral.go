@@ -0,0 +1,165 @@
+package pod
+
+import (
+	"strings"
+
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	ephemeralContainersSubResource = "ephemeralcontainers"
+
+	installContainerNotCompletedReason = "the " + dtwebhook.InstallContainerName + " init container hasn't completed successfully, so the volumes needed for the injection are not populated"
+)
+
+func isEphemeralContainersUpdate(request admission.Request) bool {
+	return request.Operation == admissionv1.Update && request.SubResource == ephemeralContainersSubResource
+}
+
+// handleEphemeralContainers injects into the ephemeral containers added by an update of the pods/ephemeralcontainers subresource, e.g. by `kubectl debug`.
+// Volumes and init containers can't be added to a running pod, so this only works for pods whose install init container has already populated the volumes.
+func (wh *webhook) handleEphemeralContainers(mutationRequest *dtwebhook.MutationRequest, request admission.Request) admission.Response {
+	emptyPatch := admission.Patched("")
+	podName := mutationRequest.PodName()
+
+	newContainers, err := wh.newEphemeralContainers(mutationRequest.Pod, request)
+	if err != nil {
+		wh.audit(request.Namespace, newFailedAuditEntry(podName, reasonInvalidRequest, err))
+
+		return silentErrorResponse(mutationRequest.Pod, err)
+	}
+
+	if len(newContainers) == 0 {
+		wh.audit(request.Namespace, newAuditEntry(podName, decisionSkipped, reasonNoNewEphemeralContainers))
+
+		return emptyPatch
+	}
+
+	if !wh.isInjected(mutationRequest) {
+		log.Info("pod is not injected, ephemeral containers are not mutated", "podName", podName)
+		wh.audit(request.Namespace, newAuditEntry(podName, decisionSkipped, reasonPodNotInjected))
+
+		return emptyPatch
+	}
+
+	if !isInstallContainerCompleted(mutationRequest.Pod) {
+		log.Info("can't inject into ephemeral containers", "podName", podName, "reason", installContainerNotCompletedReason)
+		wh.recorder.sendEphemeralContainerNotInjectedEvent(ephemeralContainerNames(mutationRequest.Pod, newContainers), installContainerNotCompletedReason)
+		wh.audit(request.Namespace, newAuditEntry(podName, decisionSkipped, reasonInstallContainerNotDone))
+
+		return emptyPatch
+	}
+
+	if !wh.reinvokeEphemeralContainers(mutationRequest, newContainers) {
+		log.Info("no change, all ephemeral containers already injected", "podName", podName)
+		wh.audit(request.Namespace, newAuditEntry(podName, decisionSkipped, reasonAlreadyInjected))
+
+		return emptyPatch
+	}
+
+	log.Info("injected into ephemeral containers", "podName", podName, "containers", ephemeralContainerNames(mutationRequest.Pod, newContainers))
+	wh.recorder.sendPodUpdateEvent()
+	wh.audit(request.Namespace, newAuditEntry(podName, decisionUpdated, reasonEphemeralContainersInjected))
+
+	return createResponseForPod(mutationRequest.Pod, request)
+}
+
+// newEphemeralContainers returns the indices of the ephemeral containers of the pod that are not part of the old object of the request.
+// Existing ephemeral containers can't be changed anymore, so only the new ones are mutated.
+func (wh *webhook) newEphemeralContainers(pod *corev1.Pod, request admission.Request) ([]int, error) {
+	existing := map[string]bool{}
+
+	if len(request.OldObject.Raw) > 0 {
+		var oldPod corev1.Pod
+		if err := wh.decoder.DecodeRaw(request.OldObject, &oldPod); err != nil {
+			return nil, err
+		}
+
+		for _, container := range oldPod.Spec.EphemeralContainers {
+			existing[container.Name] = true
+		}
+	}
+
+	var newContainers []int
+
+	for i, container := range pod.Spec.EphemeralContainers {
+		if !existing[container.Name] {
+			newContainers = append(newContainers, i)
+		}
+	}
+
+	return newContainers, nil
+}
+
+// reinvokeEphemeralContainers runs the reinvocation of the mutators on a copy of the pod that has the new ephemeral containers as its containers,
+// which are then copied back into the ephemeral containers of the pod.
+func (wh *webhook) reinvokeEphemeralContainers(mutationRequest *dtwebhook.MutationRequest, newContainers []int) bool {
+	pod := mutationRequest.Pod
+
+	containerView := pod.DeepCopy()
+	containerView.Spec.Containers = make([]corev1.Container, 0, len(newContainers))
+
+	for _, i := range newContainers {
+		containerView.Spec.Containers = append(containerView.Spec.Containers, corev1.Container(pod.Spec.EphemeralContainers[i].EphemeralContainerCommon))
+	}
+
+	reinvocationRequest := dtwebhook.NewMutationRequest(mutationRequest.Context, mutationRequest.Namespace, nil, containerView, mutationRequest.DynaKube).ToReinvocationRequest()
+
+	var needsUpdate bool
+
+	for _, mutator := range wh.mutators {
+		if mutator.Enabled(mutationRequest.BaseRequest) {
+			if update := mutator.Reinvoke(reinvocationRequest); update {
+				needsUpdate = true
+			}
+		}
+	}
+
+	if !needsUpdate {
+		return false
+	}
+
+	for j, i := range newContainers {
+		container := containerView.Spec.Containers[j]
+		container.VolumeMounts = withoutSubPathMounts(container.VolumeMounts)
+		pod.Spec.EphemeralContainers[i].EphemeralContainerCommon = corev1.EphemeralContainerCommon(container)
+	}
+
+	return true
+}
+
+// withoutSubPathMounts removes the volume mounts using a subPath, as they are not allowed for ephemeral containers.
+// This drops the mounts of per-container files, like /etc/ld.so.preload, the OneAgent is still loaded via the LD_PRELOAD env.
+func withoutSubPathMounts(volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+	var filtered []corev1.VolumeMount
+
+	for _, volumeMount := range volumeMounts {
+		if volumeMount.SubPath == "" && volumeMount.SubPathExpr == "" {
+			filtered = append(filtered, volumeMount)
+		}
+	}
+
+	return filtered
+}
+
+func isInstallContainerCompleted(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name == dtwebhook.InstallContainerName {
+			return status.State.Terminated != nil && status.State.Terminated.ExitCode == 0
+		}
+	}
+
+	return false
+}
+
+func ephemeralContainerNames(pod *corev1.Pod, indices []int) string {
+	names := make([]string, 0, len(indices))
+	for _, i := range indices {
+		names = append(names, pod.Spec.EphemeralContainers[i].Name)
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,188 @@
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	oamutation "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/oneagent"
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const testEphemeralContainerName = "debugger"
+
+func TestHandleEphemeralContainers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("new ephemeral container is injected", func(t *testing.T) {
+		podWebhook := createTestEphemeralWebhook()
+		oldPod := getInjectedTestPodWithStatus(true)
+		pod := withEphemeralContainer(oldPod, testEphemeralContainerName)
+
+		response := podWebhook.Handle(ctx, createTestEphemeralAdmissionRequest(pod, oldPod))
+
+		require.True(t, response.Allowed)
+		require.NotEmpty(t, response.Patches)
+
+		for _, patch := range response.Patches {
+			assert.Contains(t, patch.Path, "/spec/ephemeralContainers/0")
+		}
+
+		mutatedPod := applyPatches(t, pod, response)
+		ephemeralContainer := corev1.Container(mutatedPod.Spec.EphemeralContainers[0].EphemeralContainerCommon)
+
+		assert.NotEmpty(t, ephemeralContainer.Env)
+		assert.True(t, hasVolumeMount(ephemeralContainer, oamutation.OneAgentBinVolumeName))
+
+		for _, volumeMount := range ephemeralContainer.VolumeMounts {
+			assert.Empty(t, volumeMount.SubPath, volumeMount.Name)
+		}
+	})
+	t.Run("existing ephemeral containers are not changed", func(t *testing.T) {
+		podWebhook := createTestEphemeralWebhook()
+		oldPod := withEphemeralContainer(getInjectedTestPodWithStatus(true), testEphemeralContainerName)
+		pod := withEphemeralContainer(oldPod, "second-debugger")
+
+		response := podWebhook.Handle(ctx, createTestEphemeralAdmissionRequest(pod, oldPod))
+
+		require.NotEmpty(t, response.Patches)
+
+		for _, patch := range response.Patches {
+			assert.Contains(t, patch.Path, "/spec/ephemeralContainers/1")
+		}
+	})
+	t.Run("event is sent if install container hasn't completed", func(t *testing.T) {
+		podWebhook := createTestEphemeralWebhook()
+		oldPod := getInjectedTestPodWithStatus(false)
+		pod := withEphemeralContainer(oldPod, testEphemeralContainerName)
+
+		response := podWebhook.Handle(ctx, createTestEphemeralAdmissionRequest(pod, oldPod))
+
+		assert.Equal(t, admission.Patched(""), response)
+
+		recorder := podWebhook.recorder.recorder.(*record.FakeRecorder)
+		require.Len(t, recorder.Events, 1)
+		event := <-recorder.Events
+		assert.Contains(t, event, ephemeralContainerNotInjectedEvent)
+		assert.Contains(t, event, testEphemeralContainerName)
+	})
+	t.Run("not injected pod is ignored", func(t *testing.T) {
+		mutator := createSimplePodMutatorMock(t)
+		podWebhook := createTestWebhook([]dtwebhook.PodMutator{mutator}, []client.Object{getTestDynakube(), getTestNamespace()})
+		oldPod := getTestPod()
+		pod := withEphemeralContainer(oldPod, testEphemeralContainerName)
+
+		response := podWebhook.Handle(ctx, createTestEphemeralAdmissionRequest(pod, oldPod))
+
+		assert.Equal(t, admission.Patched(""), response)
+		mutator.AssertNotCalled(t, "Reinvoke", mock.Anything)
+		mutator.AssertNotCalled(t, "Mutate", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWithoutSubPathMounts(t *testing.T) {
+	volumeMounts := []corev1.VolumeMount{
+		{Name: "full", MountPath: "/full"},
+		{Name: "sub-path", MountPath: "/file", SubPath: "file"},
+		{Name: "sub-path-expr", MountPath: "/expr", SubPathExpr: "$(NAME)"},
+	}
+
+	assert.Equal(t, []corev1.VolumeMount{{Name: "full", MountPath: "/full"}}, withoutSubPathMounts(volumeMounts))
+}
+
+func createTestEphemeralWebhook() *webhook {
+	dk := getTestDynakube()
+	fakeClient := fake.NewClient(dk, getTestNamespace())
+
+	return &webhook{
+		apiReader:        fakeClient,
+		decoder:          admission.NewDecoder(scheme.Scheme),
+		recorder:         eventRecorder{recorder: record.NewFakeRecorder(10)},
+		webhookImage:     testImage,
+		webhookNamespace: testNamespaceName,
+		clusterID:        testClusterID,
+		mutators:         newMutators(testImage, testClusterID, testNamespaceName, fakeClient, fakeClient, fakeClient, true),
+	}
+}
+
+func getInjectedTestPodWithStatus(installContainerCompleted bool) *corev1.Pod {
+	pod := getInjectedPod()
+	pod.Annotations = map[string]string{
+		dtwebhook.AnnotationDynatraceInjected: "true",
+		dtwebhook.AnnotationOneAgentInjected:  "true",
+	}
+
+	state := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	if installContainerCompleted {
+		state = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}
+	}
+
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{Name: dtwebhook.InstallContainerName, State: state}}
+
+	return pod
+}
+
+func withEphemeralContainer(pod *corev1.Pod, name string) *corev1.Pod {
+	pod = pod.DeepCopy()
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:  name,
+			Image: "busybox",
+		},
+		TargetContainerName: "container",
+	})
+
+	return pod
+}
+
+func createTestEphemeralAdmissionRequest(pod, oldPod *corev1.Pod) admission.Request {
+	request := createTestAdmissionRequest(pod)
+	oldPodBytes, _ := json.Marshal(oldPod)
+
+	request.Operation = admissionv1.Update
+	request.SubResource = ephemeralContainersSubResource
+	request.OldObject = runtime.RawExtension{Raw: oldPodBytes}
+
+	return *request
+}
+
+func applyPatches(t *testing.T, pod *corev1.Pod, response admission.Response) *corev1.Pod {
+	patch, err := json.Marshal(response.Patches)
+	require.NoError(t, err)
+
+	original, err := json.Marshal(pod)
+	require.NoError(t, err)
+
+	decodedPatch, err := jsonpatch.DecodePatch(patch)
+	require.NoError(t, err)
+
+	patched, err := decodedPatch.Apply(original)
+	require.NoError(t, err)
+
+	var mutatedPod corev1.Pod
+	require.NoError(t, json.Unmarshal(patched, &mutatedPod))
+
+	return &mutatedPod
+}
+
+func hasVolumeMount(container corev1.Container, name string) bool {
+	for _, volumeMount := range container.VolumeMounts {
+		if volumeMount.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -31,6 +31,13 @@ func (er *eventRecorder) sendPodUpdateEvent() {
 		"Updating pod %s in namespace %s with missing containers", er.pod.GenerateName, er.pod.Namespace)
 }
 
+func (er *eventRecorder) sendEphemeralContainerNotInjectedEvent(containerNames, reason string) {
+	er.recorder.Eventf(er.dk,
+		corev1.EventTypeWarning,
+		ephemeralContainerNotInjectedEvent,
+		"Can't inject into ephemeral containers %s of pod %s in namespace %s: %s", containerNames, er.pod.Name, er.pod.Namespace, reason)
+}
+
 func (er *eventRecorder) sendMissingDynaKubeEvent(namespaceName, dynakubeName string) {
 	template := "Namespace '%s' is assigned to DynaKube instance '%s' but this instance doesn't exist"
 	er.recorder.Eventf(
@@ -30,20 +30,32 @@ func NewMutator(webhookNamespace string, client client.Client, apiReader client.
 	}
 }
 
+func (mut *Mutator) Name() string {
+	return "metadata-enrichment"
+}
+
 func (mut *Mutator) Enabled(request *dtwebhook.BaseRequest) bool {
-	enabledOnPod := maputils.GetFieldBool(request.Pod.Annotations, dtwebhook.AnnotationMetadataEnrichmentInject,
-		request.DynaKube.FeatureAutomaticInjection())
-	enabledOnDynakube := request.DynaKube.MetadataEnrichmentEnabled()
+	return mut.SkipReason(request) == ""
+}
 
-	matchesNamespace := true // if no namespace selector is configured, we just pass set this to true
+func (mut *Mutator) SkipReason(request *dtwebhook.BaseRequest) string {
+	if !request.DynaKube.MetadataEnrichmentEnabled() {
+		return "metadata-enrichment is not enabled in the DynaKube"
+	}
+
+	if !maputils.GetFieldBool(request.Pod.Annotations, dtwebhook.AnnotationMetadataEnrichmentInject, request.DynaKube.FeatureAutomaticInjection()) {
+		return "disabled via the " + dtwebhook.AnnotationMetadataEnrichmentInject + " annotation or automatic injection is turned off"
+	}
 
 	if request.DynaKube.MetadataEnrichmentNamespaceSelector().Size() > 0 {
 		selector, _ := metav1.LabelSelectorAsSelector(request.DynaKube.MetadataEnrichmentNamespaceSelector())
 
-		matchesNamespace = selector.Matches(labels.Set(request.Namespace.Labels))
+		if !selector.Matches(labels.Set(request.Namespace.Labels)) {
+			return "the namespace doesn't match the namespaceSelector of the metadata-enrichment"
+		}
 	}
 
-	return matchesNamespace && enabledOnPod && enabledOnDynakube
+	return ""
 }
 
 func (mut *Mutator) Injected(request *dtwebhook.BaseRequest) bool {
@@ -28,15 +28,42 @@ func newWorkloadInfo(partialObjectMetadata *metav1.PartialObjectMetadata) *workl
 }
 
 func (mut *Mutator) retrieveWorkload(request *dtwebhook.MutationRequest) (*workloadInfo, error) {
-	workload, err := findRootOwnerOfPod(request.Context, mut.metaClient, request.Pod, request.Namespace.Name)
+	workload, err := RetrieveWorkload(request, mut.metaClient)
 	if err != nil {
 		return nil, err
 	}
 
-	return workload, nil
+	return &workloadInfo{name: workload.Name, kind: workload.Kind}, nil
 }
 
-func findRootOwnerOfPod(ctx context.Context, clt client.Client, pod *corev1.Pod, namespace string) (*workloadInfo, error) {
+// RetrieveWorkload returns the workload (root owner) of the pod of the request. The owners are only looked up once per request,
+// the result is kept in the request for the other mutators.
+func RetrieveWorkload(request *dtwebhook.MutationRequest, clt client.Reader) (*dtwebhook.Workload, error) {
+	if request.Workload != nil {
+		return request.Workload, nil
+	}
+
+	workload, err := findRootOwnerOfPod(request.Context, clt, request.Pod, request.Namespace.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Workload = &dtwebhook.Workload{Name: workload.name, Kind: workload.kind}
+
+	return request.Workload, nil
+}
+
+// FindWorkloadKind returns the kind of the workload (root owner) of the pod in lower case, e.g. "deployment" or "cronjob".
+func FindWorkloadKind(ctx context.Context, clt client.Reader, pod *corev1.Pod, namespace string) (string, error) {
+	workload, err := findRootOwnerOfPod(ctx, clt, pod, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	return workload.kind, nil
+}
+
+func findRootOwnerOfPod(ctx context.Context, clt client.Reader, pod *corev1.Pod, namespace string) (*workloadInfo, error) {
 	podPartialMetadata := &metav1.PartialObjectMetadata{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: pod.APIVersion,
@@ -58,7 +85,7 @@ func findRootOwnerOfPod(ctx context.Context, clt client.Client, pod *corev1.Pod,
 	return newWorkloadInfo(rootOwner), nil
 }
 
-func findRootOwner(ctx context.Context, clt client.Client, childObjectMetadata *metav1.PartialObjectMetadata) (parentObjectMetadata *metav1.PartialObjectMetadata, err error) {
+func findRootOwner(ctx context.Context, clt client.Reader, childObjectMetadata *metav1.PartialObjectMetadata) (parentObjectMetadata *metav1.PartialObjectMetadata, err error) {
 	objectMetadata := childObjectMetadata.ObjectMeta
 	for _, owner := range objectMetadata.OwnerReferences {
 		if owner.Controller != nil && *owner.Controller {
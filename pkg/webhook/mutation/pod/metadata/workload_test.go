@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -230,6 +231,53 @@ func TestFindRootOwnerOfPod(t *testing.T) {
 	})
 }
 
+func TestRetrieveWorkload(t *testing.T) {
+	ownerReferences := []metav1.OwnerReference{
+		{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "test",
+			Controller: ptr.To(true),
+		},
+	}
+
+	t.Run("resolves the workload once and keeps it in the request", func(t *testing.T) {
+		deployment := &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		}
+		request := &dtwebhook.MutationRequest{
+			BaseRequest: &dtwebhook.BaseRequest{
+				Pod:       &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", OwnerReferences: ownerReferences}},
+				Namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+			},
+			Context: context.Background(),
+		}
+
+		workload, err := RetrieveWorkload(request, fake.NewClient(deployment))
+		require.NoError(t, err)
+		assert.Equal(t, &dtwebhook.Workload{Name: "test", Kind: "deployment"}, workload)
+		assert.Same(t, workload, request.Workload)
+
+		cachedWorkload, err := RetrieveWorkload(request, createFailK8sClient(t))
+		require.NoError(t, err)
+		assert.Same(t, workload, cachedWorkload)
+	})
+	t.Run("lookup error is not kept in the request", func(t *testing.T) {
+		request := &dtwebhook.MutationRequest{
+			BaseRequest: &dtwebhook.BaseRequest{
+				Pod:       &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", OwnerReferences: ownerReferences}},
+				Namespace: corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+			},
+			Context: context.Background(),
+		}
+
+		_, err := RetrieveWorkload(request, createFailK8sClient(t))
+		require.Error(t, err)
+		assert.Nil(t, request.Workload)
+	})
+}
+
 func createTestWorkloadInfo(t *testing.T) *workloadInfo {
 	t.Helper()
 
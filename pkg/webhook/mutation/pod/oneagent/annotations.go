@@ -11,6 +11,7 @@ import (
 
 type installerInfo struct {
 	flavor       string
+	arch         string
 	technologies string
 	installPath  string
 	installerURL string
@@ -0,0 +1,30 @@
+package oneagent
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getNodeArch returns the architecture of the node the pod will run on, if it is already known at admission,
+// because the pod is bound to a node or selects an architecture via its nodeSelector.
+// Otherwise it stays empty and the install container downloads the code modules for its own architecture, which matches the node it runs on.
+func (mut *Mutator) getNodeArch(request *dtwebhook.MutationRequest) string {
+	if request.Pod.Spec.NodeName != "" {
+		var node corev1.Node
+
+		err := mut.apiReader.Get(request.Context, client.ObjectKey{Name: request.Pod.Spec.NodeName}, &node)
+		if err != nil {
+			log.Info("failed to determine the architecture of the node of the pod", "podName", request.PodName(), "node", request.Pod.Spec.NodeName, "error", err.Error())
+		} else if nodeArch, ok := arch.FromLabels(node.Labels); ok {
+			return nodeArch
+		}
+	}
+
+	if nodeArch, ok := arch.FromLabels(request.Pod.Spec.NodeSelector); ok {
+		return nodeArch
+	}
+
+	return ""
+}
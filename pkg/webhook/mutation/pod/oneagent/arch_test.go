@@ -0,0 +1,68 @@
+package oneagent
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testNodeName = "test-node"
+
+func TestGetNodeArch(t *testing.T) {
+	t.Run("unknown at admission => empty", func(t *testing.T) {
+		mutator := createTestPodMutator(nil)
+		request := createTestMutationRequest(getTestDynakube(), nil, getTestNamespace(nil))
+
+		assert.Empty(t, mutator.getNodeArch(request))
+	})
+	t.Run("architecture of the node the pod is bound to", func(t *testing.T) {
+		mutator := createTestPodMutator([]client.Object{getTestNode(arch.ARMImage)})
+		request := createTestMutationRequest(getTestDynakube(), nil, getTestNamespace(nil))
+		request.Pod.Spec.NodeName = testNodeName
+		request.Pod.Spec.NodeSelector = map[string]string{arch.NodeLabel: arch.AMDImage}
+
+		assert.Equal(t, arch.ARMImage, mutator.getNodeArch(request))
+	})
+	t.Run("architecture from the nodeSelector", func(t *testing.T) {
+		mutator := createTestPodMutator(nil)
+		request := createTestMutationRequest(getTestDynakube(), nil, getTestNamespace(nil))
+		request.Pod.Spec.NodeName = testNodeName
+		request.Pod.Spec.NodeSelector = map[string]string{arch.NodeLabel: arch.PPCLEImage}
+
+		assert.Equal(t, arch.PPCLEImage, mutator.getNodeArch(request))
+	})
+	t.Run("unsupported architecture => empty", func(t *testing.T) {
+		mutator := createTestPodMutator(nil)
+		request := createTestMutationRequest(getTestDynakube(), nil, getTestNamespace(nil))
+		request.Pod.Spec.NodeSelector = map[string]string{arch.NodeLabel: "riscv64"}
+
+		assert.Empty(t, mutator.getNodeArch(request))
+	})
+	t.Run("architecture is passed to the install container", func(t *testing.T) {
+		mutator := createTestPodMutator([]client.Object{getTestInitSecret()})
+		request := createTestMutationRequest(getTestDynakube(), nil, getTestNamespace(nil))
+		request.Pod.Spec.NodeSelector = map[string]string{arch.NodeLabel: arch.S390Image}
+
+		require.NoError(t, mutator.Mutate(request.Context, request))
+
+		archEnv := env.FindEnvVar(request.InstallContainer.Env, consts.AgentInstallerArchEnv)
+		require.NotNil(t, archEnv)
+		assert.Equal(t, arch.S390Image, archEnv.Value)
+	})
+}
+
+func getTestNode(imageArch string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   testNodeName,
+			Labels: map[string]string{arch.NodeLabel: imageArch},
+		},
+	}
+}
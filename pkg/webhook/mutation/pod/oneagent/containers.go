@@ -63,4 +63,6 @@ func (mut *Mutator) addOneAgentToContainer(request *dtwebhook.ReinvocationReques
 	if dk.FeatureReadOnlyCsiVolume() {
 		addVolumeMountsForReadOnlyCSI(container)
 	}
+
+	addShortLivedProfileEnv(container, request.Pod, dk.FeatureShortLivedWorkloadEnv())
 }
@@ -74,6 +74,7 @@ func addInstallerInitEnvs(initContainer *corev1.Container, installer installerIn
 		corev1.EnvVar{Name: consts.AgentInstallerUrlEnv, Value: installer.installerURL},
 		corev1.EnvVar{Name: consts.AgentInstallerVersionEnv, Value: installer.version},
 		corev1.EnvVar{Name: consts.AgentInjectedEnv, Value: "true"},
+		corev1.EnvVar{Name: consts.AgentInstallerArchEnv, Value: installer.arch},
 	)
 }
 
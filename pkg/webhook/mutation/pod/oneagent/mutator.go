@@ -38,19 +38,32 @@ func NewMutator(image, clusterID, webhookNamespace string, client client.Client,
 	}
 }
 
+func (mut *Mutator) Name() string {
+	return "oneagent"
+}
+
 func (mut *Mutator) Enabled(request *dtwebhook.BaseRequest) bool {
-	enabledOnPod := maputils.GetFieldBool(request.Pod.Annotations, dtwebhook.AnnotationOneAgentInject, request.DynaKube.FeatureAutomaticInjection())
-	enabledOnDynakube := request.DynaKube.OneAgent().GetNamespaceSelector() != nil
+	return mut.SkipReason(request) == ""
+}
+
+func (mut *Mutator) SkipReason(request *dtwebhook.BaseRequest) string {
+	if request.DynaKube.OneAgent().GetNamespaceSelector() == nil {
+		return "no OneAgent mode with application monitoring is configured in the DynaKube"
+	}
 
-	matchesNamespaceSelector := true // if no namespace selector is configured, we just pass set this to true
+	if !maputils.GetFieldBool(request.Pod.Annotations, dtwebhook.AnnotationOneAgentInject, request.DynaKube.FeatureAutomaticInjection()) {
+		return "disabled via the " + dtwebhook.AnnotationOneAgentInject + " annotation or automatic injection is turned off"
+	}
 
 	if request.DynaKube.OneAgent().GetNamespaceSelector().Size() > 0 {
 		selector, _ := metav1.LabelSelectorAsSelector(request.DynaKube.OneAgent().GetNamespaceSelector())
 
-		matchesNamespaceSelector = selector.Matches(labels.Set(request.Namespace.Labels))
+		if !selector.Matches(labels.Set(request.Namespace.Labels)) {
+			return "the namespace doesn't match the namespaceSelector of the OneAgent"
+		}
 	}
 
-	return matchesNamespaceSelector && enabledOnPod && enabledOnDynakube
+	return ""
 }
 
 func (mut *Mutator) Injected(request *dtwebhook.BaseRequest) bool {
@@ -70,7 +83,10 @@ func (mut *Mutator) Mutate(ctx context.Context, request *dtwebhook.MutationReque
 		return err
 	}
 
+	mut.setInjectionProfile(request)
+
 	installerInfo := getInstallerInfo(request.Pod, request.DynaKube)
+	installerInfo.arch = mut.getNodeArch(request)
 	mut.addVolumes(request.Pod, request.DynaKube)
 	mut.configureInitContainer(request, installerInfo)
 	mut.mutateUserContainers(request)
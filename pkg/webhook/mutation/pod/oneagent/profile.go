@@ -0,0 +1,57 @@
+package oneagent
+
+import (
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/metadata"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// shortLivedWorkloadKinds are the workload kinds, as returned by metadata.RetrieveWorkload, whose pods run to completion.
+var shortLivedWorkloadKinds = []string{"job", "cronjob"}
+
+// setInjectionProfile marks pods of short-lived workloads with the AnnotationInjectionProfile, so the profile is also applied to
+// containers added during a reinvocation. A profile that was already set on the pod is kept.
+func (mut *Mutator) setInjectionProfile(request *dtwebhook.MutationRequest) {
+	if len(request.DynaKube.FeatureShortLivedWorkloadEnv()) == 0 {
+		return
+	}
+
+	if _, ok := request.Pod.Annotations[dtwebhook.AnnotationInjectionProfile]; ok {
+		return
+	}
+
+	workload, err := metadata.RetrieveWorkload(request, mut.apiReader)
+	if err != nil {
+		log.Info("failed to determine the workload of the pod, using the default injection profile", "podName", request.PodName(), "error", err.Error())
+
+		return
+	}
+
+	if !slices.Contains(shortLivedWorkloadKinds, workload.Kind) {
+		return
+	}
+
+	if request.Pod.Annotations == nil {
+		request.Pod.Annotations = make(map[string]string)
+	}
+
+	request.Pod.Annotations[dtwebhook.AnnotationInjectionProfile] = dtwebhook.ShortLivedInjectionProfile
+}
+
+// addShortLivedProfileEnv adds the environment for short-lived workloads to the container, if the pod uses that profile.
+func addShortLivedProfileEnv(container *corev1.Container, pod *corev1.Pod, envVars []corev1.EnvVar) {
+	if pod.Annotations[dtwebhook.AnnotationInjectionProfile] != dtwebhook.ShortLivedInjectionProfile {
+		return
+	}
+
+	for _, envVar := range envVars {
+		if env.IsIn(container.Env, envVar.Name) {
+			continue
+		}
+
+		container.Env = append(container.Env, envVar)
+	}
+}
@@ -0,0 +1,154 @@
+package oneagent
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testJobName = "test-job"
+
+func TestSetInjectionProfile(t *testing.T) {
+	t.Run("pod of a job gets short-lived profile", func(t *testing.T) {
+		mutator := createTestPodMutator([]client.Object{getTestJob()})
+		request := createTestMutationRequest(getTestShortLivedDynakube(), nil, getTestNamespace(nil))
+		request.Pod.OwnerReferences = getTestJobOwnerReferences()
+
+		mutator.setInjectionProfile(request)
+
+		assert.Equal(t, dtwebhook.ShortLivedInjectionProfile, request.Pod.Annotations[dtwebhook.AnnotationInjectionProfile])
+	})
+	t.Run("pod of a deployment keeps default profile", func(t *testing.T) {
+		mutator := createTestPodMutator(nil)
+		request := createTestMutationRequest(getTestShortLivedDynakube(), nil, getTestNamespace(nil))
+
+		mutator.setInjectionProfile(request)
+
+		assert.NotContains(t, request.Pod.Annotations, dtwebhook.AnnotationInjectionProfile)
+	})
+	t.Run("profile is only set if the DynaKube configures an environment for short-lived workloads", func(t *testing.T) {
+		mutator := createTestPodMutator([]client.Object{getTestJob()})
+		request := createTestMutationRequest(getTestDynakube(), nil, getTestNamespace(nil))
+		request.Pod.OwnerReferences = getTestJobOwnerReferences()
+
+		mutator.setInjectionProfile(request)
+
+		assert.NotContains(t, request.Pod.Annotations, dtwebhook.AnnotationInjectionProfile)
+	})
+	t.Run("workload of the request is reused", func(t *testing.T) {
+		mutator := createTestPodMutator(nil)
+		request := createTestMutationRequest(getTestShortLivedDynakube(), nil, getTestNamespace(nil))
+		request.Pod.OwnerReferences = getTestJobOwnerReferences()
+		request.Workload = &dtwebhook.Workload{Name: testJobName, Kind: "job"}
+
+		mutator.setInjectionProfile(request)
+
+		assert.Equal(t, dtwebhook.ShortLivedInjectionProfile, request.Pod.Annotations[dtwebhook.AnnotationInjectionProfile])
+	})
+	t.Run("resolved workload is kept in the request", func(t *testing.T) {
+		mutator := createTestPodMutator([]client.Object{getTestJob()})
+		request := createTestMutationRequest(getTestShortLivedDynakube(), nil, getTestNamespace(nil))
+		request.Pod.OwnerReferences = getTestJobOwnerReferences()
+
+		mutator.setInjectionProfile(request)
+
+		assert.Equal(t, &dtwebhook.Workload{Name: testJobName, Kind: "job"}, request.Workload)
+	})
+	t.Run("profile set on the pod is kept", func(t *testing.T) {
+		mutator := createTestPodMutator([]client.Object{getTestJob()})
+		request := createTestMutationRequest(getTestShortLivedDynakube(), map[string]string{dtwebhook.AnnotationInjectionProfile: "default"}, getTestNamespace(nil))
+		request.Pod.OwnerReferences = getTestJobOwnerReferences()
+
+		mutator.setInjectionProfile(request)
+
+		assert.Equal(t, "default", request.Pod.Annotations[dtwebhook.AnnotationInjectionProfile])
+	})
+}
+
+func TestAddShortLivedProfileEnv(t *testing.T) {
+	envVars := []corev1.EnvVar{{Name: "DT_LOGLEVELCON", Value: "info"}, {Name: "EXISTING", Value: "new"}}
+
+	t.Run("adds env to containers of short-lived pods", func(t *testing.T) {
+		pod := getTestPod(map[string]string{dtwebhook.AnnotationInjectionProfile: dtwebhook.ShortLivedInjectionProfile})
+		container := &corev1.Container{Env: []corev1.EnvVar{{Name: "EXISTING", Value: "old"}}}
+
+		addShortLivedProfileEnv(container, pod, envVars)
+
+		assert.Equal(t, []corev1.EnvVar{{Name: "EXISTING", Value: "old"}, {Name: "DT_LOGLEVELCON", Value: "info"}}, container.Env)
+	})
+	t.Run("no env for default profile", func(t *testing.T) {
+		container := &corev1.Container{}
+
+		addShortLivedProfileEnv(container, getTestPod(nil), envVars)
+
+		assert.Empty(t, container.Env)
+	})
+}
+
+func TestMutateShortLivedPod(t *testing.T) {
+	t.Run("containers get the configured env", func(t *testing.T) {
+		mutator := createTestPodMutator([]client.Object{getTestInitSecret(), getTestJob()})
+		request := createTestMutationRequest(getTestShortLivedDynakube(), nil, getTestNamespace(nil))
+		request.Pod.OwnerReferences = getTestJobOwnerReferences()
+
+		err := mutator.Mutate(request.Context, request)
+
+		require.NoError(t, err)
+		assert.Equal(t, dtwebhook.ShortLivedInjectionProfile, request.Pod.Annotations[dtwebhook.AnnotationInjectionProfile])
+
+		for _, container := range request.Pod.Spec.Containers {
+			assert.Contains(t, container.Env, corev1.EnvVar{Name: "DT_LOGLEVELCON", Value: "info"})
+		}
+	})
+	t.Run("pod of a job without the feature flag gets no extra env", func(t *testing.T) {
+		mutator := createTestPodMutator([]client.Object{getTestInitSecret(), getTestJob()})
+		jobRequest := createTestMutationRequest(getTestDynakube(), nil, getTestNamespace(nil))
+		jobRequest.Pod.OwnerReferences = getTestJobOwnerReferences()
+		podRequest := createTestMutationRequest(getTestDynakube(), nil, getTestNamespace(nil))
+
+		require.NoError(t, mutator.Mutate(jobRequest.Context, jobRequest))
+		require.NoError(t, mutator.Mutate(podRequest.Context, podRequest))
+
+		assert.NotContains(t, jobRequest.Pod.Annotations, dtwebhook.AnnotationInjectionProfile)
+		require.Len(t, jobRequest.Pod.Spec.Containers, len(podRequest.Pod.Spec.Containers))
+
+		for i, container := range jobRequest.Pod.Spec.Containers {
+			assert.Equal(t, podRequest.Pod.Spec.Containers[i].Env, container.Env)
+		}
+	})
+}
+
+func getTestShortLivedDynakube() *dynakube.DynaKube {
+	dk := getTestDynakube()
+	dk.Annotations[dynakube.AnnotationFeatureShortLivedWorkloadEnv] = "DT_LOGLEVELCON=info"
+
+	return dk
+}
+
+func getTestJob() *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testJobName,
+			Namespace: testNamespaceName,
+		},
+	}
+}
+
+func getTestJobOwnerReferences() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+			Name:       testJobName,
+			Controller: ptr.To(true),
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package otlprelay
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/logd"
+)
+
+var (
+	log = logd.Get().WithName("otlp-relay-pod-mutation")
+)
+
+const (
+	ContainerName = "dynatrace-otlp-relay"
+
+	configEnv = "DT_OTLP_RELAY_CONFIG"
+
+	caCrtDataName = "ca.crt"
+
+	otlpGrpcPort = 4317
+	otlpHttpPort = 4318
+
+	relayUser int64 = 1001
+)
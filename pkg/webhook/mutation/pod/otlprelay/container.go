@@ -0,0 +1,99 @@
+package otlprelay
+
+import (
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/otelc/statefulset"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+func newRelayContainer(dk dynakube.DynaKube, caPEM string) corev1.Container {
+	return corev1.Container{
+		Name:            ContainerName,
+		Image:           statefulset.Image(&dk),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		RestartPolicy:   ptr.To(corev1.ContainerRestartPolicyAlways),
+		Args:            []string{"--config=env:" + configEnv},
+		Env: []corev1.EnvVar{
+			{Name: configEnv, Value: relayConfig(dk, caPEM)},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: resources.NewResourceList("10m", "32Mi"),
+			Limits:   resources.NewResourceList("100m", "128Mi"),
+		},
+		SecurityContext: &corev1.SecurityContext{
+			ReadOnlyRootFilesystem:   ptr.To(true),
+			AllowPrivilegeEscalation: ptr.To(false),
+			Privileged:               ptr.To(false),
+			RunAsNonRoot:             ptr.To(true),
+			RunAsUser:                ptr.To(relayUser),
+			RunAsGroup:               ptr.To(relayUser),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+	}
+}
+
+// relayConfig returns the collector configuration, it receives OTLP only on localhost and exports everything to the telemetry service.
+// If the telemetry service uses TLS, caPEM is the certificate the exporter trusts.
+func relayConfig(dk dynakube.DynaKube, caPEM string) string {
+	tls := map[string]any{
+		"insecure": caPEM == "",
+	}
+	if caPEM != "" {
+		tls["ca_pem"] = caPEM
+	}
+
+	pipeline := map[string]any{
+		"receivers":  []string{"otlp"},
+		"processors": []string{"batch"},
+		"exporters":  []string{"otlp"},
+	}
+
+	config := map[string]any{
+		"receivers": map[string]any{
+			"otlp": map[string]any{
+				"protocols": map[string]any{
+					"grpc": map[string]any{"endpoint": fmt.Sprintf("127.0.0.1:%d", otlpGrpcPort)},
+					"http": map[string]any{"endpoint": fmt.Sprintf("127.0.0.1:%d", otlpHttpPort)},
+				},
+			},
+		},
+		"processors": map[string]any{
+			"batch": map[string]any{},
+		},
+		"exporters": map[string]any{
+			"otlp": map[string]any{
+				"endpoint": telemetryServiceEndpoint(dk),
+				"tls":      tls,
+			},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces":  pipeline,
+				"metrics": pipeline,
+				"logs":    pipeline,
+			},
+		},
+	}
+
+	// the config only consists of maps, strings and bools, so it can always be marshaled
+	raw, _ := yaml.Marshal(config)
+
+	return string(raw)
+}
+
+func telemetryServiceEndpoint(dk dynakube.DynaKube) string {
+	serviceName := dk.TelemetryService().GetName()
+	if dk.TelemetryService().ServiceName != "" {
+		serviceName = dk.TelemetryService().ServiceName
+	}
+
+	return fmt.Sprintf("%s.%s:%d", serviceName, dk.Namespace, otlpGrpcPort)
+}
@@ -0,0 +1,130 @@
+package otlprelay
+
+import (
+	"context"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/telemetryservice"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/container"
+	maputils "github.com/Dynatrace/dynatrace-operator/pkg/util/map"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Mutator adds an OpenTelemetry collector as native sidecar to the pod, that receives OTLP on localhost and relays it to the
+// telemetry service of the DynaKube, so the applications don't need to know where to export to.
+type Mutator struct {
+	apiReader               client.Reader
+	nativeSidecarsSupported bool
+}
+
+var (
+	_ dtwebhook.PodMutator               = &Mutator{}
+	_ dtwebhook.PodMutatorDescriber      = &Mutator{}
+	_ dtwebhook.InstallContainerOptional = &Mutator{}
+)
+
+func NewMutator(apiReader client.Reader, nativeSidecarsSupported bool) *Mutator {
+	return &Mutator{
+		apiReader:               apiReader,
+		nativeSidecarsSupported: nativeSidecarsSupported,
+	}
+}
+
+func (mut *Mutator) Name() string {
+	return "otlp-relay"
+}
+
+func (mut *Mutator) Enabled(request *dtwebhook.BaseRequest) bool {
+	return mut.SkipReason(request) == ""
+}
+
+func (mut *Mutator) SkipReason(request *dtwebhook.BaseRequest) string {
+	namespaceDefault := maputils.GetFieldBool(request.Namespace.Annotations, dtwebhook.AnnotationOtlpRelayInject, false)
+	if !maputils.GetFieldBool(request.Pod.Annotations, dtwebhook.AnnotationOtlpRelayInject, namespaceDefault) {
+		return "not enabled via the " + dtwebhook.AnnotationOtlpRelayInject + " annotation of the pod or namespace"
+	}
+
+	if !request.DynaKube.TelemetryService().IsEnabled() {
+		return "the DynaKube has no telemetry service to relay to"
+	}
+
+	if !slices.Contains(request.DynaKube.TelemetryService().Spec.GetProtocols(), telemetryservice.OtlpProtocol) {
+		return "the telemetry service of the DynaKube doesn't receive the " + string(telemetryservice.OtlpProtocol) + " protocol"
+	}
+
+	if !mut.nativeSidecarsSupported {
+		return "native sidecars are not supported by the Kubernetes version of the cluster"
+	}
+
+	return ""
+}
+
+func (mut *Mutator) Injected(request *dtwebhook.BaseRequest) bool {
+	return maputils.GetFieldBool(request.Pod.Annotations, dtwebhook.AnnotationOtlpRelayInjected, false)
+}
+
+// NeedsInstallContainer implements dtwebhook.InstallContainerOptional, the relay brings its own container.
+func (mut *Mutator) NeedsInstallContainer() bool {
+	return false
+}
+
+func (mut *Mutator) Mutate(ctx context.Context, request *dtwebhook.MutationRequest) error {
+	if container.FindInitContainerInPodSpec(&request.Pod.Spec, ContainerName) == nil {
+		caPEM, err := mut.getTelemetryServiceCA(ctx, request.DynaKube)
+		if err != nil {
+			return err
+		}
+
+		log.Info("adding OTLP relay sidecar to pod", "podName", request.PodName())
+
+		// the relay is the first init container, so it's also available to the other init containers
+		request.Pod.Spec.InitContainers = append([]corev1.Container{newRelayContainer(request.DynaKube, caPEM)}, request.Pod.Spec.InitContainers...)
+	}
+
+	setInjectedAnnotation(request.Pod)
+
+	return nil
+}
+
+// Reinvoke doesn't need to do anything, the relay is shared by all containers of the pod.
+func (mut *Mutator) Reinvoke(_ *dtwebhook.ReinvocationRequest) bool {
+	return false
+}
+
+// getTelemetryServiceCA returns the certificate the relay has to trust to export to the telemetry service, if it uses TLS.
+// The TLS secret is in the namespace of the DynaKube and can't be mounted into the pod, so the certificate is added to the relay configuration.
+// The "ca.crt" of the secret is preferred, otherwise the server certificate itself is trusted, which covers self-signed certificates.
+func (mut *Mutator) getTelemetryServiceCA(ctx context.Context, dk dynakube.DynaKube) (string, error) {
+	tlsRefName := dk.TelemetryService().TlsRefName
+	if tlsRefName == "" {
+		return "", nil
+	}
+
+	var tlsSecret corev1.Secret
+
+	err := mut.apiReader.Get(ctx, client.ObjectKey{Name: tlsRefName, Namespace: dk.Namespace}, &tlsSecret)
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to get the TLS secret %s of the telemetry service", tlsRefName)
+	}
+
+	for _, key := range []string{caCrtDataName, consts.TLSCrtDataName} {
+		if caPEM := tlsSecret.Data[key]; len(caPEM) > 0 {
+			return string(caPEM), nil
+		}
+	}
+
+	return "", errors.Errorf("the TLS secret %s of the telemetry service contains no certificate", tlsRefName)
+}
+
+func setInjectedAnnotation(pod *corev1.Pod) {
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+
+	pod.Annotations[dtwebhook.AnnotationOtlpRelayInjected] = "true"
+}
@@ -0,0 +1,248 @@
+package otlprelay
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube/telemetryservice"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	testDynakubeName  = "test-dynakube"
+	testNamespaceName = "test-namespace"
+	testTLSRefName    = "my-tls"
+	testCAPEM         = "-----BEGIN CERTIFICATE-----\nca\n-----END CERTIFICATE-----\n"
+	testServerPEM     = "-----BEGIN CERTIFICATE-----\nserver\n-----END CERTIFICATE-----\n"
+)
+
+func TestSkipReason(t *testing.T) {
+	t.Run("off by default", func(t *testing.T) {
+		request := createTestMutationRequest(getTestDynakube(), nil, nil)
+
+		assert.Contains(t, NewMutator(fake.NewClient(), true).SkipReason(request.BaseRequest), dtwebhook.AnnotationOtlpRelayInject)
+	})
+	t.Run("on via pod annotation", func(t *testing.T) {
+		request := createTestMutationRequest(getTestDynakube(), map[string]string{dtwebhook.AnnotationOtlpRelayInject: "true"}, nil)
+
+		assert.True(t, NewMutator(fake.NewClient(), true).Enabled(request.BaseRequest))
+	})
+	t.Run("on via namespace annotation", func(t *testing.T) {
+		request := createTestMutationRequest(getTestDynakube(), nil, map[string]string{dtwebhook.AnnotationOtlpRelayInject: "true"})
+
+		assert.True(t, NewMutator(fake.NewClient(), true).Enabled(request.BaseRequest))
+	})
+	t.Run("pod annotation takes precedence over namespace annotation", func(t *testing.T) {
+		request := createTestMutationRequest(getTestDynakube(),
+			map[string]string{dtwebhook.AnnotationOtlpRelayInject: "false"},
+			map[string]string{dtwebhook.AnnotationOtlpRelayInject: "true"})
+
+		assert.False(t, NewMutator(fake.NewClient(), true).Enabled(request.BaseRequest))
+	})
+	t.Run("off without telemetry service", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.TelemetryService = nil
+		request := createTestMutationRequest(dk, map[string]string{dtwebhook.AnnotationOtlpRelayInject: "true"}, nil)
+
+		assert.Contains(t, NewMutator(fake.NewClient(), true).SkipReason(request.BaseRequest), "telemetry service")
+	})
+	t.Run("off without otlp protocol in telemetry service", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.TelemetryService.Protocols = []string{string(telemetryservice.ZipkinProtocol), string(telemetryservice.JaegerProtocol)}
+		request := createTestMutationRequest(dk, map[string]string{dtwebhook.AnnotationOtlpRelayInject: "true"}, nil)
+
+		assert.Contains(t, NewMutator(fake.NewClient(), true).SkipReason(request.BaseRequest), "otlp protocol")
+	})
+	t.Run("on with otlp protocol in telemetry service", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.TelemetryService.Protocols = []string{string(telemetryservice.OtlpProtocol)}
+		request := createTestMutationRequest(dk, map[string]string{dtwebhook.AnnotationOtlpRelayInject: "true"}, nil)
+
+		assert.True(t, NewMutator(fake.NewClient(), true).Enabled(request.BaseRequest))
+	})
+	t.Run("off without native sidecar support", func(t *testing.T) {
+		request := createTestMutationRequest(getTestDynakube(), map[string]string{dtwebhook.AnnotationOtlpRelayInject: "true"}, nil)
+
+		assert.Contains(t, NewMutator(fake.NewClient(), false).SkipReason(request.BaseRequest), "native sidecars")
+	})
+}
+
+func TestMutate(t *testing.T) {
+	t.Run("adds relay as first init container", func(t *testing.T) {
+		mutator := NewMutator(fake.NewClient(), true)
+		request := createTestMutationRequest(getTestDynakube(), nil, nil)
+
+		require.NoError(t, mutator.Mutate(context.Background(), request))
+
+		require.Len(t, request.Pod.Spec.InitContainers, 2)
+		relay := request.Pod.Spec.InitContainers[0]
+		assert.Equal(t, ContainerName, relay.Name)
+		require.NotNil(t, relay.RestartPolicy)
+		assert.Equal(t, corev1.ContainerRestartPolicyAlways, *relay.RestartPolicy)
+		assert.True(t, mutator.Injected(request.BaseRequest))
+		assert.False(t, mutator.NeedsInstallContainer())
+	})
+	t.Run("adds relay only once", func(t *testing.T) {
+		mutator := NewMutator(fake.NewClient(), true)
+		request := createTestMutationRequest(getTestDynakube(), nil, nil)
+
+		require.NoError(t, mutator.Mutate(context.Background(), request))
+		require.NoError(t, mutator.Mutate(context.Background(), request))
+
+		assert.Len(t, request.Pod.Spec.InitContainers, 2)
+	})
+}
+
+func TestMutateWithTLS(t *testing.T) {
+	t.Run("relay trusts the CA of the TLS secret", func(t *testing.T) {
+		mutator := NewMutator(fake.NewClient(getTestTLSSecret(map[string][]byte{
+			caCrtDataName:         []byte(testCAPEM),
+			consts.TLSCrtDataName: []byte(testServerPEM),
+		})), true)
+		request := createTestMutationRequest(getTestTLSDynakube(), nil, nil)
+
+		require.NoError(t, mutator.Mutate(context.Background(), request))
+
+		tls := getRelayTLSConfig(t, request.Pod.Spec.InitContainers[0])
+		assert.Equal(t, false, tls["insecure"])
+		assert.Equal(t, testCAPEM, tls["ca_pem"])
+	})
+	t.Run("relay trusts the server certificate without CA", func(t *testing.T) {
+		mutator := NewMutator(fake.NewClient(getTestTLSSecret(map[string][]byte{
+			consts.TLSCrtDataName: []byte(testServerPEM),
+		})), true)
+		request := createTestMutationRequest(getTestTLSDynakube(), nil, nil)
+
+		require.NoError(t, mutator.Mutate(context.Background(), request))
+
+		tls := getRelayTLSConfig(t, request.Pod.Spec.InitContainers[0])
+		assert.Equal(t, testServerPEM, tls["ca_pem"])
+	})
+	t.Run("error without TLS secret", func(t *testing.T) {
+		mutator := NewMutator(fake.NewClient(), true)
+		request := createTestMutationRequest(getTestTLSDynakube(), nil, nil)
+
+		err := mutator.Mutate(context.Background(), request)
+
+		require.Error(t, err)
+		assert.Len(t, request.Pod.Spec.InitContainers, 1)
+		assert.False(t, mutator.Injected(request.BaseRequest))
+	})
+	t.Run("error without certificate in TLS secret", func(t *testing.T) {
+		mutator := NewMutator(fake.NewClient(getTestTLSSecret(map[string][]byte{
+			consts.TLSKeyDataName: []byte("key"),
+		})), true)
+		request := createTestMutationRequest(getTestTLSDynakube(), nil, nil)
+
+		err := mutator.Mutate(context.Background(), request)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "contains no certificate")
+	})
+}
+
+func TestRelayConfig(t *testing.T) {
+	t.Run("exports to telemetry service of the DynaKube", func(t *testing.T) {
+		config := parseRelayConfig(t, *getTestDynakube(), "")
+
+		exporter := config["exporters"].(map[string]any)["otlp"].(map[string]any)
+		assert.Equal(t, "test-dynakube-telemetry.dynatrace:4317", exporter["endpoint"])
+		assert.Equal(t, true, exporter["tls"].(map[string]any)["insecure"])
+	})
+	t.Run("exports to custom service with TLS", func(t *testing.T) {
+		dk := getTestDynakube()
+		dk.Spec.TelemetryService.ServiceName = "my-telemetry"
+		dk.Spec.TelemetryService.TlsRefName = testTLSRefName
+
+		config := parseRelayConfig(t, *dk, testCAPEM)
+
+		exporter := config["exporters"].(map[string]any)["otlp"].(map[string]any)
+		assert.Equal(t, "my-telemetry.dynatrace:4317", exporter["endpoint"])
+		assert.Equal(t, false, exporter["tls"].(map[string]any)["insecure"])
+		assert.Equal(t, testCAPEM, exporter["tls"].(map[string]any)["ca_pem"])
+	})
+	t.Run("receives only on localhost", func(t *testing.T) {
+		config := parseRelayConfig(t, *getTestDynakube(), "")
+
+		protocols := config["receivers"].(map[string]any)["otlp"].(map[string]any)["protocols"].(map[string]any)
+		assert.Equal(t, "127.0.0.1:4317", protocols["grpc"].(map[string]any)["endpoint"])
+		assert.Equal(t, "127.0.0.1:4318", protocols["http"].(map[string]any)["endpoint"])
+	})
+}
+
+func parseRelayConfig(t *testing.T, dk dynakube.DynaKube, caPEM string) map[string]any {
+	var config map[string]any
+
+	require.NoError(t, yaml.Unmarshal([]byte(relayConfig(dk, caPEM)), &config))
+
+	return config
+}
+
+func getRelayTLSConfig(t *testing.T, relay corev1.Container) map[string]any {
+	require.Len(t, relay.Env, 1)
+
+	var config map[string]any
+
+	require.NoError(t, yaml.Unmarshal([]byte(relay.Env[0].Value), &config))
+
+	return config["exporters"].(map[string]any)["otlp"].(map[string]any)["tls"].(map[string]any)
+}
+
+func getTestTLSDynakube() *dynakube.DynaKube {
+	dk := getTestDynakube()
+	dk.Spec.TelemetryService.TlsRefName = testTLSRefName
+
+	return dk
+}
+
+func getTestTLSSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testTLSRefName,
+			Namespace: "dynatrace",
+		},
+		Data: data,
+	}
+}
+
+func createTestMutationRequest(dk *dynakube.DynaKube, podAnnotations, namespaceAnnotations map[string]string) *dtwebhook.MutationRequest {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   testNamespaceName,
+			Annotations: podAnnotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers:     []corev1.Container{{Name: "app", Image: "alpine"}},
+			InitContainers: []corev1.Container{{Name: "init", Image: "alpine"}},
+		},
+	}
+	namespace := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testNamespaceName,
+			Annotations: namespaceAnnotations,
+		},
+	}
+
+	return dtwebhook.NewMutationRequest(context.Background(), namespace, nil, pod, *dk)
+}
+
+func getTestDynakube() *dynakube.DynaKube {
+	return &dynakube.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testDynakubeName,
+			Namespace: "dynatrace",
+		},
+		Spec: dynakube.DynaKubeSpec{
+			TelemetryService: &telemetryservice.Spec{},
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package pod
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/injection/policy"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// applyInjectionPolicy sets the annotations of the InjectionPolicy selecting the pod, if there is one.
+// Failing to resolve the policy doesn't prevent the injection, the pod is then injected based on its own annotations.
+func (wh *webhook) applyInjectionPolicy(ctx context.Context, mutationRequest *dtwebhook.MutationRequest) {
+	injectionPolicy, err := policy.Resolve(ctx, wh.apiReader, mutationRequest.Pod, mutationRequest.Namespace.Name)
+	if meta.IsNoMatchError(err) {
+		return // the InjectionPolicy CRD is not installed
+	} else if err != nil {
+		log.Error(err, "failed to resolve the injection policy of the pod, continuing without it", "podName", mutationRequest.PodName())
+
+		return
+	}
+
+	if injectionPolicy == nil {
+		return
+	}
+
+	log.Info("applying injection policy", "podName", mutationRequest.PodName(), "policy", injectionPolicy.Name)
+	policy.Apply(injectionPolicy, mutationRequest.Pod)
+}
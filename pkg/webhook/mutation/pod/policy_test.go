@@ -0,0 +1,53 @@
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/injectionpolicy"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestApplyInjectionPolicy(t *testing.T) {
+	ctx := context.Background()
+	testPolicy := &injectionpolicy.InjectionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Namespace: testNamespaceName},
+		Spec: injectionpolicy.InjectionPolicySpec{
+			Technologies:       []string{"java"},
+			ExcludedContainers: []string{"container"},
+		},
+	}
+
+	t.Run("policy is applied during injection", func(t *testing.T) {
+		mutator := createSimplePodMutatorMock(t)
+		podWebhook := createTestWebhook([]dtwebhook.PodMutator{mutator}, []client.Object{getTestDynakube(), getTestNamespace(), testPolicy})
+		request := createTestAdmissionRequest(getTestPod())
+
+		response := podWebhook.Handle(ctx, *request)
+		require.True(t, response.Allowed)
+
+		mutatedPod := applyPatches(t, getTestPod(), response)
+		assert.Equal(t, "test-policy", mutatedPod.Annotations[dtwebhook.AnnotationInjectionPolicy])
+		assert.Equal(t, "java", mutatedPod.Annotations[dtwebhook.AnnotationTechnologies])
+	})
+	t.Run("excluded containers of the policy are not injected", func(t *testing.T) {
+		podWebhook := createTestWebhook(nil, []client.Object{testPolicy})
+		mutationRequest := createTestMutationRequest(getTestDynakube())
+
+		podWebhook.applyInjectionPolicy(ctx, mutationRequest)
+
+		assert.False(t, podNeedsInjection(mutationRequest))
+	})
+	t.Run("no policy", func(t *testing.T) {
+		podWebhook := createTestWebhook(nil, nil)
+		mutationRequest := createTestMutationRequest(getTestDynakube())
+
+		podWebhook.applyInjectionPolicy(ctx, mutationRequest)
+
+		assert.Empty(t, mutationRequest.Pod.Annotations)
+	})
+}
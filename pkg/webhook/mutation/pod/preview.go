@@ -0,0 +1,207 @@
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta3/dynakube"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// InjectPreviewPath is the path of the read-only endpoint that previews the injection into a pod.
+	InjectPreviewPath = "/inject-preview"
+
+	// injectPreviewEnabledEnv enables the InjectPreviewPath. The endpoint doesn't authenticate its callers,
+	// everyone who can reach the webhook service can use it to learn about namespaces, DynaKubes and workloads, so it is off by default.
+	injectPreviewEnabledEnv = "INJECT_PREVIEW_ENABLED"
+
+	maxPreviewRequestSize = 1024 * 1024
+)
+
+// PreviewRequest is the body expected by the inject-preview endpoint.
+type PreviewRequest struct {
+	Pod       corev1.Pod `json:"pod"`
+	Namespace string     `json:"namespace"`
+}
+
+// PreviewResponse describes what the webhook would do with the pod of a PreviewRequest.
+type PreviewResponse struct {
+	Pod      *corev1.Pod           `json:"pod,omitempty"`
+	DynaKube string                `json:"dynakube,omitempty"`
+	Message  string                `json:"message"`
+	Patch    []jsonpatch.Operation `json:"patch,omitempty"`
+	Mutators []MutatorDecision     `json:"mutators,omitempty"`
+	Mutated  bool                  `json:"mutated"`
+}
+
+// MutatorDecision describes whether a single mutator fired and why.
+type MutatorDecision struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason,omitempty"`
+	Fired  bool   `json:"fired"`
+}
+
+type previewHandler struct {
+	webhook *webhook
+}
+
+func (handler *previewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	var previewRequest PreviewRequest
+
+	err := json.NewDecoder(io.LimitReader(r.Body, maxPreviewRequestSize)).Decode(&previewRequest)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode preview request: %s", err.Error()), http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := handler.webhook.preview(r.Context(), previewRequest)
+	if k8serrors.IsNotFound(err) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	} else if err != nil {
+		log.Error(err, "failed to preview injection")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Error(err, "failed to write preview response")
+	}
+}
+
+// preview runs the same steps as Handle on a copy of the given pod, but without sending events.
+// The mutators of the preview webhook are expected to use dry-run clients, so no objects are changed.
+func (wh *webhook) preview(ctx context.Context, previewRequest PreviewRequest) (*PreviewResponse, error) {
+	originalPod := previewRequest.Pod.DeepCopy()
+	if previewRequest.Namespace != "" {
+		originalPod.Namespace = previewRequest.Namespace
+	}
+
+	var namespace corev1.Namespace
+	if err := wh.apiReader.Get(ctx, client.ObjectKey{Name: originalPod.Namespace}, &namespace); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	dynakubeName, err := getDynakubeName(namespace)
+	if err != nil {
+		return &PreviewResponse{Message: err.Error()}, nil //nolint:nilerr
+	}
+
+	var dk dynakube.DynaKube
+	if err := wh.apiReader.Get(ctx, client.ObjectKey{Name: dynakubeName, Namespace: wh.webhookNamespace}, &dk); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response := &PreviewResponse{DynaKube: dk.Name}
+	mutationRequest := dtwebhook.NewMutationRequest(ctx, namespace, nil, originalPod.DeepCopy(), dk)
+
+	switch {
+	case !mutationRequired(mutationRequest):
+		response.Message = "injection disabled via the " + dtwebhook.AnnotationDynatraceInject + " annotation"
+	case wh.isOcDebugPod(mutationRequest.Pod):
+		response.Message = "injection skipped for OpenShift debug pod"
+	case wh.isInjected(mutationRequest):
+		response.Mutated = wh.handlePodReinvocation(mutationRequest)
+		response.Message = "pod is already injected, only new containers are updated"
+	default:
+		wh.applyInjectionPolicy(ctx, mutationRequest)
+
+		response.Mutated, err = wh.mutatePod(ctx, mutationRequest, &response.Mutators)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Message = "injection finished"
+		if !response.Mutated {
+			response.Message = "no mutation needed"
+		}
+	}
+
+	if !response.Mutated {
+		return response, nil
+	}
+
+	return response, addPatch(response, originalPod, mutationRequest.Pod)
+}
+
+func addPatch(response *PreviewResponse, originalPod, mutatedPod *corev1.Pod) error {
+	original, err := json.Marshal(originalPod)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	mutated, err := json.Marshal(mutatedPod)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	patchResponse := admission.PatchResponseFromRaw(original, mutated)
+	if patchResponse.Result != nil && patchResponse.Result.Code == http.StatusInternalServerError {
+		return errors.New(patchResponse.Result.Message)
+	}
+
+	response.Pod = mutatedPod
+	response.Patch = patchResponse.Patches
+
+	return nil
+}
+
+func addMutatorDecision(decisions *[]MutatorDecision, decision MutatorDecision) {
+	if decisions != nil {
+		*decisions = append(*decisions, decision)
+	}
+}
+
+func newSkippedDecision(mutator dtwebhook.PodMutator, request *dtwebhook.BaseRequest) MutatorDecision {
+	decision := MutatorDecision{Name: mutatorName(mutator), Reason: "not enabled for this pod"}
+
+	if describer, ok := mutator.(dtwebhook.PodMutatorDescriber); ok {
+		decision.Reason = describer.SkipReason(request)
+	}
+
+	return decision
+}
+
+func newMutatedDecision(mutator dtwebhook.PodMutator, request *dtwebhook.BaseRequest) MutatorDecision {
+	decision := MutatorDecision{Name: mutatorName(mutator), Fired: true}
+
+	// a mutator can still decide not to inject while mutating, e.g. the OneAgent mutator if the connection info is missing
+	if !mutator.Injected(request) {
+		decision.Reason = "fired but did not inject"
+
+		if reason := request.Pod.Annotations[dtwebhook.AnnotationOneAgentReason]; reason != "" {
+			decision.Reason += ": " + reason
+		}
+	}
+
+	return decision
+}
+
+func mutatorName(mutator dtwebhook.PodMutator) string {
+	if describer, ok := mutator.(dtwebhook.PodMutatorDescriber); ok {
+		return describer.Name()
+	}
+
+	return fmt.Sprintf("%T", mutator)
+}
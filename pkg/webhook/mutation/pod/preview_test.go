@@ -0,0 +1,148 @@
+package pod
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func createTestPreviewWebhook(objects ...client.Object) (*webhook, client.Client) {
+	fakeClient := fake.NewClient(objects...)
+	dryRunClient := client.NewDryRunClient(fakeClient)
+
+	return &webhook{
+		apiReader:        fakeClient,
+		decoder:          admission.NewDecoder(scheme.Scheme),
+		recorder:         eventRecorder{recorder: record.NewFakeRecorder(10)},
+		webhookImage:     testImage,
+		webhookNamespace: testNamespaceName,
+		clusterID:        testClusterID,
+		mutators:         newMutators(testImage, testClusterID, testNamespaceName, dryRunClient, fakeClient, dryRunClient, true),
+	}, fakeClient
+}
+
+func TestPreview(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("mutators fire without changing the cluster", func(t *testing.T) {
+		wh, fakeClient := createTestPreviewWebhook(getTestDynakube(), getTestNamespace())
+
+		response, err := wh.preview(ctx, PreviewRequest{Pod: *getTestPod()})
+		require.NoError(t, err)
+
+		assert.True(t, response.Mutated)
+		assert.Equal(t, testDynakubeName, response.DynaKube)
+		assert.NotEmpty(t, response.Patch)
+		require.NotNil(t, response.Pod)
+		assert.Equal(t, "true", response.Pod.Annotations[dtwebhook.AnnotationDynatraceInjected])
+
+		require.Len(t, response.Mutators, 3)
+		assert.Equal(t, "oneagent", response.Mutators[0].Name)
+		assert.True(t, response.Mutators[0].Fired)
+		assert.Contains(t, response.Mutators[0].Reason, "did not inject")
+		assert.Equal(t, "metadata-enrichment", response.Mutators[1].Name)
+		assert.False(t, response.Mutators[1].Fired)
+		assert.Contains(t, response.Mutators[1].Reason, "not enabled")
+		assert.Equal(t, "otlp-relay", response.Mutators[2].Name)
+		assert.False(t, response.Mutators[2].Fired)
+
+		var initSecret corev1.Secret
+		err = fakeClient.Get(ctx, client.ObjectKey{Name: consts.AgentInitSecretName, Namespace: testNamespaceName}, &initSecret)
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+	t.Run("namespace from request is used", func(t *testing.T) {
+		wh, _ := createTestPreviewWebhook(getTestDynakube(), getTestNamespace())
+		pod := getTestPod()
+		pod.Namespace = ""
+
+		response, err := wh.preview(ctx, PreviewRequest{Pod: *pod, Namespace: testNamespaceName})
+		require.NoError(t, err)
+		assert.True(t, response.Mutated)
+		assert.Equal(t, testNamespaceName, response.Pod.Namespace)
+	})
+	t.Run("injection disabled by annotation", func(t *testing.T) {
+		wh, _ := createTestPreviewWebhook(getTestDynakube(), getTestNamespace())
+
+		response, err := wh.preview(ctx, PreviewRequest{Pod: *getTestPodWithInjectionDisabled()})
+		require.NoError(t, err)
+		assert.False(t, response.Mutated)
+		assert.Contains(t, response.Message, dtwebhook.AnnotationDynatraceInject)
+		assert.Empty(t, response.Patch)
+		assert.Empty(t, response.Mutators)
+	})
+	t.Run("namespace without dynakube", func(t *testing.T) {
+		namespace := getTestNamespace()
+		namespace.Labels = nil
+		wh, _ := createTestPreviewWebhook(getTestDynakube(), namespace)
+
+		response, err := wh.preview(ctx, PreviewRequest{Pod: *getTestPod()})
+		require.NoError(t, err)
+		assert.False(t, response.Mutated)
+		assert.Contains(t, response.Message, "no DynaKube instance set")
+	})
+	t.Run("missing namespace", func(t *testing.T) {
+		wh, _ := createTestPreviewWebhook(getTestDynakube())
+
+		_, err := wh.preview(ctx, PreviewRequest{Pod: *getTestPod()})
+		require.Error(t, err)
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+}
+
+func TestPreviewHandler(t *testing.T) {
+	wh, _ := createTestPreviewWebhook(getTestDynakube(), getTestNamespace())
+	handler := &previewHandler{webhook: wh}
+
+	t.Run("valid request", func(t *testing.T) {
+		body, err := json.Marshal(PreviewRequest{Pod: *getTestPod()})
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, InjectPreviewPath, bytes.NewReader(body)))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var response PreviewResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.True(t, response.Mutated)
+	})
+	t.Run("only POST is allowed", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, InjectPreviewPath, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+	t.Run("invalid body", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, InjectPreviewPath, bytes.NewReader([]byte("{"))))
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+	t.Run("unknown namespace", func(t *testing.T) {
+		pod := getTestPod()
+		pod.ObjectMeta = metav1.ObjectMeta{Name: testPodName, Namespace: "unknown"}
+		body, err := json.Marshal(PreviewRequest{Pod: *pod})
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, InjectPreviewPath, bytes.NewReader(body)))
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
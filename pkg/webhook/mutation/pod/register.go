@@ -3,14 +3,17 @@ package pod
 import (
 	"context"
 	"net/http"
+	"os"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/container"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pod"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/oneagentapm"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/cache"
 	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/metadata"
 	oamutation "github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/oneagent"
+	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/mutation/pod/otlprelay"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -57,35 +60,84 @@ func registerInjectEndpoint(ctx context.Context, mgr manager.Manager, webhookNam
 		return err
 	}
 
-	mgr.GetWebhookServer().Register("/inject", &webhooks.Admission{Handler: &webhook{
-		apiReader:        apiReader,
+	nativeSidecarsSupported, err := kubesystem.SupportsNativeSidecars(kubeConfig)
+	if err != nil {
+		log.Info("failed to check if native sidecars are supported, the OTLP relay won't be injected", "error", err.Error())
+	}
+
+	// the DynaKubes and Namespaces are read on every admission request, so they are served from a cache to not depend on the Kubernetes API for each pod
+	webhookCache, err := cache.New(kubeConfig, apiReader, webhookNamespace, cache.DefaultMaxStaleness)
+	if err != nil {
+		return err
+	}
+
+	if err := mgr.Add(webhookCache); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := mgr.AddReadyzCheck(cache.ReadyzCheckName, webhookCache.ReadyzCheck); err != nil {
+		return errors.WithStack(err)
+	}
+
+	injectWebhook := &webhook{
+		apiReader:        webhookCache,
 		webhookNamespace: webhookNamespace,
 		webhookImage:     webhookPodImage,
 		deployedViaOLM:   kubesystem.IsDeployedViaOlm(*webhookPod),
 		clusterID:        clusterID,
 		recorder:         eventRecorder,
-		mutators: []dtwebhook.PodMutator{
-			oamutation.NewMutator(
-				webhookPodImage,
-				clusterID,
-				webhookNamespace,
-				kubeClient,
-				apiReader,
-			),
-			metadata.NewMutator(
-				webhookNamespace,
-				kubeClient,
-				apiReader,
-				metaClient,
-			),
-		},
-		decoder: admission.NewDecoder(mgr.GetScheme()),
-	}})
+		mutators:         newMutators(webhookPodImage, clusterID, webhookNamespace, kubeClient, apiReader, metaClient, nativeSidecarsSupported),
+		decoder:          admission.NewDecoder(mgr.GetScheme()),
+	}
+
+	if os.Getenv(injectionReportEnabledEnv) == "true" {
+		if report := newInjectionReport(kubeClient, apiReader, webhookNamespace, webhookPodName); report != nil {
+			if err := mgr.Add(report); err != nil {
+				return errors.WithStack(err)
+			}
+
+			injectWebhook.report = report
+
+			log.Info("injection report enabled")
+		}
+	}
+
+	mgr.GetWebhookServer().Register("/inject", &webhooks.Admission{Handler: injectWebhook})
 	log.Info("registered /inject endpoint")
 
+	if os.Getenv(injectPreviewEnabledEnv) != "true" {
+		return nil
+	}
+
+	// the preview must not change anything in the cluster, so the mutators only get dry-run clients
+	previewWebhook := *injectWebhook
+	previewWebhook.mutators = newMutators(webhookPodImage, clusterID, webhookNamespace, client.NewDryRunClient(kubeClient), apiReader, client.NewDryRunClient(metaClient), nativeSidecarsSupported)
+
+	mgr.GetWebhookServer().Register(InjectPreviewPath, &previewHandler{webhook: &previewWebhook})
+	log.Info("registered " + InjectPreviewPath + " endpoint")
+
 	return nil
 }
 
+func newMutators(webhookPodImage, clusterID, webhookNamespace string, kubeClient client.Client, apiReader client.Reader, metaClient client.Client, nativeSidecarsSupported bool) []dtwebhook.PodMutator {
+	return []dtwebhook.PodMutator{
+		oamutation.NewMutator(
+			webhookPodImage,
+			clusterID,
+			webhookNamespace,
+			kubeClient,
+			apiReader,
+		),
+		metadata.NewMutator(
+			webhookNamespace,
+			kubeClient,
+			apiReader,
+			metaClient,
+		),
+		otlprelay.NewMutator(apiReader, nativeSidecarsSupported),
+	}
+}
+
 func registerLivezEndpoint(mgr manager.Manager) {
 	mgr.GetWebhookServer().Register("/livez", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -0,0 +1,225 @@
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	injectionReportEnabledEnv = "INJECTION_REPORT_ENABLED"
+
+	injectionReportNamePrefix   = "dynatrace-injection-report-"
+	injectionReportLabel        = "dynatrace.com/injection-report"
+	injectionReportFlushPeriod  = 30 * time.Second
+	injectionReportRecentLength = 20
+)
+
+// namespaceReport summarizes the decisions of the webhook for the pods of a namespace since the webhook was started.
+type namespaceReport struct {
+	Injected int            `json:"injected"`
+	Updated  int            `json:"updated"`
+	Skipped  int            `json:"skipped"`
+	Failed   int            `json:"failed"`
+	Reasons  map[string]int `json:"reasons"`
+	Recent   []reportEntry  `json:"recent"`
+}
+
+type reportEntry struct {
+	Time metav1.Time `json:"time"`
+	auditEntry
+}
+
+// injectionReport collects the audited decisions per namespace and periodically writes them to a ConfigMap per namespace in the webhook namespace.
+// Each webhook replica writes its own report to the ConfigMap, using its pod name as key, so the replicas don't overwrite each other.
+// The reports of replicas whose pod no longer exists are removed on the next update.
+type injectionReport struct {
+	client    client.Client
+	apiReader client.Reader
+
+	webhookNamespace string
+	webhookPodName   string
+
+	mutex      sync.Mutex
+	namespaces map[string]*namespaceReport
+	dirty      map[string]bool
+}
+
+// newInjectionReport returns nil, which disables the report, if the pod name of the webhook is unknown, as it's the key of the report in the ConfigMaps.
+func newInjectionReport(kubeClient client.Client, apiReader client.Reader, webhookNamespace, webhookPodName string) *injectionReport {
+	if webhookPodName == "" {
+		log.Info("injection report is disabled, as the pod name of the webhook is unknown")
+
+		return nil
+	}
+
+	return &injectionReport{
+		client:           kubeClient,
+		apiReader:        apiReader,
+		webhookNamespace: webhookNamespace,
+		webhookPodName:   webhookPodName,
+		namespaces:       map[string]*namespaceReport{},
+		dirty:            map[string]bool{},
+	}
+}
+
+// add is a no-op if the report is not enabled, so the webhook can call it unconditionally.
+func (report *injectionReport) add(namespace string, entry auditEntry) {
+	if report == nil {
+		return
+	}
+
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+
+	nsReport, ok := report.namespaces[namespace]
+	if !ok {
+		nsReport = &namespaceReport{Reasons: map[string]int{}}
+		report.namespaces[namespace] = nsReport
+	}
+
+	switch entry.Decision {
+	case decisionInjected:
+		nsReport.Injected++
+	case decisionUpdated:
+		nsReport.Updated++
+	case decisionSkipped:
+		nsReport.Skipped++
+	case decisionFailed:
+		nsReport.Failed++
+	}
+
+	nsReport.Reasons[entry.Reason]++
+
+	nsReport.Recent = append(nsReport.Recent, reportEntry{Time: metav1.Now(), auditEntry: entry})
+	if len(nsReport.Recent) > injectionReportRecentLength {
+		nsReport.Recent = nsReport.Recent[len(nsReport.Recent)-injectionReportRecentLength:]
+	}
+
+	report.dirty[namespace] = true
+}
+
+// Start implements manager.Runnable, it flushes the report periodically until the context is done.
+func (report *injectionReport) Start(ctx context.Context) error {
+	ticker := time.NewTicker(injectionReportFlushPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			report.flush(context.Background())
+
+			return nil
+		case <-ticker.C:
+			report.flush(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, every replica reports its own decisions.
+func (report *injectionReport) NeedLeaderElection() bool {
+	return false
+}
+
+func (report *injectionReport) flush(ctx context.Context) {
+	for namespace, data := range report.takeDirty() {
+		if err := report.update(ctx, namespace, data); err != nil {
+			log.Info("failed to update injection report, will retry", "namespace", namespace, "error", err.Error())
+
+			report.mutex.Lock()
+			report.dirty[namespace] = true
+			report.mutex.Unlock()
+		}
+	}
+}
+
+// takeDirty returns the serialized reports of all namespaces that changed since the last flush.
+func (report *injectionReport) takeDirty() map[string]string {
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+
+	dirty := make(map[string]string, len(report.dirty))
+
+	for namespace := range report.dirty {
+		data, err := json.Marshal(report.namespaces[namespace])
+		if err != nil {
+			log.Info("failed to serialize injection report", "namespace", namespace, "error", err.Error())
+
+			continue
+		}
+
+		dirty[namespace] = string(data)
+	}
+
+	report.dirty = map[string]bool{}
+
+	return dirty
+}
+
+func (report *injectionReport) update(ctx context.Context, namespace, data string) error {
+	var configMap corev1.ConfigMap
+
+	err := report.apiReader.Get(ctx, client.ObjectKey{Name: injectionReportName(namespace), Namespace: report.webhookNamespace}, &configMap)
+	if k8serrors.IsNotFound(err) {
+		configMap = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      injectionReportName(namespace),
+				Namespace: report.webhookNamespace,
+				Labels: map[string]string{
+					labels.AppManagedByLabel: version.AppName,
+					injectionReportLabel:     namespace,
+				},
+			},
+			Data: map[string]string{report.webhookPodName: data},
+		}
+
+		return errors.WithStack(report.client.Create(ctx, &configMap))
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+
+	if err := report.pruneGoneReplicas(ctx, configMap.Data); err != nil {
+		return err
+	}
+
+	configMap.Data[report.webhookPodName] = data
+
+	return errors.WithStack(report.client.Update(ctx, &configMap))
+}
+
+// pruneGoneReplicas removes the reports of webhook replicas whose pod doesn't exist anymore, so the ConfigMap doesn't grow with every rollout.
+func (report *injectionReport) pruneGoneReplicas(ctx context.Context, data map[string]string) error {
+	for podName := range data {
+		if podName == report.webhookPodName {
+			continue
+		}
+
+		var pod corev1.Pod
+
+		err := report.apiReader.Get(ctx, client.ObjectKey{Name: podName, Namespace: report.webhookNamespace}, &pod)
+		if k8serrors.IsNotFound(err) {
+			delete(data, podName)
+		} else if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func injectionReportName(namespace string) string {
+	return injectionReportNamePrefix + namespace
+}
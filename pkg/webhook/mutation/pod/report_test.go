@@ -0,0 +1,134 @@
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testWebhookPodName = "dynatrace-webhook-1234"
+
+func TestInjectionReportAdd(t *testing.T) {
+	t.Run("nil report is disabled", func(t *testing.T) {
+		var report *injectionReport
+
+		assert.NotPanics(t, func() {
+			report.add(testNamespaceName, newAuditEntry(testPodName, decisionInjected, reasonMutated))
+		})
+	})
+	t.Run("disabled without pod name of the webhook", func(t *testing.T) {
+		report := newInjectionReport(fake.NewClient(), fake.NewClient(), testNamespaceName, "")
+
+		assert.Nil(t, report)
+		assert.NotPanics(t, func() {
+			report.add(testNamespaceName, newAuditEntry(testPodName, decisionInjected, reasonMutated))
+		})
+	})
+	t.Run("counts decisions and keeps only the recent entries", func(t *testing.T) {
+		report := newInjectionReport(nil, nil, testNamespaceName, testWebhookPodName)
+
+		report.add("app", newAuditEntry("first", decisionSkipped, reasonOcDebugPod))
+
+		for range injectionReportRecentLength {
+			report.add("app", newAuditEntry(testPodName, decisionInjected, reasonMutated))
+		}
+
+		nsReport := report.namespaces["app"]
+		require.NotNil(t, nsReport)
+		assert.Equal(t, injectionReportRecentLength, nsReport.Injected)
+		assert.Equal(t, 1, nsReport.Skipped)
+		assert.Equal(t, 1, nsReport.Reasons[reasonOcDebugPod])
+		require.Len(t, nsReport.Recent, injectionReportRecentLength)
+		assert.Equal(t, testPodName, nsReport.Recent[0].Pod)
+		assert.True(t, report.dirty["app"])
+	})
+}
+
+func TestInjectionReportFlush(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates ConfigMap per namespace", func(t *testing.T) {
+		fakeClient := fake.NewClient()
+		report := newInjectionReport(fakeClient, fakeClient, testNamespaceName, testWebhookPodName)
+
+		report.add("app", newAuditEntry(testPodName, decisionInjected, reasonMutated))
+		report.flush(ctx)
+
+		nsReport := getNamespaceReport(t, fakeClient, "app", testWebhookPodName)
+		assert.Equal(t, 1, nsReport.Injected)
+		assert.Empty(t, report.dirty)
+	})
+	t.Run("keeps the reports of other replicas", func(t *testing.T) {
+		fakeClient := fake.NewClient(getTestWebhookPod("other-replica"), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      injectionReportName("app"),
+				Namespace: testNamespaceName,
+			},
+			Data: map[string]string{"other-replica": `{"failed":1}`},
+		})
+		report := newInjectionReport(fakeClient, fakeClient, testNamespaceName, testWebhookPodName)
+
+		report.add("app", newFailedAuditEntry(testPodName, reasonMutationFailed, assert.AnError))
+		report.flush(ctx)
+
+		nsReport := getNamespaceReport(t, fakeClient, "app", testWebhookPodName)
+		assert.Equal(t, 1, nsReport.Failed)
+		assert.Equal(t, assert.AnError.Error(), nsReport.Recent[0].Message)
+
+		otherReport := getNamespaceReport(t, fakeClient, "app", "other-replica")
+		assert.Equal(t, 1, otherReport.Failed)
+	})
+	t.Run("removes the reports of replicas that are gone", func(t *testing.T) {
+		fakeClient := fake.NewClient(getTestWebhookPod("other-replica"), &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      injectionReportName("app"),
+				Namespace: testNamespaceName,
+			},
+			Data: map[string]string{
+				"other-replica": `{"failed":1}`,
+				"gone-replica":  `{"injected":1}`,
+			},
+		})
+		report := newInjectionReport(fakeClient, fakeClient, testNamespaceName, testWebhookPodName)
+
+		report.add("app", newAuditEntry(testPodName, decisionInjected, reasonMutated))
+		report.flush(ctx)
+
+		var configMap corev1.ConfigMap
+
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: injectionReportName("app"), Namespace: testNamespaceName}, &configMap))
+		assert.Len(t, configMap.Data, 2)
+		assert.Contains(t, configMap.Data, testWebhookPodName)
+		assert.Contains(t, configMap.Data, "other-replica")
+		assert.NotContains(t, configMap.Data, "gone-replica")
+	})
+}
+
+func getTestWebhookPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespaceName,
+		},
+	}
+}
+
+func getNamespaceReport(t *testing.T, reader client.Reader, namespace, key string) namespaceReport {
+	var configMap corev1.ConfigMap
+
+	require.NoError(t, reader.Get(context.Background(), client.ObjectKey{Name: injectionReportName(namespace), Namespace: testNamespaceName}, &configMap))
+	require.Contains(t, configMap.Data, key)
+
+	var nsReport namespaceReport
+
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[key]), &nsReport))
+
+	return nsReport
+}
@@ -40,6 +40,7 @@ func AddWebhookToManager(ctx context.Context, mgr manager.Manager, ns string) er
 type webhook struct {
 	decoder  admission.Decoder
 	recorder eventRecorder
+	report   *injectionReport
 
 	apiReader client.Reader
 
@@ -59,41 +60,68 @@ func (wh *webhook) Handle(ctx context.Context, request admission.Request) admiss
 	if err != nil {
 		emptyPatch.Result.Message = fmt.Sprintf("unable to inject into pod (err=%s)", err.Error())
 		log.Error(err, "building mutation request base encountered an error")
+		wh.audit(request.Namespace, newFailedAuditEntry(request.Name, reasonInvalidRequest, err))
 
 		return emptyPatch
 	}
 
 	if mutationRequest == nil {
 		emptyPatch.Result.Message = "injection into pod not required"
+		wh.audit(request.Namespace, newAuditEntry(request.Name, decisionSkipped, reasonNamespaceNotMonitored))
 
 		return emptyPatch
 	}
 
 	podName := mutationRequest.PodName()
 
-	if !mutationRequired(mutationRequest) || wh.isOcDebugPod(mutationRequest.Pod) {
+	if !mutationRequired(mutationRequest) {
+		wh.audit(request.Namespace, newAuditEntry(podName, decisionSkipped, reasonInjectionDisabled))
+
+		return emptyPatch
+	}
+
+	if wh.isOcDebugPod(mutationRequest.Pod) {
+		wh.audit(request.Namespace, newAuditEntry(podName, decisionSkipped, reasonOcDebugPod))
+
 		return emptyPatch
 	}
 
 	wh.setupEventRecorder(mutationRequest)
 
+	if isEphemeralContainersUpdate(request) {
+		return wh.handleEphemeralContainers(mutationRequest, request)
+	}
+
 	if wh.isInjected(mutationRequest) {
 		if wh.handlePodReinvocation(mutationRequest) {
 			log.Info("reinvocation policy applied", "podName", podName)
 			wh.recorder.sendPodUpdateEvent()
+			wh.audit(request.Namespace, newAuditEntry(podName, decisionUpdated, reasonNewContainersInjected))
 
 			return createResponseForPod(mutationRequest.Pod, request)
 		}
 
 		log.Info("no change, all containers already injected", "podName", podName)
+		wh.audit(request.Namespace, newAuditEntry(podName, decisionSkipped, reasonAlreadyInjected))
 
 		return emptyPatch
 	}
 
-	if err := wh.handlePodMutation(ctx, mutationRequest); err != nil {
+	wh.applyInjectionPolicy(ctx, mutationRequest)
+
+	isMutated, err := wh.handlePodMutation(ctx, mutationRequest)
+	if err != nil {
+		wh.audit(request.Namespace, newFailedAuditEntry(podName, reasonMutationFailed, err))
+
 		return silentErrorResponse(mutationRequest.Pod, err)
 	}
 
+	if !isMutated {
+		wh.audit(request.Namespace, newAuditEntry(podName, decisionSkipped, notMutatedReason(mutationRequest)))
+	} else {
+		wh.audit(request.Namespace, newAuditEntry(podName, decisionInjected, reasonMutated))
+	}
+
 	log.Info("injection finished for pod", "podName", podName, "namespace", request.Namespace)
 
 	return createResponseForPod(mutationRequest.Pod, request)
@@ -144,48 +172,86 @@ func (wh *webhook) isOcDebugPod(pod *corev1.Pod) bool {
 func podNeedsInjection(mutationRequest *dtwebhook.MutationRequest) bool {
 	needsInjection := false
 	for _, container := range mutationRequest.Pod.Spec.Containers {
-		needsInjection = needsInjection || !dtwebhook.IsContainerExcludedFromInjection(mutationRequest.DynaKube.Annotations, mutationRequest.Pod.Annotations, container.Name)
+		needsInjection = needsInjection || !mutationRequest.IsContainerExcluded(container)
 	}
 
 	return needsInjection
 }
 
-func (wh *webhook) handlePodMutation(ctx context.Context, mutationRequest *dtwebhook.MutationRequest) error {
+func (wh *webhook) handlePodMutation(ctx context.Context, mutationRequest *dtwebhook.MutationRequest) (bool, error) {
+	isMutated, err := wh.mutatePod(ctx, mutationRequest, nil)
+	if err != nil || !isMutated {
+		return false, err
+	}
+
+	wh.recorder.sendPodInjectEvent()
+
+	return true, nil
+}
+
+// notMutatedReason returns the reason why mutatePod didn't mutate the pod of the request.
+func notMutatedReason(mutationRequest *dtwebhook.MutationRequest) string {
+	if !podNeedsInjection(mutationRequest) {
+		return reasonAllContainersExcluded
+	}
+
+	return reasonNoMutatorEnabled
+}
+
+// mutatePod runs all enabled mutators on the pod of the request, if decisions is set the outcome of each mutator is added to it.
+func (wh *webhook) mutatePod(ctx context.Context, mutationRequest *dtwebhook.MutationRequest, decisions *[]MutatorDecision) (bool, error) {
 	if !podNeedsInjection(mutationRequest) {
 		log.Info("no mutation is needed, all containers are excluded from injection.")
+		mutationRequest.ReportImageExclusions()
 
-		return nil
+		return false, nil
 	}
 
 	mutationRequest.InstallContainer = createInstallInitContainerBase(wh.webhookImage, wh.clusterID, mutationRequest.Pod, mutationRequest.DynaKube)
 
 	_ = updateContainerInfo(mutationRequest.BaseRequest, mutationRequest.InstallContainer)
 
-	var isMutated bool
+	var isMutated, needsInstallContainer bool
 
 	for _, mutator := range wh.mutators {
 		if !mutator.Enabled(mutationRequest.BaseRequest) {
+			addMutatorDecision(decisions, newSkippedDecision(mutator, mutationRequest.BaseRequest))
+
 			continue
 		}
 
 		if err := mutator.Mutate(ctx, mutationRequest); err != nil {
-			return err
+			return false, err
 		}
 
+		addMutatorDecision(decisions, newMutatedDecision(mutator, mutationRequest.BaseRequest))
+
 		isMutated = true
+		needsInstallContainer = needsInstallContainer || mutatorNeedsInstallContainer(mutator)
 	}
 
 	if !isMutated {
 		log.Info("no mutation is enabled")
 
-		return nil
+		return false, nil
 	}
 
-	addInitContainerToPod(mutationRequest.Pod, mutationRequest.InstallContainer)
-	wh.recorder.sendPodInjectEvent()
+	if needsInstallContainer {
+		addInitContainerToPod(mutationRequest.Pod, mutationRequest.InstallContainer)
+	}
+
+	mutationRequest.ReportImageExclusions()
 	setDynatraceInjectedAnnotation(mutationRequest)
 
-	return nil
+	return true, nil
+}
+
+func mutatorNeedsInstallContainer(mutator dtwebhook.PodMutator) bool {
+	if optional, ok := mutator.(dtwebhook.InstallContainerOptional); ok {
+		return optional.NeedsInstallContainer()
+	}
+
+	return true
 }
 
 func (wh *webhook) handlePodReinvocation(mutationRequest *dtwebhook.MutationRequest) bool {
@@ -207,6 +273,10 @@ func (wh *webhook) handlePodReinvocation(mutationRequest *dtwebhook.MutationRequ
 		}
 	}
 
+	if needsUpdate {
+		mutationRequest.ReportImageExclusions()
+	}
+
 	return needsUpdate
 }
 
@@ -223,8 +223,9 @@ func TestHandlePodMutation(t *testing.T) {
 		podWebhook := createTestWebhook([]dtwebhook.PodMutator{mutator1, mutator2}, nil)
 		mutationRequest := createTestMutationRequest(dk)
 
-		err := podWebhook.handlePodMutation(context.Background(), mutationRequest)
+		isMutated, err := podWebhook.handlePodMutation(context.Background(), mutationRequest)
 		require.NoError(t, err)
+		assert.True(t, isMutated)
 		assert.NotNil(t, mutationRequest.InstallContainer)
 
 		require.Len(t, mutationRequest.Pod.Spec.InitContainers, 2)
@@ -260,8 +261,9 @@ func TestHandlePodMutation(t *testing.T) {
 		podWebhook := createTestWebhook([]dtwebhook.PodMutator{sadMutator, happyMutator}, nil)
 		mutationRequest := createTestMutationRequest(dk)
 
-		err := podWebhook.handlePodMutation(context.Background(), mutationRequest)
+		isMutated, err := podWebhook.handlePodMutation(context.Background(), mutationRequest)
 		require.Error(t, err)
+		assert.False(t, isMutated)
 		assert.NotNil(t, mutationRequest.InstallContainer)
 		assert.Len(t, mutationRequest.Pod.Spec.InitContainers, 1)
 		assert.NotEqual(t, "true", mutationRequest.Pod.Annotations[dtwebhook.AnnotationDynatraceInjected])
@@ -270,6 +272,53 @@ func TestHandlePodMutation(t *testing.T) {
 		happyMutator.AssertNotCalled(t, "Enabled", mock.Anything)
 		happyMutator.AssertNotCalled(t, "Mutate", mock.Anything, mock.Anything)
 	})
+	t.Run("should not add initContainer if no mutator needs it, annotation added", func(t *testing.T) {
+		mutator := noInstallContainerMutator{createSimplePodMutatorMock(t)}
+		dk := getTestDynakube()
+		podWebhook := createTestWebhook([]dtwebhook.PodMutator{mutator}, nil)
+		mutationRequest := createTestMutationRequest(dk)
+
+		isMutated, err := podWebhook.handlePodMutation(context.Background(), mutationRequest)
+		require.NoError(t, err)
+		assert.True(t, isMutated)
+		assert.Len(t, mutationRequest.Pod.Spec.InitContainers, 1)
+		assert.Equal(t, "true", mutationRequest.Pod.Annotations[dtwebhook.AnnotationDynatraceInjected])
+	})
+	t.Run("should add initContainer if any mutator needs it", func(t *testing.T) {
+		optionalMutator := noInstallContainerMutator{createSimplePodMutatorMock(t)}
+		mutator := createSimplePodMutatorMock(t)
+		dk := getTestDynakube()
+		podWebhook := createTestWebhook([]dtwebhook.PodMutator{optionalMutator, mutator}, nil)
+		mutationRequest := createTestMutationRequest(dk)
+
+		isMutated, err := podWebhook.handlePodMutation(context.Background(), mutationRequest)
+		require.NoError(t, err)
+		assert.True(t, isMutated)
+		assert.Len(t, mutationRequest.Pod.Spec.InitContainers, 2)
+	})
+	t.Run("should report containers excluded by their image, annotation added", func(t *testing.T) {
+		mutator := createSimplePodMutatorMock(t)
+		dk := getTestDynakube()
+		dk.Annotations = map[string]string{dynakube.AnnotationFeatureInjectionImageExclude: "*istio/proxyv2"}
+		podWebhook := createTestWebhook([]dtwebhook.PodMutator{mutator}, nil)
+		mutationRequest := createTestMutationRequest(dk)
+		mutationRequest.Pod.Spec.Containers = append(mutationRequest.Pod.Spec.Containers, corev1.Container{Name: "istio-proxy", Image: "docker.io/istio/proxyv2:1.24"})
+
+		isMutated, err := podWebhook.handlePodMutation(context.Background(), mutationRequest)
+		require.NoError(t, err)
+		assert.True(t, isMutated)
+		assert.JSONEq(t,
+			`{"istio-proxy":"image docker.io/istio/proxyv2 matches exclude pattern *istio/proxyv2"}`,
+			mutationRequest.Pod.Annotations[dtwebhook.AnnotationImageExcludedContainers])
+	})
+}
+
+type noInstallContainerMutator struct {
+	*webhookmock.PodMutator
+}
+
+func (noInstallContainerMutator) NeedsInstallContainer() bool {
+	return false
 }
 
 func TestHandlePodReinvocation(t *testing.T) {
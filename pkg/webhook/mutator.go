@@ -25,6 +25,21 @@ type PodMutator interface {
 	Reinvoke(request *ReinvocationRequest) bool
 }
 
+// PodMutatorDescriber can be implemented by a PodMutator to describe its decisions, e.g. in an injection preview.
+type PodMutatorDescriber interface {
+	// Name returns a short, human-readable name of the mutator.
+	Name() string
+
+	// SkipReason returns why the mutator is not enabled for the given request, or an empty string if it is enabled.
+	SkipReason(request *BaseRequest) string
+}
+
+// InstallContainerOptional can be implemented by a PodMutator whose mutation doesn't depend on the install init container,
+// so the install init container is only added to the pod if another mutator needs it.
+type InstallContainerOptional interface {
+	NeedsInstallContainer() bool
+}
+
 // BaseRequest is the base request for all mutation requests
 type BaseRequest struct {
 	Pod       *corev1.Pod
@@ -45,7 +60,7 @@ func (req *BaseRequest) NewContainers(isInjected func(corev1.Container) bool) (n
 
 	for i := range req.Pod.Spec.Containers {
 		container := &req.Pod.Spec.Containers[i]
-		if IsContainerExcludedFromInjection(req.DynaKube.Annotations, req.Pod.Annotations, container.Name) {
+		if req.IsContainerExcluded(*container) {
 			continue
 		}
 
@@ -59,6 +74,31 @@ func (req *BaseRequest) NewContainers(isInjected func(corev1.Container) bool) (n
 	return
 }
 
+// IsContainerExcluded returns true if the container is excluded from injection by its name or by its image.
+func (req *BaseRequest) IsContainerExcluded(container corev1.Container) bool {
+	if IsContainerExcludedFromInjection(req.DynaKube.Annotations, req.Pod.Annotations, container.Name) {
+		return true
+	}
+
+	excluded, _ := IsContainerImageExcludedFromInjection(req.DynaKube, container.Image)
+
+	return excluded
+}
+
+// ReportImageExclusions adds the containers of the pod that are excluded from injection by their image to the
+// AnnotationImageExcludedContainers annotation, it is meant to be called once the pod was mutated.
+func (req *BaseRequest) ReportImageExclusions() {
+	for _, container := range req.Pod.Spec.Containers {
+		if IsContainerExcludedFromInjection(req.DynaKube.Annotations, req.Pod.Annotations, container.Name) {
+			continue
+		}
+
+		if excluded, reason := IsContainerImageExcludedFromInjection(req.DynaKube, container.Image); excluded {
+			reportImageExclusion(req.Pod, container.Name, reason)
+		}
+	}
+}
+
 // MutationRequest contains all the information needed to mutate a pod
 // It is meant to be passed into each mutator, so that they can mutate the elements in the way they need to,
 // and after passing it in to all the mutator the request will have the final state which can be used to mutate the pod.
@@ -66,6 +106,16 @@ type MutationRequest struct {
 	*BaseRequest
 	Context          context.Context
 	InstallContainer *corev1.Container
+
+	// Workload is the workload (root owner) of the pod, it is resolved once by the first mutator that needs it
+	// and shared with the other mutators of the request. It is nil until then.
+	Workload *Workload
+}
+
+// Workload is the workload (root owner) of a pod, the kind is in lower case, e.g. "deployment" or "cronjob".
+type Workload struct {
+	Name string
+	Kind string
 }
 
 // ReinvocationRequest contains all the information needed to reinvoke a pod
@@ -6,6 +6,8 @@ import (
 	bytes "bytes"
 	context "context"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	rest "k8s.io/client-go/rest"
@@ -96,6 +98,79 @@ func (_c *Executor_Exec_Call) RunAndReturn(run func(context.Context, *rest.Confi
 	return _c
 }
 
+// ExecWithStdin provides a mock function with given fields: ctx, config, podName, podNamespace, containerName, command, stdin
+func (_m *Executor) ExecWithStdin(ctx context.Context, config *rest.Config, podName string, podNamespace string, containerName string, command []string, stdin io.Reader) (*bytes.Buffer, *bytes.Buffer, error) {
+	ret := _m.Called(ctx, config, podName, podNamespace, containerName, command, stdin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecWithStdin")
+	}
+
+	var r0 *bytes.Buffer
+	var r1 *bytes.Buffer
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *rest.Config, string, string, string, []string, io.Reader) (*bytes.Buffer, *bytes.Buffer, error)); ok {
+		return rf(ctx, config, podName, podNamespace, containerName, command, stdin)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *rest.Config, string, string, string, []string, io.Reader) *bytes.Buffer); ok {
+		r0 = rf(ctx, config, podName, podNamespace, containerName, command, stdin)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bytes.Buffer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *rest.Config, string, string, string, []string, io.Reader) *bytes.Buffer); ok {
+		r1 = rf(ctx, config, podName, podNamespace, containerName, command, stdin)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*bytes.Buffer)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *rest.Config, string, string, string, []string, io.Reader) error); ok {
+		r2 = rf(ctx, config, podName, podNamespace, containerName, command, stdin)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Executor_ExecWithStdin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecWithStdin'
+type Executor_ExecWithStdin_Call struct {
+	*mock.Call
+}
+
+// ExecWithStdin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - config *rest.Config
+//   - podName string
+//   - podNamespace string
+//   - containerName string
+//   - command []string
+//   - stdin io.Reader
+func (_e *Executor_Expecter) ExecWithStdin(ctx interface{}, config interface{}, podName interface{}, podNamespace interface{}, containerName interface{}, command interface{}, stdin interface{}) *Executor_ExecWithStdin_Call {
+	return &Executor_ExecWithStdin_Call{Call: _e.mock.On("ExecWithStdin", ctx, config, podName, podNamespace, containerName, command, stdin)}
+}
+
+func (_c *Executor_ExecWithStdin_Call) Run(run func(ctx context.Context, config *rest.Config, podName string, podNamespace string, containerName string, command []string, stdin io.Reader)) *Executor_ExecWithStdin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*rest.Config), args[2].(string), args[3].(string), args[4].(string), args[5].([]string), args[6].(io.Reader))
+	})
+	return _c
+}
+
+func (_c *Executor_ExecWithStdin_Call) Return(stdOut *bytes.Buffer, stdErr *bytes.Buffer, err error) *Executor_ExecWithStdin_Call {
+	_c.Call.Return(stdOut, stdErr, err)
+	return _c
+}
+
+func (_c *Executor_ExecWithStdin_Call) RunAndReturn(run func(context.Context, *rest.Config, string, string, string, []string, io.Reader) (*bytes.Buffer, *bytes.Buffer, error)) *Executor_ExecWithStdin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewExecutor creates a new instance of Executor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewExecutor(t interface {